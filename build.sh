@@ -43,17 +43,26 @@ go build ./tools/impact || exit 255
 mkdir -p .tmp/geonet-meta/config/rinex || exit 255
 go build ./tools/rinexml || exit 255
 
-./rinexml -install ./install -network ./network -output .tmp/geonet-meta/config/gnsssitexml
+./rinexml -base . -output .tmp/geonet-meta/config/gnsssitexml
 
 mkdir -p .tmp/geonet-meta/config/gloria || exit 255
 go build ./tools/gloria || exit 255
 
-./gloria --install install --network network -output .tmp/geonet-meta/config/gloria
+./gloria -base . -output .tmp/geonet-meta/config/gloria
 
 mkdir -p .tmp/geonet-meta/config/sit || exit 255
 go build ./tools/sit || exit 255
 
-./sit -install install -network network -asset assets -output .tmp/geonet-meta/config/sit
+./sit -base . -asset assets -output .tmp/geonet-meta/config/sit
+
+go build ./tools/respcheck || exit 255
+
+./respcheck -baseline tools/respcheck/baseline.txt
+
+go build ./tools/respimport || exit 255
+go build ./tools/sacpz || exit 255
+go build ./tools/tidefit || exit 255
+go build ./tools/detide || exit 255
 
 exit $errcount
 
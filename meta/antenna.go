@@ -1,7 +1,6 @@
 package meta
 
 import (
-	"fmt"
 	"sort"
 	"strconv"
 	"strings"
@@ -19,9 +18,24 @@ const (
 	antennaAzimuth
 	antennaStart
 	antennaEnd
-	antennaLast
 )
 
+var antennaSchema = schema{
+	version: 1,
+	columns: []column{
+		antennaMake:    {name: "Make"},
+		antennaModel:   {name: "Model"},
+		antennaSerial:  {name: "Serial"},
+		antennaMark:    {name: "Mark"},
+		antennaHeight:  {name: "Height", kind: numberKind},
+		antennaNorth:   {name: "North", kind: numberKind},
+		antennaEast:    {name: "East", kind: numberKind},
+		antennaAzimuth: {name: "Azimuth", kind: numberKind},
+		antennaStart:   {name: "Start Date"},
+		antennaEnd:     {name: "End Date"},
+	},
+}
+
 type InstalledAntenna struct {
 	Install
 	Offset
@@ -32,35 +46,54 @@ type InstalledAntenna struct {
 
 type InstalledAntennaList []InstalledAntenna
 
-func (a InstalledAntennaList) Len() int           { return len(a) }
-func (a InstalledAntennaList) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
-func (a InstalledAntennaList) Less(i, j int) bool { return a[i].Install.less(a[j].Install) }
+func (a InstalledAntennaList) Len() int                          { return len(a) }
+func (a InstalledAntennaList) Swap(i, j int)                     { a[i], a[j] = a[j], a[i] }
+func (a InstalledAntennaList) Less(i, j int) bool                { return a[i].Install.less(a[j].Install) }
+func (a InstalledAntennaList) schema() schema                    { return antennaSchema }
+func (a InstalledAntennaList) MarshalJSON() ([]byte, error)      { return marshalJSON(a) }
+func (a *InstalledAntennaList) UnmarshalJSON(b []byte) error     { return unmarshalJSON(b, a) }
+func (a InstalledAntennaList) MarshalYAML() (interface{}, error) { return marshalYAML(a) }
+func (a *InstalledAntennaList) UnmarshalYAML(f func(interface{}) error) error {
+	return unmarshalYAML(f, a)
+}
+
+func (a InstalledAntenna) MarshalJSON() ([]byte, error) {
+	return marshalRecordJSON(InstalledAntennaList{a})
+}
+func (a *InstalledAntenna) UnmarshalJSON(b []byte) error {
+	var l InstalledAntennaList
+	if err := unmarshalRecordJSON(b, &l); err != nil {
+		return err
+	}
+	*a = l[0]
+	return nil
+}
+func (a InstalledAntenna) MarshalYAML() (interface{}, error) {
+	return marshalRecordYAML(InstalledAntennaList{a})
+}
+func (a *InstalledAntenna) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var l InstalledAntennaList
+	if err := unmarshalRecordYAML(unmarshal, &l); err != nil {
+		return err
+	}
+	*a = l[0]
+	return nil
+}
 
 func (a InstalledAntennaList) encode() [][]string {
-	data := [][]string{{
-		"Make",
-		"Model",
-		"Serial",
-		"Mark",
-		"Height",
-		"North",
-		"East",
-		"Azimuth",
-		"Start Date",
-		"End Date",
-	}}
+	data := [][]string{antennaSchema.header()}
 	for _, v := range a {
 		data = append(data, []string{
 			strings.TrimSpace(v.Make),
 			strings.TrimSpace(v.Model),
 			strings.TrimSpace(v.Serial),
 			strings.TrimSpace(v.Mark),
-			strconv.FormatFloat(v.Vertical, 'g', -1, 64),
-			strconv.FormatFloat(v.North, 'g', -1, 64),
-			strconv.FormatFloat(v.East, 'g', -1, 64),
+			formatNullable(v.Vertical, v.nullVertical),
+			formatNullable(v.North, v.nullNorth),
+			formatNullable(v.East, v.nullEast),
 			strconv.FormatFloat(v.Azimuth, 'g', -1, 64),
 			v.Start.Format(DateTimeFormat),
-			v.End.Format(DateTimeFormat),
+			formatEnd(v.End),
 		})
 	}
 	return data
@@ -69,34 +102,43 @@ func (a InstalledAntennaList) encode() [][]string {
 func (a *InstalledAntennaList) decode(data [][]string) error {
 	var antennas []InstalledAntenna
 	if len(data) > 1 {
-		for _, d := range data[1:] {
-			if len(d) != antennaLast {
-				return fmt.Errorf("incorrect number of installed antenna fields")
-			}
-			var err error
+		fields, err := antennaSchema.fields(data[0])
+		if err != nil {
+			return err
+		}
+		var errs DecodeErrors
+		for i, row := range data[1:] {
+			d := fields.remap(row)
 
 			var height, north, east float64
-			if height, err = strconv.ParseFloat(d[antennaHeight], 64); err != nil {
-				return err
+			var nullHeight, nullNorth, nullEast bool
+			if height, nullHeight, err = parseNullable(d[antennaHeight]); err != nil {
+				errs = append(errs, fields.invalid(i+1, d, antennaHeight, err))
+				continue
 			}
-			if north, err = strconv.ParseFloat(d[antennaNorth], 64); err != nil {
-				return err
+			if north, nullNorth, err = parseNullable(d[antennaNorth]); err != nil {
+				errs = append(errs, fields.invalid(i+1, d, antennaNorth, err))
+				continue
 			}
-			if east, err = strconv.ParseFloat(d[antennaEast], 64); err != nil {
-				return err
+			if east, nullEast, err = parseNullable(d[antennaEast]); err != nil {
+				errs = append(errs, fields.invalid(i+1, d, antennaEast, err))
+				continue
 			}
 
 			var azimuth float64
 			if azimuth, err = strconv.ParseFloat(d[antennaAzimuth], 64); err != nil {
-				return err
+				errs = append(errs, fields.invalid(i+1, d, antennaAzimuth, err))
+				continue
 			}
 
 			var start, end time.Time
 			if start, err = time.Parse(DateTimeFormat, d[antennaStart]); err != nil {
-				return err
+				errs = append(errs, fields.invalid(i+1, d, antennaStart, err))
+				continue
 			}
-			if end, err = time.Parse(DateTimeFormat, d[antennaEnd]); err != nil {
-				return err
+			if end, err = parseEnd(d[antennaEnd]); err != nil {
+				errs = append(errs, fields.invalid(i+1, d, antennaEnd, err))
+				continue
 			}
 
 			antennas = append(antennas, InstalledAntenna{
@@ -115,12 +157,20 @@ func (a *InstalledAntennaList) decode(data [][]string) error {
 					Vertical: height,
 					North:    north,
 					East:     east,
+
+					nullVertical: nullHeight,
+					nullNorth:    nullNorth,
+					nullEast:     nullEast,
 				},
 				Mark:    strings.TrimSpace(d[antennaMark]),
 				Azimuth: azimuth,
 			})
 		}
 
+		if errs != nil {
+			return errs
+		}
+
 		*a = InstalledAntennaList(antennas)
 	}
 	return nil
@@ -1,7 +1,6 @@
 package meta
 
 import (
-	"fmt"
 	"sort"
 	"strings"
 )
@@ -18,17 +17,53 @@ const (
 	assetSerial
 	assetNumber
 	assetNotes
-	assetLast
 )
 
+var assetSchema = schema{
+	version: 1,
+	columns: []column{
+		assetMake:   {name: "Make"},
+		assetModel:  {name: "Model"},
+		assetSerial: {name: "Serial"},
+		assetNumber: {name: "Number"},
+		assetNotes:  {name: "Notes", optional: true},
+	},
+}
+
 type AssetList []Asset
 
-func (a AssetList) Len() int           { return len(a) }
-func (a AssetList) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
-func (a AssetList) Less(i, j int) bool { return a[i].Equipment.Less(a[j].Equipment) }
+func (a AssetList) Len() int                          { return len(a) }
+func (a AssetList) Swap(i, j int)                     { a[i], a[j] = a[j], a[i] }
+func (a AssetList) Less(i, j int) bool                { return a[i].Equipment.Less(a[j].Equipment) }
+func (a AssetList) schema() schema                    { return assetSchema }
+func (a AssetList) MarshalJSON() ([]byte, error)      { return marshalJSON(a) }
+func (a *AssetList) UnmarshalJSON(b []byte) error     { return unmarshalJSON(b, a) }
+func (a AssetList) MarshalYAML() (interface{}, error) { return marshalYAML(a) }
+func (a *AssetList) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalYAML(unmarshal, a)
+}
+
+func (a Asset) MarshalJSON() ([]byte, error) { return marshalRecordJSON(AssetList{a}) }
+func (a *Asset) UnmarshalJSON(b []byte) error {
+	var l AssetList
+	if err := unmarshalRecordJSON(b, &l); err != nil {
+		return err
+	}
+	*a = l[0]
+	return nil
+}
+func (a Asset) MarshalYAML() (interface{}, error) { return marshalRecordYAML(AssetList{a}) }
+func (a *Asset) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var l AssetList
+	if err := unmarshalRecordYAML(unmarshal, &l); err != nil {
+		return err
+	}
+	*a = l[0]
+	return nil
+}
 
 func (a AssetList) encode() [][]string {
-	data := [][]string{{"Make", "Model", "Serial", "Number", "Notes"}}
+	data := [][]string{assetSchema.header()}
 	for _, v := range a {
 		data = append(data, []string{
 			v.Make,
@@ -44,10 +79,12 @@ func (a AssetList) encode() [][]string {
 func (a *AssetList) decode(data [][]string) error {
 	var assets []Asset
 	if len(data) > 1 {
-		for _, d := range data[1:] {
-			if len(d) != assetLast {
-				return fmt.Errorf("incorrect number of asset fields")
-			}
+		fields, err := assetSchema.fields(data[0])
+		if err != nil {
+			return err
+		}
+		for _, row := range data[1:] {
+			d := fields.remap(row)
 			assets = append(assets, Asset{
 				Equipment: Equipment{
 					Make:   strings.TrimSpace(d[assetMake]),
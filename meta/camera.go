@@ -1,7 +1,6 @@
 package meta
 
 import (
-	"fmt"
 	"sort"
 	"strconv"
 	"strings"
@@ -21,9 +20,26 @@ const (
 	installedCameraStart
 	installedCameraEnd
 	installedCameraNotes
-	installedCameraLast
 )
 
+var installedCameraSchema = schema{
+	version: 1,
+	columns: []column{
+		installedCameraMake:    {name: "Make"},
+		installedCameraModel:   {name: "Model"},
+		installedCameraSerial:  {name: "Serial"},
+		installedCameraMount:   {name: "Mount"},
+		installedCameraDip:     {name: "Dip", kind: numberKind},
+		installedCameraAzimuth: {name: "Azimuth", kind: numberKind},
+		installedCameraHeight:  {name: "Height", kind: numberKind},
+		installedCameraNorth:   {name: "North", kind: numberKind},
+		installedCameraEast:    {name: "East", kind: numberKind},
+		installedCameraStart:   {name: "Start Date"},
+		installedCameraEnd:     {name: "End Date"},
+		installedCameraNotes:   {name: "Notes", optional: true},
+	},
+}
+
 type InstalledCamera struct {
 	Install
 	Orientation
@@ -35,25 +51,42 @@ type InstalledCamera struct {
 
 type InstalledCameraList []InstalledCamera
 
-func (a InstalledCameraList) Len() int           { return len(a) }
-func (a InstalledCameraList) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
-func (a InstalledCameraList) Less(i, j int) bool { return a[i].Install.less(a[j].Install) }
+func (a InstalledCameraList) Len() int                          { return len(a) }
+func (a InstalledCameraList) Swap(i, j int)                     { a[i], a[j] = a[j], a[i] }
+func (a InstalledCameraList) Less(i, j int) bool                { return a[i].Install.less(a[j].Install) }
+func (a InstalledCameraList) schema() schema                    { return installedCameraSchema }
+func (a InstalledCameraList) MarshalJSON() ([]byte, error)      { return marshalJSON(a) }
+func (a *InstalledCameraList) UnmarshalJSON(b []byte) error     { return unmarshalJSON(b, a) }
+func (a InstalledCameraList) MarshalYAML() (interface{}, error) { return marshalYAML(a) }
+func (a *InstalledCameraList) UnmarshalYAML(f func(interface{}) error) error {
+	return unmarshalYAML(f, a)
+}
+
+func (a InstalledCamera) MarshalJSON() ([]byte, error) {
+	return marshalRecordJSON(InstalledCameraList{a})
+}
+func (a *InstalledCamera) UnmarshalJSON(b []byte) error {
+	var l InstalledCameraList
+	if err := unmarshalRecordJSON(b, &l); err != nil {
+		return err
+	}
+	*a = l[0]
+	return nil
+}
+func (a InstalledCamera) MarshalYAML() (interface{}, error) {
+	return marshalRecordYAML(InstalledCameraList{a})
+}
+func (a *InstalledCamera) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var l InstalledCameraList
+	if err := unmarshalRecordYAML(unmarshal, &l); err != nil {
+		return err
+	}
+	*a = l[0]
+	return nil
+}
 
 func (a InstalledCameraList) encode() [][]string {
-	data := [][]string{{
-		"Make",
-		"Model",
-		"Serial",
-		"Mount",
-		"Dip",
-		"Azimuth",
-		"Height",
-		"North",
-		"East",
-		"Start Date",
-		"End Date",
-		"Notes",
-	}}
+	data := [][]string{installedCameraSchema.header()}
 	for _, v := range a {
 		data = append(data, []string{
 			strings.TrimSpace(v.Make),
@@ -62,11 +95,11 @@ func (a InstalledCameraList) encode() [][]string {
 			strings.TrimSpace(v.Mount),
 			strconv.FormatFloat(v.Dip, 'g', -1, 64),
 			strconv.FormatFloat(v.Azimuth, 'g', -1, 64),
-			strconv.FormatFloat(v.Vertical, 'g', -1, 64),
-			strconv.FormatFloat(v.North, 'g', -1, 64),
-			strconv.FormatFloat(v.East, 'g', -1, 64),
+			formatNullable(v.Vertical, v.nullVertical),
+			formatNullable(v.North, v.nullNorth),
+			formatNullable(v.East, v.nullEast),
 			v.Start.Format(DateTimeFormat),
-			v.End.Format(DateTimeFormat),
+			formatEnd(v.End),
 			strings.TrimSpace(v.Notes),
 		})
 	}
@@ -76,37 +109,47 @@ func (a InstalledCameraList) encode() [][]string {
 func (a *InstalledCameraList) decode(data [][]string) error {
 	var cameras []InstalledCamera
 	if len(data) > 1 {
-		for _, d := range data[1:] {
-			if len(d) != installedCameraLast {
-				return fmt.Errorf("incorrect number of installed camera fields")
-			}
-			var err error
+		fields, err := installedCameraSchema.fields(data[0])
+		if err != nil {
+			return err
+		}
+		var errs DecodeErrors
+		for i, row := range data[1:] {
+			d := fields.remap(row)
 
 			var dip, azimuth float64
 			if dip, err = strconv.ParseFloat(d[installedCameraDip], 64); err != nil {
-				return err
+				errs = append(errs, fields.invalid(i+1, d, installedCameraDip, err))
+				continue
 			}
 			if azimuth, err = strconv.ParseFloat(d[installedCameraAzimuth], 64); err != nil {
-				return err
+				errs = append(errs, fields.invalid(i+1, d, installedCameraAzimuth, err))
+				continue
 			}
 
 			var height, north, east float64
-			if height, err = strconv.ParseFloat(d[installedCameraHeight], 64); err != nil {
-				return err
+			var nullHeight, nullNorth, nullEast bool
+			if height, nullHeight, err = parseNullable(d[installedCameraHeight]); err != nil {
+				errs = append(errs, fields.invalid(i+1, d, installedCameraHeight, err))
+				continue
 			}
-			if north, err = strconv.ParseFloat(d[installedCameraNorth], 64); err != nil {
-				return err
+			if north, nullNorth, err = parseNullable(d[installedCameraNorth]); err != nil {
+				errs = append(errs, fields.invalid(i+1, d, installedCameraNorth, err))
+				continue
 			}
-			if east, err = strconv.ParseFloat(d[installedCameraEast], 64); err != nil {
-				return err
+			if east, nullEast, err = parseNullable(d[installedCameraEast]); err != nil {
+				errs = append(errs, fields.invalid(i+1, d, installedCameraEast, err))
+				continue
 			}
 
 			var start, end time.Time
 			if start, err = time.Parse(DateTimeFormat, d[installedCameraStart]); err != nil {
-				return err
+				errs = append(errs, fields.invalid(i+1, d, installedCameraStart, err))
+				continue
 			}
-			if end, err = time.Parse(DateTimeFormat, d[installedCameraEnd]); err != nil {
-				return err
+			if end, err = parseEnd(d[installedCameraEnd]); err != nil {
+				errs = append(errs, fields.invalid(i+1, d, installedCameraEnd, err))
+				continue
 			}
 
 			cameras = append(cameras, InstalledCamera{
@@ -129,12 +172,20 @@ func (a *InstalledCameraList) decode(data [][]string) error {
 					Vertical: height,
 					North:    north,
 					East:     east,
+
+					nullVertical: nullHeight,
+					nullNorth:    nullNorth,
+					nullEast:     nullEast,
 				},
 				Mount: strings.TrimSpace(d[installedCameraMount]),
 				Notes: strings.TrimSpace(d[installedCameraNotes]),
 			})
 		}
 
+		if errs != nil {
+			return errs
+		}
+
 		*a = InstalledCameraList(cameras)
 	}
 	return nil
@@ -1,7 +1,6 @@
 package meta
 
 import (
-	"fmt"
 	"sort"
 	"strings"
 	"time"
@@ -14,9 +13,20 @@ const (
 	connectionRole
 	connectionStart
 	connectionEnd
-	connectionLast
 )
 
+var connectionSchema = schema{
+	version: 1,
+	columns: []column{
+		connectionStation:  {name: "Station"},
+		connectionLocation: {name: "Location"},
+		connectionPlace:    {name: "Place"},
+		connectionRole:     {name: "Role"},
+		connectionStart:    {name: "Start Date"},
+		connectionEnd:      {name: "End Date"},
+	},
+}
+
 type Connection struct {
 	Span
 
@@ -51,19 +61,38 @@ func (c Connection) less(con Connection) bool {
 
 type ConnectionList []Connection
 
-func (c ConnectionList) Len() int           { return len(c) }
-func (c ConnectionList) Swap(i, j int)      { c[i], c[j] = c[j], c[i] }
-func (c ConnectionList) Less(i, j int) bool { return c[i].less(c[j]) }
+func (c ConnectionList) Len() int                          { return len(c) }
+func (c ConnectionList) Swap(i, j int)                     { c[i], c[j] = c[j], c[i] }
+func (c ConnectionList) Less(i, j int) bool                { return c[i].less(c[j]) }
+func (c ConnectionList) schema() schema                    { return connectionSchema }
+func (c ConnectionList) MarshalJSON() ([]byte, error)      { return marshalJSON(c) }
+func (c *ConnectionList) UnmarshalJSON(b []byte) error     { return unmarshalJSON(b, c) }
+func (c ConnectionList) MarshalYAML() (interface{}, error) { return marshalYAML(c) }
+func (c *ConnectionList) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalYAML(unmarshal, c)
+}
+
+func (c Connection) MarshalJSON() ([]byte, error) { return marshalRecordJSON(ConnectionList{c}) }
+func (c *Connection) UnmarshalJSON(b []byte) error {
+	var l ConnectionList
+	if err := unmarshalRecordJSON(b, &l); err != nil {
+		return err
+	}
+	*c = l[0]
+	return nil
+}
+func (c Connection) MarshalYAML() (interface{}, error) { return marshalRecordYAML(ConnectionList{c}) }
+func (c *Connection) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var l ConnectionList
+	if err := unmarshalRecordYAML(unmarshal, &l); err != nil {
+		return err
+	}
+	*c = l[0]
+	return nil
+}
 
 func (c ConnectionList) encode() [][]string {
-	data := [][]string{{
-		"Station",
-		"Location",
-		"Place",
-		"Role",
-		"Start Date",
-		"End Date",
-	}}
+	data := [][]string{connectionSchema.header()}
 	for _, v := range c {
 		data = append(data, []string{
 			strings.TrimSpace(v.Station),
@@ -71,7 +100,7 @@ func (c ConnectionList) encode() [][]string {
 			strings.TrimSpace(v.Place),
 			strings.TrimSpace(v.Role),
 			v.Start.Format(DateTimeFormat),
-			v.End.Format(DateTimeFormat),
+			formatEnd(v.End),
 		})
 	}
 	return data
@@ -80,18 +109,22 @@ func (c ConnectionList) encode() [][]string {
 func (c *ConnectionList) decode(data [][]string) error {
 	var connections []Connection
 	if len(data) > 1 {
-		for _, v := range data[1:] {
-			if len(v) != connectionLast {
-				return fmt.Errorf("incorrect number of installed connection fields")
-			}
-			var err error
+		fields, err := connectionSchema.fields(data[0])
+		if err != nil {
+			return err
+		}
+		var errs DecodeErrors
+		for i, row := range data[1:] {
+			v := fields.remap(row)
 
 			var start, end time.Time
 			if start, err = time.Parse(DateTimeFormat, v[connectionStart]); err != nil {
-				return err
+				errs = append(errs, fields.invalid(i+1, v, connectionStart, err))
+				continue
 			}
-			if end, err = time.Parse(DateTimeFormat, v[connectionEnd]); err != nil {
-				return err
+			if end, err = parseEnd(v[connectionEnd]); err != nil {
+				errs = append(errs, fields.invalid(i+1, v, connectionEnd, err))
+				continue
 			}
 
 			connections = append(connections, Connection{
@@ -106,6 +139,10 @@ func (c *ConnectionList) decode(data [][]string) error {
 			})
 		}
 
+		if errs != nil {
+			return errs
+		}
+
 		*c = ConnectionList(connections)
 	}
 	return nil
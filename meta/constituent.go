@@ -1,7 +1,6 @@
 package meta
 
 import (
-	"fmt"
 	"sort"
 	"strconv"
 	"strings"
@@ -13,9 +12,19 @@ const (
 	constituentName
 	constituentAmplitude
 	constituentLag
-	constituentLast
 )
 
+var constituentSchema = schema{
+	version: 1,
+	columns: []column{
+		constituentGauge:     {name: "Gauge"},
+		constituentNumber:    {name: "Number", kind: numberKind},
+		constituentName:      {name: "Constituent"},
+		constituentAmplitude: {name: "Amplitude", kind: numberKind},
+		constituentLag:       {name: "Lag", kind: numberKind},
+	},
+}
+
 type Constituent struct {
 	Gauge     string
 	Number    int
@@ -39,14 +48,35 @@ func (c ConstituentList) Less(i, j int) bool {
 	}
 }
 
+func (c ConstituentList) schema() schema                    { return constituentSchema }
+func (c ConstituentList) MarshalJSON() ([]byte, error)      { return marshalJSON(c) }
+func (c *ConstituentList) UnmarshalJSON(b []byte) error     { return unmarshalJSON(b, c) }
+func (c ConstituentList) MarshalYAML() (interface{}, error) { return marshalYAML(c) }
+func (c *ConstituentList) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalYAML(unmarshal, c)
+}
+
+func (c Constituent) MarshalJSON() ([]byte, error) { return marshalRecordJSON(ConstituentList{c}) }
+func (c *Constituent) UnmarshalJSON(b []byte) error {
+	var l ConstituentList
+	if err := unmarshalRecordJSON(b, &l); err != nil {
+		return err
+	}
+	*c = l[0]
+	return nil
+}
+func (c Constituent) MarshalYAML() (interface{}, error) { return marshalRecordYAML(ConstituentList{c}) }
+func (c *Constituent) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var l ConstituentList
+	if err := unmarshalRecordYAML(unmarshal, &l); err != nil {
+		return err
+	}
+	*c = l[0]
+	return nil
+}
+
 func (c ConstituentList) encode() [][]string {
-	data := [][]string{{
-		"Gauge",
-		"Number",
-		"Constituent",
-		"Amplitude",
-		"Lag",
-	}}
+	data := [][]string{constituentSchema.header()}
 	for _, v := range c {
 		data = append(data, []string{
 			strings.TrimSpace(v.Gauge),
@@ -62,23 +92,28 @@ func (c ConstituentList) encode() [][]string {
 func (c *ConstituentList) decode(data [][]string) error {
 	var constituents []Constituent
 	if len(data) > 1 {
-		for _, d := range data[1:] {
-			if len(d) != constituentLast {
-				return fmt.Errorf("incorrect number of installed constituent fields")
-			}
-			var err error
+		fields, err := constituentSchema.fields(data[0])
+		if err != nil {
+			return err
+		}
+		var errs DecodeErrors
+		for i, row := range data[1:] {
+			d := fields.remap(row)
 
 			var num int
 			if num, err = strconv.Atoi(d[constituentNumber]); err != nil {
-				return err
+				errs = append(errs, fields.invalid(i+1, d, constituentNumber, err))
+				continue
 			}
 
 			var amp, lag float64
 			if amp, err = strconv.ParseFloat(d[constituentAmplitude], 64); err != nil {
-				return err
+				errs = append(errs, fields.invalid(i+1, d, constituentAmplitude, err))
+				continue
 			}
 			if lag, err = strconv.ParseFloat(d[constituentLag], 64); err != nil {
-				return err
+				errs = append(errs, fields.invalid(i+1, d, constituentLag, err))
+				continue
 			}
 
 			constituents = append(constituents, Constituent{
@@ -90,6 +125,10 @@ func (c *ConstituentList) decode(data [][]string) error {
 			})
 		}
 
+		if errs != nil {
+			return errs
+		}
+
 		*c = ConstituentList(constituents)
 	}
 	return nil
@@ -1,7 +1,6 @@
 package meta
 
 import (
-	"fmt"
 	"sort"
 	"strings"
 	"time"
@@ -15,9 +14,21 @@ const (
 	dataloggerRole
 	dataloggerStart
 	dataloggerEnd
-	dataloggerLast
 )
 
+var dataloggerSchema = schema{
+	version: 1,
+	columns: []column{
+		dataloggerMake:   {name: "Make"},
+		dataloggerModel:  {name: "Model"},
+		dataloggerSerial: {name: "Serial"},
+		dataloggerPlace:  {name: "Place"},
+		dataloggerRole:   {name: "Role"},
+		dataloggerStart:  {name: "Start Date"},
+		dataloggerEnd:    {name: "End Date"},
+	},
+}
+
 type DeployedDatalogger struct {
 	Install
 
@@ -27,20 +38,42 @@ type DeployedDatalogger struct {
 
 type DeployedDataloggerList []DeployedDatalogger
 
-func (d DeployedDataloggerList) Len() int           { return len(d) }
-func (d DeployedDataloggerList) Swap(i, j int)      { d[i], d[j] = d[j], d[i] }
-func (d DeployedDataloggerList) Less(i, j int) bool { return d[i].Install.less(d[j].Install) }
+func (d DeployedDataloggerList) Len() int                          { return len(d) }
+func (d DeployedDataloggerList) Swap(i, j int)                     { d[i], d[j] = d[j], d[i] }
+func (d DeployedDataloggerList) Less(i, j int) bool                { return d[i].Install.less(d[j].Install) }
+func (d DeployedDataloggerList) schema() schema                    { return dataloggerSchema }
+func (d DeployedDataloggerList) MarshalJSON() ([]byte, error)      { return marshalJSON(d) }
+func (d *DeployedDataloggerList) UnmarshalJSON(b []byte) error     { return unmarshalJSON(b, d) }
+func (d DeployedDataloggerList) MarshalYAML() (interface{}, error) { return marshalYAML(d) }
+func (d *DeployedDataloggerList) UnmarshalYAML(f func(interface{}) error) error {
+	return unmarshalYAML(f, d)
+}
+
+func (d DeployedDatalogger) MarshalJSON() ([]byte, error) {
+	return marshalRecordJSON(DeployedDataloggerList{d})
+}
+func (d *DeployedDatalogger) UnmarshalJSON(b []byte) error {
+	var l DeployedDataloggerList
+	if err := unmarshalRecordJSON(b, &l); err != nil {
+		return err
+	}
+	*d = l[0]
+	return nil
+}
+func (d DeployedDatalogger) MarshalYAML() (interface{}, error) {
+	return marshalRecordYAML(DeployedDataloggerList{d})
+}
+func (d *DeployedDatalogger) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var l DeployedDataloggerList
+	if err := unmarshalRecordYAML(unmarshal, &l); err != nil {
+		return err
+	}
+	*d = l[0]
+	return nil
+}
 
 func (d DeployedDataloggerList) encode() [][]string {
-	data := [][]string{{
-		"Make",
-		"Model",
-		"Serial",
-		"Place",
-		"Role",
-		"Start Date",
-		"End Date",
-	}}
+	data := [][]string{dataloggerSchema.header()}
 	for _, v := range d {
 		data = append(data, []string{
 			strings.TrimSpace(v.Make),
@@ -49,7 +82,7 @@ func (d DeployedDataloggerList) encode() [][]string {
 			strings.TrimSpace(v.Place),
 			strings.TrimSpace(v.Role),
 			v.Start.Format(DateTimeFormat),
-			v.End.Format(DateTimeFormat),
+			formatEnd(v.End),
 		})
 	}
 	return data
@@ -58,18 +91,22 @@ func (d DeployedDataloggerList) encode() [][]string {
 func (d *DeployedDataloggerList) decode(data [][]string) error {
 	var dataloggers []DeployedDatalogger
 	if len(data) > 1 {
-		for _, v := range data[1:] {
-			if len(v) != dataloggerLast {
-				return fmt.Errorf("incorrect number of installed datalogger fields")
-			}
-			var err error
+		fields, err := dataloggerSchema.fields(data[0])
+		if err != nil {
+			return err
+		}
+		var errs DecodeErrors
+		for i, row := range data[1:] {
+			v := fields.remap(row)
 
 			var start, end time.Time
 			if start, err = time.Parse(DateTimeFormat, v[dataloggerStart]); err != nil {
-				return err
+				errs = append(errs, fields.invalid(i+1, v, dataloggerStart, err))
+				continue
 			}
-			if end, err = time.Parse(DateTimeFormat, v[dataloggerEnd]); err != nil {
-				return err
+			if end, err = parseEnd(v[dataloggerEnd]); err != nil {
+				errs = append(errs, fields.invalid(i+1, v, dataloggerEnd, err))
+				continue
 			}
 
 			dataloggers = append(dataloggers, DeployedDatalogger{
@@ -89,6 +126,10 @@ func (d *DeployedDataloggerList) decode(data [][]string) error {
 			})
 		}
 
+		if errs != nil {
+			return errs
+		}
+
 		*d = DeployedDataloggerList(dataloggers)
 	}
 	return nil
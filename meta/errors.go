@@ -0,0 +1,67 @@
+package meta
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrMissingColumn is returned when a required csv column is not present in a list header.
+var ErrMissingColumn = errors.New("missing required column")
+
+// DecodeError records the location and value of a csv field that could not be decoded.
+type DecodeError struct {
+	Path   string
+	Line   int
+	Column string
+	Value  string
+	Err    error
+
+	// csv record number, used to find the actual line when known
+	record int
+}
+
+func (e DecodeError) Error() string {
+	var parts []string
+	switch {
+	case e.Path != "":
+		parts = append(parts, fmt.Sprintf("%s:%d", e.Path, e.Line))
+	default:
+		parts = append(parts, fmt.Sprintf("line %d", e.Line))
+	}
+	if e.Column != "" {
+		parts = append(parts, fmt.Sprintf("column %q", e.Column))
+	}
+	if e.Value != "" {
+		parts = append(parts, fmt.Sprintf("value %q", e.Value))
+	}
+	if e.Err != nil {
+		parts = append(parts, e.Err.Error())
+	}
+	return strings.Join(parts, ": ")
+}
+
+func (e DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// DecodeErrors holds all the decode errors found while decoding a list.
+type DecodeErrors []DecodeError
+
+func (e DecodeErrors) Error() string {
+	var lines []string
+	for _, err := range e {
+		lines = append(lines, err.Error())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// locate updates the errors with the file path and the actual csv line numbers if known.
+func (e DecodeErrors) locate(path string, lines []int) {
+	for i := range e {
+		e[i].Path = path
+		if r := e[i].record; r >= 0 && r < len(lines) {
+			e[i].Line = lines[r]
+		}
+	}
+}
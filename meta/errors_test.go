@@ -0,0 +1,51 @@
+package meta_test
+
+import (
+	"testing"
+
+	"github.com/GeoNet/delta/meta"
+)
+
+func TestDecodeErrors(t *testing.T) {
+
+	var stations meta.StationList
+
+	err := meta.UnmarshalList([]byte(`Station,Network,Name,Latitude,Longitude,Elevation,Datum,Start Date,End Date
+DFE,TR,Dawson Falls,-39.325743417,174.103863732,880,WGS84,1993-12-14T00:00:00Z,2010-02-23T00:00:00Z
+TBAS,SM,"Tolaga Bay
+Area School",-38.372803703,xxx,8,WGS84,2002-03-05T00:00:00Z,9999-01-01T00:00:00Z
+WEL,NZ,Wellington,-41.284047578,174.768179737,138,WGS84,1916-01-01,9999-01-01T00:00:00Z
+`), &stations)
+	if err == nil {
+		t.Fatal("expected decode errors")
+	}
+
+	errs, ok := err.(meta.DecodeErrors)
+	if !ok {
+		t.Fatalf("invalid decode error type: %T", err)
+	}
+	if len(errs) != 2 {
+		t.Fatalf("invalid number of decode errors: got %d, expected %d", len(errs), 2)
+	}
+
+	var checks = []struct {
+		line   int
+		column string
+		value  string
+	}{
+		{3, "Longitude", "xxx"},
+		{5, "Start Date", "1916-01-01"},
+	}
+
+	for i, c := range checks {
+		if errs[i].Line != c.line {
+			t.Errorf("invalid decode error line: got %d, expected %d", errs[i].Line, c.line)
+		}
+		if errs[i].Column != c.column {
+			t.Errorf("invalid decode error column: got %q, expected %q", errs[i].Column, c.column)
+		}
+		if errs[i].Value != c.value {
+			t.Errorf("invalid decode error value: got %q, expected %q", errs[i].Value, c.value)
+		}
+	}
+}
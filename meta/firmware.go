@@ -1,7 +1,6 @@
 package meta
 
 import (
-	"fmt"
 	"sort"
 	"strings"
 	"time"
@@ -15,9 +14,21 @@ const (
 	firmwareStart
 	firmwareEnd
 	firmwareNotes
-	firmwareLast
 )
 
+var firmwareSchema = schema{
+	version: 1,
+	columns: []column{
+		firmwareMake:    {name: "Make"},
+		firmwareModel:   {name: "Model"},
+		firmwareSerial:  {name: "Serial"},
+		firmwareVersion: {name: "Version"},
+		firmwareStart:   {name: "Start Date"},
+		firmwareEnd:     {name: "End Date"},
+		firmwareNotes:   {name: "Notes", optional: true},
+	},
+}
+
 type FirmwareHistory struct {
 	Install
 
@@ -27,20 +38,42 @@ type FirmwareHistory struct {
 
 type FirmwareHistoryList []FirmwareHistory
 
-func (f FirmwareHistoryList) Len() int           { return len(f) }
-func (f FirmwareHistoryList) Swap(i, j int)      { f[i], f[j] = f[j], f[i] }
-func (f FirmwareHistoryList) Less(i, j int) bool { return f[i].Install.less(f[j].Install) }
+func (f FirmwareHistoryList) Len() int                          { return len(f) }
+func (f FirmwareHistoryList) Swap(i, j int)                     { f[i], f[j] = f[j], f[i] }
+func (f FirmwareHistoryList) Less(i, j int) bool                { return f[i].Install.less(f[j].Install) }
+func (f FirmwareHistoryList) schema() schema                    { return firmwareSchema }
+func (f FirmwareHistoryList) MarshalJSON() ([]byte, error)      { return marshalJSON(f) }
+func (f *FirmwareHistoryList) UnmarshalJSON(b []byte) error     { return unmarshalJSON(b, f) }
+func (f FirmwareHistoryList) MarshalYAML() (interface{}, error) { return marshalYAML(f) }
+func (f *FirmwareHistoryList) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalYAML(unmarshal, f)
+}
+
+func (f FirmwareHistory) MarshalJSON() ([]byte, error) {
+	return marshalRecordJSON(FirmwareHistoryList{f})
+}
+func (f *FirmwareHistory) UnmarshalJSON(b []byte) error {
+	var l FirmwareHistoryList
+	if err := unmarshalRecordJSON(b, &l); err != nil {
+		return err
+	}
+	*f = l[0]
+	return nil
+}
+func (f FirmwareHistory) MarshalYAML() (interface{}, error) {
+	return marshalRecordYAML(FirmwareHistoryList{f})
+}
+func (f *FirmwareHistory) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var l FirmwareHistoryList
+	if err := unmarshalRecordYAML(unmarshal, &l); err != nil {
+		return err
+	}
+	*f = l[0]
+	return nil
+}
 
 func (f FirmwareHistoryList) encode() [][]string {
-	data := [][]string{{
-		"Make",
-		"Model",
-		"Serial",
-		"Version",
-		"Start Date",
-		"End Date",
-		"Notes",
-	}}
+	data := [][]string{firmwareSchema.header()}
 	for _, v := range f {
 		data = append(data, []string{
 			strings.TrimSpace(v.Make),
@@ -48,7 +81,7 @@ func (f FirmwareHistoryList) encode() [][]string {
 			strings.TrimSpace(v.Serial),
 			strings.TrimSpace(v.Version),
 			v.Start.Format(DateTimeFormat),
-			v.End.Format(DateTimeFormat),
+			formatEnd(v.End),
 			strings.TrimSpace(v.Notes),
 		})
 	}
@@ -58,18 +91,22 @@ func (f FirmwareHistoryList) encode() [][]string {
 func (f *FirmwareHistoryList) decode(data [][]string) error {
 	var histories []FirmwareHistory
 	if len(data) > 1 {
-		for _, d := range data[1:] {
-			if len(d) != firmwareLast {
-				return fmt.Errorf("incorrect number of firmware history fields")
-			}
-			var err error
+		fields, err := firmwareSchema.fields(data[0])
+		if err != nil {
+			return err
+		}
+		var errs DecodeErrors
+		for i, row := range data[1:] {
+			d := fields.remap(row)
 
 			var start, end time.Time
 			if start, err = time.Parse(DateTimeFormat, d[firmwareStart]); err != nil {
-				return err
+				errs = append(errs, fields.invalid(i+1, d, firmwareStart, err))
+				continue
 			}
-			if end, err = time.Parse(DateTimeFormat, d[firmwareEnd]); err != nil {
-				return err
+			if end, err = parseEnd(d[firmwareEnd]); err != nil {
+				errs = append(errs, fields.invalid(i+1, d, firmwareEnd, err))
+				continue
 			}
 
 			histories = append(histories, FirmwareHistory{
@@ -89,6 +126,10 @@ func (f *FirmwareHistoryList) decode(data [][]string) error {
 			})
 		}
 
+		if errs != nil {
+			return errs
+		}
+
 		*f = FirmwareHistoryList(histories)
 	}
 	return nil
@@ -0,0 +1,193 @@
+package meta
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	gainMake int = iota
+	gainModel
+	gainSerial
+	gainGain
+	gainBitWeight
+	gainFilter
+	gainStart
+	gainEnd
+	gainNotes
+)
+
+// errInvalidGain is returned for gains that cannot scale a datalogger response.
+var errInvalidGain = errors.New("gain must be greater than zero")
+
+var gainSchema = schema{
+	version: 1,
+	columns: []column{
+		gainMake:      {name: "Make"},
+		gainModel:     {name: "Model"},
+		gainSerial:    {name: "Serial"},
+		gainGain:      {name: "Gain", kind: numberKind},
+		gainBitWeight: {name: "Bit Weight", kind: numberKind},
+		gainFilter:    {name: "Filter", optional: true},
+		gainStart:     {name: "Start Date"},
+		gainEnd:       {name: "End Date"},
+		gainNotes:     {name: "Notes", optional: true},
+	},
+}
+
+// DataloggerGain records any non-default datalogger settings used while deployed. The Gain is the
+// pre-amp gain applied ahead of the digitiser, and the optional BitWeight, in volts per count, replaces
+// the digitiser sensitivity of the datalogger model. The optional Filter names the response filter used
+// by the datalogger when the response configuration offers alternatives for the same channels.
+type DataloggerGain struct {
+	Install
+
+	Gain      float64
+	BitWeight float64
+	Filter    string
+	Notes     string
+
+	nullBitWeight bool
+}
+
+// HasBitWeight returns whether a bit weight has been given for the datalogger.
+func (d DataloggerGain) HasBitWeight() bool {
+	return !d.nullBitWeight
+}
+
+type DataloggerGainList []DataloggerGain
+
+func (d DataloggerGainList) Len() int                          { return len(d) }
+func (d DataloggerGainList) Swap(i, j int)                     { d[i], d[j] = d[j], d[i] }
+func (d DataloggerGainList) Less(i, j int) bool                { return d[i].Install.less(d[j].Install) }
+func (d DataloggerGainList) schema() schema                    { return gainSchema }
+func (d DataloggerGainList) MarshalJSON() ([]byte, error)      { return marshalJSON(d) }
+func (d *DataloggerGainList) UnmarshalJSON(b []byte) error     { return unmarshalJSON(b, d) }
+func (d DataloggerGainList) MarshalYAML() (interface{}, error) { return marshalYAML(d) }
+func (d *DataloggerGainList) UnmarshalYAML(f func(interface{}) error) error {
+	return unmarshalYAML(f, d)
+}
+
+func (d DataloggerGain) MarshalJSON() ([]byte, error) {
+	return marshalRecordJSON(DataloggerGainList{d})
+}
+func (d *DataloggerGain) UnmarshalJSON(b []byte) error {
+	var l DataloggerGainList
+	if err := unmarshalRecordJSON(b, &l); err != nil {
+		return err
+	}
+	*d = l[0]
+	return nil
+}
+func (d DataloggerGain) MarshalYAML() (interface{}, error) {
+	return marshalRecordYAML(DataloggerGainList{d})
+}
+func (d *DataloggerGain) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var l DataloggerGainList
+	if err := unmarshalRecordYAML(unmarshal, &l); err != nil {
+		return err
+	}
+	*d = l[0]
+	return nil
+}
+
+func (d DataloggerGainList) encode() [][]string {
+	data := [][]string{gainSchema.header()}
+	for _, v := range d {
+		data = append(data, []string{
+			strings.TrimSpace(v.Make),
+			strings.TrimSpace(v.Model),
+			strings.TrimSpace(v.Serial),
+			strconv.FormatFloat(v.Gain, 'g', -1, 64),
+			formatNullable(v.BitWeight, v.nullBitWeight),
+			strings.TrimSpace(v.Filter),
+			v.Start.Format(DateTimeFormat),
+			formatEnd(v.End),
+			strings.TrimSpace(v.Notes),
+		})
+	}
+	return data
+}
+
+func (d *DataloggerGainList) decode(data [][]string) error {
+	var gains []DataloggerGain
+	if len(data) > 1 {
+		fields, err := gainSchema.fields(data[0])
+		if err != nil {
+			return err
+		}
+		var errs DecodeErrors
+		for i, row := range data[1:] {
+			v := fields.remap(row)
+
+			var gain float64
+			if gain, err = strconv.ParseFloat(v[gainGain], 64); err != nil {
+				errs = append(errs, fields.invalid(i+1, v, gainGain, err))
+				continue
+			}
+			if !(gain > 0.0) {
+				errs = append(errs, fields.invalid(i+1, v, gainGain, errInvalidGain))
+				continue
+			}
+
+			var bitWeight float64
+			var nullBitWeight bool
+			if bitWeight, nullBitWeight, err = parseNullable(v[gainBitWeight]); err != nil {
+				errs = append(errs, fields.invalid(i+1, v, gainBitWeight, err))
+				continue
+			}
+
+			var start, end time.Time
+			if start, err = time.Parse(DateTimeFormat, v[gainStart]); err != nil {
+				errs = append(errs, fields.invalid(i+1, v, gainStart, err))
+				continue
+			}
+			if end, err = parseEnd(v[gainEnd]); err != nil {
+				errs = append(errs, fields.invalid(i+1, v, gainEnd, err))
+				continue
+			}
+
+			gains = append(gains, DataloggerGain{
+				Install: Install{
+					Equipment: Equipment{
+						Make:   strings.TrimSpace(v[gainMake]),
+						Model:  strings.TrimSpace(v[gainModel]),
+						Serial: strings.TrimSpace(v[gainSerial]),
+					},
+					Span: Span{
+						Start: start,
+						End:   end,
+					},
+				},
+				Gain:      gain,
+				BitWeight: bitWeight,
+				Filter:    strings.TrimSpace(v[gainFilter]),
+				Notes:     strings.TrimSpace(v[gainNotes]),
+
+				nullBitWeight: nullBitWeight,
+			})
+		}
+
+		if errs != nil {
+			return errs
+		}
+
+		*d = DataloggerGainList(gains)
+	}
+	return nil
+}
+
+func LoadDataloggerGains(path string) ([]DataloggerGain, error) {
+	var g []DataloggerGain
+
+	if err := LoadList(path, (*DataloggerGainList)(&g)); err != nil {
+		return nil, err
+	}
+
+	sort.Sort(DataloggerGainList(g))
+
+	return g, nil
+}
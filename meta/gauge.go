@@ -1,7 +1,6 @@
 package meta
 
 import (
-	"fmt"
 	"sort"
 	"strconv"
 	"strings"
@@ -15,9 +14,21 @@ const (
 	gaugeAnalysisLatitude
 	gaugeAnalysisLongitude
 	gaugeCrex
-	gaugeLast
 )
 
+var gaugeSchema = schema{
+	version: 1,
+	columns: []column{
+		gaugeCode:              {name: "Gauge"},
+		gaugeNetwork:           {name: "Network"},
+		gaugeNumber:            {name: "LINZ Number"},
+		gaugeAnalysisTimeZone:  {name: "Analysis Time Zone", kind: numberKind},
+		gaugeAnalysisLatitude:  {name: "Analysis Latitude", kind: numberKind},
+		gaugeAnalysisLongitude: {name: "Analysis Longitude", kind: numberKind},
+		gaugeCrex:              {name: "Crex Tag"},
+	},
+}
+
 type Gauge struct {
 	Reference
 	Point
@@ -29,20 +40,38 @@ type Gauge struct {
 
 type GaugeList []Gauge
 
-func (g GaugeList) Len() int           { return len(g) }
-func (g GaugeList) Swap(i, j int)      { g[i], g[j] = g[j], g[i] }
-func (g GaugeList) Less(i, j int) bool { return g[i].Code < g[j].Code }
+func (g GaugeList) Len() int                          { return len(g) }
+func (g GaugeList) Swap(i, j int)                     { g[i], g[j] = g[j], g[i] }
+func (g GaugeList) Less(i, j int) bool                { return g[i].Code < g[j].Code }
+func (g GaugeList) schema() schema                    { return gaugeSchema }
+func (g GaugeList) MarshalJSON() ([]byte, error)      { return marshalJSON(g) }
+func (g *GaugeList) UnmarshalJSON(b []byte) error     { return unmarshalJSON(b, g) }
+func (g GaugeList) MarshalYAML() (interface{}, error) { return marshalYAML(g) }
+func (g *GaugeList) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalYAML(unmarshal, g)
+}
+
+func (g Gauge) MarshalJSON() ([]byte, error) { return marshalRecordJSON(GaugeList{g}) }
+func (g *Gauge) UnmarshalJSON(b []byte) error {
+	var l GaugeList
+	if err := unmarshalRecordJSON(b, &l); err != nil {
+		return err
+	}
+	*g = l[0]
+	return nil
+}
+func (g Gauge) MarshalYAML() (interface{}, error) { return marshalRecordYAML(GaugeList{g}) }
+func (g *Gauge) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var l GaugeList
+	if err := unmarshalRecordYAML(unmarshal, &l); err != nil {
+		return err
+	}
+	*g = l[0]
+	return nil
+}
 
 func (g GaugeList) encode() [][]string {
-	data := [][]string{{
-		"Gauge",
-		"Network",
-		"LINZ Number",
-		"Analysis Time Zone",
-		"Analysis Latitude",
-		"Analysis Longitude",
-		"Crex Tag",
-	}}
+	data := [][]string{gaugeSchema.header()}
 	for _, v := range g {
 		data = append(data, []string{
 			strings.TrimSpace(v.Code),
@@ -60,21 +89,26 @@ func (g GaugeList) encode() [][]string {
 func (g *GaugeList) decode(data [][]string) error {
 	var gauges []Gauge
 	if len(data) > 1 {
-		for _, d := range data[1:] {
-			if len(d) != gaugeLast {
-				return fmt.Errorf("incorrect number of installed gauge fields")
-			}
-			var err error
+		fields, err := gaugeSchema.fields(data[0])
+		if err != nil {
+			return err
+		}
+		var errs DecodeErrors
+		for i, row := range data[1:] {
+			d := fields.remap(row)
 
 			var lat, lon, zone float64
 			if zone, err = strconv.ParseFloat(d[gaugeAnalysisTimeZone], 64); err != nil {
-				return err
+				errs = append(errs, fields.invalid(i+1, d, gaugeAnalysisTimeZone, err))
+				continue
 			}
 			if lat, err = strconv.ParseFloat(d[gaugeAnalysisLatitude], 64); err != nil {
-				return err
+				errs = append(errs, fields.invalid(i+1, d, gaugeAnalysisLatitude, err))
+				continue
 			}
 			if lon, err = strconv.ParseFloat(d[gaugeAnalysisLongitude], 64); err != nil {
-				return err
+				errs = append(errs, fields.invalid(i+1, d, gaugeAnalysisLongitude, err))
+				continue
 			}
 
 			gauges = append(gauges, Gauge{
@@ -92,6 +126,10 @@ func (g *GaugeList) decode(data [][]string) error {
 			})
 		}
 
+		if errs != nil {
+			return errs
+		}
+
 		*g = GaugeList(gauges)
 	}
 	return nil
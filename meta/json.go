@@ -0,0 +1,276 @@
+package meta
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// schemaEncoder is implemented by lists that can be encoded using their csv schema.
+type schemaEncoder interface {
+	ListSchema
+	ListEncoder
+}
+
+// schemaDecoder is implemented by lists that can be decoded using their csv schema.
+type schemaDecoder interface {
+	ListSchema
+	ListDecoder
+}
+
+// toValue converts an encoded csv value into a typed json or yaml value, empty
+// number and boolean values are returned as nil.
+func (c column) toValue(s string) interface{} {
+	switch c.kind {
+	case numberKind:
+		if strings.TrimSpace(s) == "" {
+			return nil
+		}
+		if v, err := strconv.ParseFloat(s, 64); err == nil {
+			return v
+		}
+	case boolKind:
+		switch strings.ToLower(strings.TrimSpace(s)) {
+		case "":
+			return nil
+		case "yes":
+			return true
+		case "no":
+			return false
+		}
+		if v, err := strconv.ParseBool(s); err == nil {
+			return v
+		}
+	}
+	return s
+}
+
+// fromValue converts a json or yaml value back into its csv representation.
+func (c column) fromValue(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	case int:
+		return strconv.Itoa(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// encodeRecords converts a list into a slice of ordered records keyed by the schema column names.
+func encodeRecords(l schemaEncoder) []yaml.MapSlice {
+	columns := l.schema().columns
+
+	records := []yaml.MapSlice{}
+	for _, row := range l.encode()[1:] {
+		var record yaml.MapSlice
+		for i, c := range columns {
+			var v string
+			if i < len(row) {
+				v = row[i]
+			}
+			record = append(record, yaml.MapItem{Key: c.key(), Value: c.toValue(v)})
+		}
+		records = append(records, record)
+	}
+
+	return records
+}
+
+// decodeRecords converts a slice of records keyed by the schema column names into the given list,
+// missing entries are decoded using the column default values.
+func decodeRecords(records []map[string]interface{}, l schemaDecoder) error {
+	s := l.schema()
+
+	data := [][]string{s.header()}
+	for _, r := range records {
+		row := make([]string, len(s.columns))
+		for i, c := range s.columns {
+			v, ok := r[c.key()]
+			if !ok {
+				row[i] = c.value
+				continue
+			}
+			row[i] = c.fromValue(v)
+		}
+		data = append(data, row)
+	}
+
+	return l.decode(data)
+}
+
+// writeRecordJSON writes a single record as a json object, the object fields are in column order.
+func writeRecordJSON(b *bytes.Buffer, record yaml.MapSlice) error {
+	b.WriteString("{")
+	for i, item := range record {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		k, err := json.Marshal(item.Key)
+		if err != nil {
+			return err
+		}
+		v, err := json.Marshal(item.Value)
+		if err != nil {
+			return err
+		}
+		b.Write(k)
+		b.WriteString(":")
+		b.Write(v)
+	}
+	b.WriteString("}")
+
+	return nil
+}
+
+// marshalJSON encodes a list as a json array of objects, the object fields are in column order.
+func marshalJSON(l schemaEncoder) ([]byte, error) {
+	var b bytes.Buffer
+
+	b.WriteString("[")
+	for n, record := range encodeRecords(l) {
+		if n > 0 {
+			b.WriteString(",")
+		}
+		if err := writeRecordJSON(&b, record); err != nil {
+			return nil, err
+		}
+	}
+	b.WriteString("]")
+
+	return b.Bytes(), nil
+}
+
+// marshalRecordJSON encodes a single entry list as a json object using the same keys as the list encoding.
+func marshalRecordJSON(l schemaEncoder) ([]byte, error) {
+	records := encodeRecords(l)
+	if len(records) != 1 {
+		return nil, fmt.Errorf("expected a single record, found %d", len(records))
+	}
+
+	var b bytes.Buffer
+	if err := writeRecordJSON(&b, records[0]); err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+// unmarshalRecordJSON decodes a single json object into the given list.
+func unmarshalRecordJSON(data []byte, l schemaDecoder) error {
+	var record map[string]interface{}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&record); err != nil {
+		return err
+	}
+
+	return decodeRecords([]map[string]interface{}{record}, l)
+}
+
+// unmarshalJSON decodes a json array of objects into the given list.
+func unmarshalJSON(data []byte, l schemaDecoder) error {
+	var records []map[string]interface{}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&records); err != nil {
+		return err
+	}
+
+	return decodeRecords(records, l)
+}
+
+// marshalYAML returns a yaml representation of a list, the mapping keys are in column order.
+func marshalYAML(l schemaEncoder) (interface{}, error) {
+	return encodeRecords(l), nil
+}
+
+// unmarshalYAML decodes a yaml sequence of mappings into the given list.
+func unmarshalYAML(unmarshal func(interface{}) error, l schemaDecoder) error {
+	var records []map[string]interface{}
+	if err := unmarshal(&records); err != nil {
+		return err
+	}
+	return decodeRecords(records, l)
+}
+
+// marshalRecordYAML returns a yaml representation of a single entry list using the same keys as the list encoding.
+func marshalRecordYAML(l schemaEncoder) (interface{}, error) {
+	records := encodeRecords(l)
+	if len(records) != 1 {
+		return nil, fmt.Errorf("expected a single record, found %d", len(records))
+	}
+	return records[0], nil
+}
+
+// unmarshalRecordYAML decodes a single yaml mapping into the given list.
+func unmarshalRecordYAML(unmarshal func(interface{}) error, l schemaDecoder) error {
+	var record map[string]interface{}
+	if err := unmarshal(&record); err != nil {
+		return err
+	}
+	return decodeRecords([]map[string]interface{}{record}, l)
+}
+
+// LoadListJSON reads a json encoded list from the given file path.
+func LoadListJSON(path string, l ListDecoder) error {
+
+	s, ok := l.(schemaDecoder)
+	if !ok {
+		return fmt.Errorf("%s: list does not support json decoding", path)
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	if err := unmarshalJSON(data, s); err != nil {
+		return fmt.Errorf("%s: %v", path, err)
+	}
+
+	return nil
+}
+
+// StoreListJSON writes a list to the given file path as indented json.
+func StoreListJSON(path string, l ListEncoder) error {
+
+	s, ok := l.(schemaEncoder)
+	if !ok {
+		return fmt.Errorf("%s: list does not support json encoding", path)
+	}
+
+	data, err := marshalJSON(s)
+	if err != nil {
+		return err
+	}
+
+	var b bytes.Buffer
+	if err := json.Indent(&b, data, "", "  "); err != nil {
+		return err
+	}
+	b.WriteString("\n")
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, b.Bytes(), 0644)
+}
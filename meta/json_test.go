@@ -0,0 +1,149 @@
+package meta_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/GeoNet/delta/meta"
+)
+
+func TestListJSON(t *testing.T) {
+
+	raw := "Make,Model,Serial,Station,Location,Azimuth,Dip,Depth,North,East,Scale Factor,Scale Bias,Start Date,End Date\n" +
+		"Kinemetrics,FBA-23,25038,WEL,20,0,0,,0,,1,,1991-07-30T00:00:00Z,9999-01-01T00:00:00Z\n"
+
+	var sensors meta.InstalledSensorList
+	if err := meta.UnmarshalList([]byte(raw), &sensors); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Log("Check json encoding")
+	{
+		b, err := json.Marshal(sensors)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s := string(b); s != `[{"make":"Kinemetrics","model":"FBA-23","serial":"25038","station":"WEL","location":"20","azimuth":0,"dip":0,"depth":null,"north":0,"east":null,"scale_factor":1,"scale_bias":null,"start_date":"1991-07-30T00:00:00Z","end_date":"9999-01-01T00:00:00Z"}]` {
+			t.Errorf("invalid json encoding: %s", s)
+		}
+
+		var list meta.InstalledSensorList
+		if err := json.Unmarshal(b, &list); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(list, sensors) {
+			t.Errorf("invalid json decoding: %v", list)
+		}
+	}
+
+	t.Log("Check yaml encoding")
+	{
+		b, err := yaml.Marshal(sensors)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var list meta.InstalledSensorList
+		if err := yaml.Unmarshal(b, &list); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(list, sensors) {
+			t.Errorf("invalid yaml decoding: %v", list)
+		}
+	}
+
+	t.Log("Check json files")
+	{
+		dir, err := ioutil.TempDir("", "meta")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(dir)
+
+		var marks meta.MarkList
+		if err := meta.LoadList("../network/marks.csv", &marks); err != nil {
+			t.Fatal(err)
+		}
+
+		path := filepath.Join(dir, "marks.json")
+		if err := meta.StoreListJSON(path, marks); err != nil {
+			t.Fatal(err)
+		}
+
+		var list meta.MarkList
+		if err := meta.LoadListJSON(path, &list); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(list, marks) {
+			t.Error("invalid json list round trip")
+		}
+	}
+}
+
+func TestRecordJSON(t *testing.T) {
+
+	raw := "Station,Network,Name,Latitude,Longitude,Elevation,Datum,Start Date,End Date\n" +
+		"WEL,NZ,Wellington,-41.28,174.77,,WGS84,1916-01-01T00:00:00Z,9999-01-01T00:00:00Z\n"
+
+	var stations meta.StationList
+	if err := meta.UnmarshalList([]byte(raw), &stations); err != nil {
+		t.Fatal(err)
+	}
+	if len(stations) != 1 {
+		t.Fatalf("invalid number of stations: %d", len(stations))
+	}
+	station := stations[0]
+
+	t.Log("Check json record encoding")
+	{
+		b, err := json.Marshal(station)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		l, err := json.Marshal(stations)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s := "[" + string(b) + "]"; s != string(l) {
+			t.Errorf("record and list json encoding mismatch: %s != %s", s, string(l))
+		}
+
+		var record meta.Station
+		if err := json.Unmarshal(b, &record); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(record, station) {
+			t.Errorf("invalid json record decoding: %v", record)
+		}
+		if record.HasElevation() {
+			t.Error("unknown elevation should not be decoded as known")
+		}
+	}
+
+	t.Log("Check yaml record encoding")
+	{
+		b, err := yaml.Marshal(station)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s := string(b); !strings.HasPrefix(s, "station: WEL\n") || !strings.Contains(s, "elevation: null\n") {
+			t.Errorf("invalid yaml record encoding: %s", s)
+		}
+
+		var record meta.Station
+		if err := yaml.Unmarshal(b, &record); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(record, station) {
+			t.Errorf("invalid yaml record decoding: %v", record)
+		}
+	}
+}
@@ -3,6 +3,8 @@ package meta
 import (
 	"bytes"
 	"encoding/csv"
+	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"sort"
@@ -32,11 +34,14 @@ func MarshalList(l ListEncoder) []byte {
 
 func UnmarshalList(b []byte, l ListDecoder) error {
 
-	v, err := csv.NewReader(bytes.NewBuffer(b)).ReadAll()
+	v, lines, err := readList(bytes.NewBuffer(b))
 	if err != nil {
 		return err
 	}
 	if err := DecodeList(v, l); err != nil {
+		if errs, ok := err.(DecodeErrors); ok {
+			errs.locate("", lines)
+		}
 		return err
 	}
 
@@ -59,18 +64,68 @@ func LoadList(path string, l ListDecoder) error {
 	}
 	defer file.Close()
 
-	data, err := csv.NewReader(file).ReadAll()
+	data, lines, err := readList(file)
 	if err != nil {
 		return err
 	}
 
 	if err := DecodeList(data, l); err != nil {
+		if errs, ok := err.(DecodeErrors); ok {
+			errs.locate(path, lines)
+		}
 		return err
 	}
 
 	return nil
 }
 
+// LoadListFS decodes a list from the given path of a file system, such as one returned by os.DirFS.
+func LoadListFS(fsys fs.FS, path string, l ListDecoder) error {
+
+	file, err := fsys.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	data, lines, err := readList(file)
+	if err != nil {
+		return err
+	}
+
+	if err := DecodeList(data, l); err != nil {
+		if errs, ok := err.(DecodeErrors); ok {
+			errs.locate(path, lines)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// readList reads all csv records together with the line number each record starts on.
+func readList(r io.Reader) ([][]string, []int, error) {
+	var data [][]string
+	var lines []int
+
+	reader := csv.NewReader(r)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		line, _ := reader.FieldPos(0)
+
+		data = append(data, record)
+		lines = append(lines, line)
+	}
+
+	return data, lines, nil
+}
+
 func StoreList(path string, l ListEncoder) error {
 
 	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
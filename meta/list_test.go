@@ -438,6 +438,55 @@ func TestList(t *testing.T) {
 				},
 			},
 		},
+		{
+			"testdata/gains.csv",
+			&meta.DataloggerGainList{
+				meta.DataloggerGain{
+					Install: meta.Install{
+						Equipment: meta.Equipment{
+							Make:   "Quanterra",
+							Model:  "Q330HR/6",
+							Serial: "1234",
+						},
+						Span: meta.Span{
+							Start: func() time.Time {
+								v, _ := time.Parse(meta.DateTimeFormat, "2010-01-01T00:00:00Z")
+								return v
+							}(),
+							End: func() time.Time {
+								v, _ := time.Parse(meta.DateTimeFormat, "2015-06-01T00:00:00Z")
+								return v
+							}(),
+						},
+					},
+					Gain:      1,
+					BitWeight: 2.5e-06,
+				},
+				meta.DataloggerGain{
+					Install: meta.Install{
+						Equipment: meta.Equipment{
+							Make:   "Quanterra",
+							Model:  "Q330HR/6",
+							Serial: "1234",
+						},
+						Span: meta.Span{
+							Start: func() time.Time {
+								v, _ := time.Parse(meta.DateTimeFormat, "2015-06-01T00:00:00Z")
+								return v
+							}(),
+							End: func() time.Time {
+								v, _ := time.Parse(meta.DateTimeFormat, "9999-01-01T00:00:00Z")
+								return v
+							}(),
+						},
+					},
+					Gain:      20,
+					BitWeight: 2.5e-07,
+					Filter:    "Q330HR_FLbelow100-100",
+					Notes:     "High gain pre-amp",
+				},
+			},
+		},
 		{
 			"testdata/metsensors.csv",
 			&meta.InstalledMetSensorList{
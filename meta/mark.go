@@ -1,7 +1,6 @@
 package meta
 
 import (
-	"fmt"
 	"sort"
 	"strconv"
 	"strings"
@@ -19,9 +18,24 @@ const (
 	markDatum
 	markStartTime
 	markEndTime
-	markLast
 )
 
+var markSchema = schema{
+	version: 1,
+	columns: []column{
+		markCode:      {name: "Mark"},
+		markNetwork:   {name: "Network"},
+		markIgs:       {name: "Igs", kind: boolKind},
+		markName:      {name: "Name"},
+		markLatitude:  {name: "Latitude", kind: numberKind},
+		markLongitude: {name: "Longitude", kind: numberKind},
+		markElevation: {name: "Elevation", kind: numberKind},
+		markDatum:     {name: "Datum"},
+		markStartTime: {name: "Start Date"},
+		markEndTime:   {name: "End Date"},
+	},
+}
+
 type Mark struct {
 	Reference
 	Point
@@ -32,23 +46,38 @@ type Mark struct {
 
 type MarkList []Mark
 
-func (m MarkList) Len() int           { return len(m) }
-func (m MarkList) Swap(i, j int)      { m[i], m[j] = m[j], m[i] }
-func (m MarkList) Less(i, j int) bool { return m[i].Code < m[j].Code }
+func (m MarkList) Len() int                          { return len(m) }
+func (m MarkList) Swap(i, j int)                     { m[i], m[j] = m[j], m[i] }
+func (m MarkList) Less(i, j int) bool                { return m[i].Code < m[j].Code }
+func (m MarkList) schema() schema                    { return markSchema }
+func (m MarkList) MarshalJSON() ([]byte, error)      { return marshalJSON(m) }
+func (m *MarkList) UnmarshalJSON(b []byte) error     { return unmarshalJSON(b, m) }
+func (m MarkList) MarshalYAML() (interface{}, error) { return marshalYAML(m) }
+func (m *MarkList) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalYAML(unmarshal, m)
+}
+
+func (m Mark) MarshalJSON() ([]byte, error) { return marshalRecordJSON(MarkList{m}) }
+func (m *Mark) UnmarshalJSON(b []byte) error {
+	var l MarkList
+	if err := unmarshalRecordJSON(b, &l); err != nil {
+		return err
+	}
+	*m = l[0]
+	return nil
+}
+func (m Mark) MarshalYAML() (interface{}, error) { return marshalRecordYAML(MarkList{m}) }
+func (m *Mark) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var l MarkList
+	if err := unmarshalRecordYAML(unmarshal, &l); err != nil {
+		return err
+	}
+	*m = l[0]
+	return nil
+}
 
 func (m MarkList) encode() [][]string {
-	data := [][]string{{
-		"Mark",
-		"Network",
-		"Igs",
-		"Name",
-		"Latitude",
-		"Longitude",
-		"Elevation",
-		"Datum",
-		"Start Date",
-		"End Date",
-	}}
+	data := [][]string{markSchema.header()}
 	for _, v := range m {
 		data = append(data, []string{
 			strings.TrimSpace(v.Code),
@@ -62,10 +91,10 @@ func (m MarkList) encode() [][]string {
 			strings.TrimSpace(v.Name),
 			strconv.FormatFloat(v.Latitude, 'g', -1, 64),
 			strconv.FormatFloat(v.Longitude, 'g', -1, 64),
-			strconv.FormatFloat(v.Elevation, 'g', -1, 64),
+			formatNullable(v.Elevation, v.nullElevation),
 			strings.TrimSpace(v.Datum),
 			v.Start.Format(DateTimeFormat),
-			v.End.Format(DateTimeFormat),
+			formatEnd(v.End),
 		})
 	}
 	return data
@@ -74,11 +103,13 @@ func (m MarkList) encode() [][]string {
 func (m *MarkList) decode(data [][]string) error {
 	var marks []Mark
 	if len(data) > 1 {
-		for _, d := range data[1:] {
-			if len(d) != markLast {
-				return fmt.Errorf("incorrect number of installed mark fields")
-			}
-			var err error
+		fields, err := markSchema.fields(data[0])
+		if err != nil {
+			return err
+		}
+		var errs DecodeErrors
+		for i, row := range data[1:] {
+			d := fields.remap(row)
 
 			var igs bool
 			if igs, err = strconv.ParseBool(d[markIgs]); err != nil {
@@ -88,27 +119,34 @@ func (m *MarkList) decode(data [][]string) error {
 				case "n", "N", "no", "NO":
 					igs = false
 				default:
-					return err
+					errs = append(errs, fields.invalid(i+1, d, markIgs, err))
+					continue
 				}
 			}
 
 			var lat, lon, elev float64
+			var nullElev bool
 			if lat, err = strconv.ParseFloat(d[markLatitude], 64); err != nil {
-				return err
+				errs = append(errs, fields.invalid(i+1, d, markLatitude, err))
+				continue
 			}
 			if lon, err = strconv.ParseFloat(d[markLongitude], 64); err != nil {
-				return err
+				errs = append(errs, fields.invalid(i+1, d, markLongitude, err))
+				continue
 			}
-			if elev, err = strconv.ParseFloat(d[markElevation], 64); err != nil {
-				return err
+			if elev, nullElev, err = parseNullable(d[markElevation]); err != nil {
+				errs = append(errs, fields.invalid(i+1, d, markElevation, err))
+				continue
 			}
 
 			var start, end time.Time
 			if start, err = time.Parse(DateTimeFormat, d[markStartTime]); err != nil {
-				return err
+				errs = append(errs, fields.invalid(i+1, d, markStartTime, err))
+				continue
 			}
-			if end, err = time.Parse(DateTimeFormat, d[markEndTime]); err != nil {
-				return err
+			if end, err = parseEnd(d[markEndTime]); err != nil {
+				errs = append(errs, fields.invalid(i+1, d, markEndTime, err))
+				continue
 			}
 			marks = append(marks, Mark{
 				Reference: Reference{
@@ -125,11 +163,17 @@ func (m *MarkList) decode(data [][]string) error {
 					Longitude: lon,
 					Elevation: elev,
 					Datum:     strings.TrimSpace(d[markDatum]),
+
+					nullElevation: nullElev,
 				},
 				Igs: igs,
 			})
 		}
 
+		if errs != nil {
+			return errs
+		}
+
 		*m = MarkList(marks)
 	}
 	return nil
@@ -1,7 +1,8 @@
 package meta
 
 import (
-	//	"strconv"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -18,6 +19,19 @@ type Point struct {
 	Longitude float64
 	Elevation float64
 	Datum     string
+
+	// the elevation is not known
+	nullElevation bool
+}
+
+// HasElevation returns whether the point elevation is known.
+func (p Point) HasElevation() bool {
+	return !p.nullElevation
+}
+
+// SetElevation updates the point elevation, a nil value marks the elevation as not known.
+func (p *Point) SetElevation(elevation *float64) {
+	p.Elevation, p.nullElevation = nullable(elevation)
 }
 
 type Orientation struct {
@@ -29,18 +43,185 @@ type Offset struct {
 	Vertical float64
 	North    float64
 	East     float64
+
+	// the offsets are not known
+	nullVertical bool
+	nullNorth    bool
+	nullEast     bool
+}
+
+// HasVertical returns whether the vertical offset is known.
+func (o Offset) HasVertical() bool {
+	return !o.nullVertical
+}
+
+// HasNorth returns whether the north offset is known.
+func (o Offset) HasNorth() bool {
+	return !o.nullNorth
+}
+
+// HasEast returns whether the east offset is known.
+func (o Offset) HasEast() bool {
+	return !o.nullEast
+}
+
+// SetVertical updates the vertical offset, a nil value marks the offset as not known.
+func (o *Offset) SetVertical(vertical *float64) {
+	o.Vertical, o.nullVertical = nullable(vertical)
+}
+
+// SetNorth updates the north offset, a nil value marks the offset as not known.
+func (o *Offset) SetNorth(north *float64) {
+	o.North, o.nullNorth = nullable(north)
+}
+
+// SetEast updates the east offset, a nil value marks the offset as not known.
+func (o *Offset) SetEast(east *float64) {
+	o.East, o.nullEast = nullable(east)
 }
 
 type Scale struct {
 	Factor float64
 	Bias   float64
+
+	// the scale values are not known
+	nullFactor bool
+	nullBias   bool
+}
+
+// HasFactor returns whether the scale factor is known.
+func (s Scale) HasFactor() bool {
+	return !s.nullFactor
+}
+
+// HasBias returns whether the scale bias is known.
+func (s Scale) HasBias() bool {
+	return !s.nullBias
+}
+
+// SetFactor updates the scale factor, a nil value marks the factor as not known.
+func (s *Scale) SetFactor(factor *float64) {
+	s.Factor, s.nullFactor = nullable(factor)
+}
+
+// SetBias updates the scale bias, a nil value marks the bias as not known.
+func (s *Scale) SetBias(bias *float64) {
+	s.Bias, s.nullBias = nullable(bias)
 }
 
+// OpenEnd is the end time used to represent a span which has not yet finished,
+// i.e. equipment that is still installed.
+var OpenEnd = time.Date(9999, time.January, 1, 0, 0, 0, 0, time.UTC)
+
 type Span struct {
 	Start time.Time
 	End   time.Time
 }
 
+// IsOpen returns whether the span has not yet finished.
+func (s Span) IsOpen() bool {
+	return s.End.IsZero() || !s.End.Before(OpenEnd)
+}
+
+// Contains returns whether the given time is within the span, the start time is inclusive
+// whereas the end time is exclusive.
+func (s Span) Contains(t time.Time) bool {
+	switch {
+	case t.Before(s.Start):
+		return false
+	case s.IsOpen():
+		return true
+	default:
+		return t.Before(s.End)
+	}
+}
+
+// Overlaps returns whether the two spans have any time in common.
+func (s Span) Overlaps(span Span) bool {
+	switch {
+	case !s.IsOpen() && !span.Start.Before(s.End):
+		return false
+	case !span.IsOpen() && !s.Start.Before(span.End):
+		return false
+	default:
+		return true
+	}
+}
+
+// Intersect returns the time common to both spans, and whether the spans overlap at all.
+func (s Span) Intersect(span Span) (Span, bool) {
+	if !s.Overlaps(span) {
+		return Span{}, false
+	}
+
+	start, end := s.Start, s.End
+	if span.Start.After(start) {
+		start = span.Start
+	}
+	switch {
+	case s.IsOpen():
+		end = span.End
+	case span.IsOpen():
+	case span.End.Before(end):
+		end = span.End
+	}
+
+	return Span{Start: start, End: end}, true
+}
+
+// Duration returns the length of the span, for open spans this is the time from the start until
+// the given reference time.
+func (s Span) Duration(at time.Time) time.Duration {
+	if s.IsOpen() {
+		return at.Sub(s.Start)
+	}
+	return s.End.Sub(s.Start)
+}
+
+// nullable converts an optional value into a value and a null flag.
+func nullable(v *float64) (float64, bool) {
+	if v == nil {
+		return 0.0, true
+	}
+	return *v, false
+}
+
+// parseNullable decodes an optional float value, an empty value returns a null flag.
+func parseNullable(s string) (float64, bool, error) {
+	if strings.TrimSpace(s) == "" {
+		return 0.0, true, nil
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0.0, false, err
+	}
+	return v, false, nil
+}
+
+// formatNullable encodes an optional float value, null values are returned as an empty string.
+func formatNullable(v float64, null bool) string {
+	if null {
+		return ""
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// parseEnd decodes an end time, an empty value is treated as an open span.
+func parseEnd(s string) (time.Time, error) {
+	if strings.TrimSpace(s) == "" {
+		return OpenEnd, nil
+	}
+	return time.Parse(DateTimeFormat, s)
+}
+
+// formatEnd encodes an end time, open spans are written using the OpenEnd time.
+func formatEnd(t time.Time) string {
+	if t.IsZero() || !t.Before(OpenEnd) {
+		return OpenEnd.Format(DateTimeFormat)
+	}
+	return t.Format(DateTimeFormat)
+}
+
 type Equipment struct {
 	Make   string
 	Model  string
@@ -0,0 +1,145 @@
+package meta_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GeoNet/delta/meta"
+)
+
+func TestSpan(t *testing.T) {
+
+	at := func(s string) time.Time {
+		v, err := time.Parse(meta.DateTimeFormat, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v
+	}
+
+	closed := meta.Span{Start: at("2010-01-01T00:00:00Z"), End: at("2012-01-01T00:00:00Z")}
+	open := meta.Span{Start: at("2011-01-01T00:00:00Z"), End: meta.OpenEnd}
+	later := meta.Span{Start: at("2012-01-01T00:00:00Z"), End: meta.OpenEnd}
+
+	if closed.IsOpen() {
+		t.Error("closed span should not be open")
+	}
+	if !open.IsOpen() {
+		t.Error("open span should be open")
+	}
+
+	var contains = []struct {
+		s meta.Span
+		t time.Time
+		r bool
+	}{
+		{closed, at("2009-12-31T23:59:59Z"), false},
+		{closed, at("2010-01-01T00:00:00Z"), true},
+		{closed, at("2012-01-01T00:00:00Z"), false},
+		{open, at("2010-12-31T23:59:59Z"), false},
+		{open, at("9999-12-31T00:00:00Z"), true},
+	}
+	for _, c := range contains {
+		if r := c.s.Contains(c.t); r != c.r {
+			t.Errorf("invalid span contains %v: got %v, expected %v", c.t, r, c.r)
+		}
+	}
+
+	if !closed.Overlaps(open) || !open.Overlaps(closed) {
+		t.Error("spans should overlap")
+	}
+	if closed.Overlaps(later) || later.Overlaps(closed) {
+		t.Error("adjacent spans should not overlap")
+	}
+
+	span, ok := closed.Intersect(open)
+	if !ok {
+		t.Fatal("spans should intersect")
+	}
+	if !span.Start.Equal(open.Start) || !span.End.Equal(closed.End) {
+		t.Errorf("invalid span intersection: %v", span)
+	}
+	if span, ok := open.Intersect(later); !ok || !span.Start.Equal(later.Start) || !span.IsOpen() {
+		t.Errorf("invalid open span intersection: %v", span)
+	}
+	if _, ok := closed.Intersect(later); ok {
+		t.Error("adjacent spans should not intersect")
+	}
+
+	if d := closed.Duration(later.Start); d != closed.End.Sub(closed.Start) {
+		t.Errorf("invalid span duration: %v", d)
+	}
+	if d := open.Duration(later.Start); d != later.Start.Sub(open.Start) {
+		t.Errorf("invalid open span duration: %v", d)
+	}
+
+	t.Log("Check open span encoding")
+	{
+		var connections meta.ConnectionList
+		if err := meta.UnmarshalList([]byte("Station,Location,Place,Role,Start Date,End Date\nAPZ,10,APZ,,2009-01-01T00:00:00Z,\n"), &connections); err != nil {
+			t.Fatal(err)
+		}
+		if len(connections) != 1 || !connections[0].IsOpen() {
+			t.Fatalf("invalid open connection decode: %v", connections)
+		}
+
+		connections[0].End = time.Time{}
+		if s := string(meta.MarshalList(connections)); s != "Station,Location,Place,Role,Start Date,End Date\nAPZ,10,APZ,,2009-01-01T00:00:00Z,9999-01-01T00:00:00Z\n" {
+			t.Errorf("invalid open connection encode: %q", s)
+		}
+	}
+}
+
+func TestNullable(t *testing.T) {
+
+	raw := "Make,Model,Serial,Station,Location,Azimuth,Dip,Depth,North,East,Scale Factor,Scale Bias,Start Date,End Date\n" +
+		"Kinemetrics,FBA-23,25038,WEL,20,0,0,,0,,1,,1991-07-30T00:00:00Z,9999-01-01T00:00:00Z\n"
+
+	var sensors meta.InstalledSensorList
+	if err := meta.UnmarshalList([]byte(raw), &sensors); err != nil {
+		t.Fatal(err)
+	}
+	if len(sensors) != 1 {
+		t.Fatalf("invalid number of sensors: got %d, expected %d", len(sensors), 1)
+	}
+
+	s := sensors[0]
+	if s.HasVertical() || !s.HasNorth() || s.HasEast() || !s.HasFactor() || s.HasBias() {
+		t.Errorf("invalid nullable sensor decode: %v", s)
+	}
+	if r := string(meta.MarshalList(sensors)); r != raw {
+		t.Errorf("invalid nullable sensor encode: %q", r)
+	}
+
+	rawGains := "Make,Model,Serial,Gain,Bit Weight,Filter,Start Date,End Date,Notes\n" +
+		"Quanterra,Q330HR/6,1234,20,,,2015-06-01T00:00:00Z,9999-01-01T00:00:00Z,\n"
+
+	var gains meta.DataloggerGainList
+	if err := meta.UnmarshalList([]byte(rawGains), &gains); err != nil {
+		t.Fatal(err)
+	}
+	if len(gains) != 1 || gains[0].HasBitWeight() || gains[0].Gain != 20 {
+		t.Errorf("invalid nullable gain decode: %v", gains)
+	}
+	if r := string(meta.MarshalList(gains)); r != rawGains {
+		t.Errorf("invalid nullable gain encode: %q", r)
+	}
+	if err := meta.UnmarshalList([]byte(strings.Replace(rawGains, ",20,", ",0,", 1)), &gains); err == nil {
+		t.Error("expected zero gain error")
+	}
+
+	var point meta.Point
+	if !point.HasElevation() {
+		t.Error("point elevation should be known by default")
+	}
+	point.SetElevation(nil)
+	if point.HasElevation() {
+		t.Error("point elevation should not be known")
+	}
+	elevation := 12.5
+	point.SetElevation(&elevation)
+	if !point.HasElevation() || point.Elevation != elevation {
+		t.Errorf("invalid point elevation: %v", point)
+	}
+}
@@ -1,7 +1,6 @@
 package meta
 
 import (
-	"fmt"
 	"sort"
 	"strconv"
 	"strings"
@@ -23,9 +22,28 @@ const (
 	installedMetsensorDatum
 	installedMetsensorStart
 	installedMetsensorStop
-	installedMetsensorLast
 )
 
+var installedMetsensorSchema = schema{
+	version: 1,
+	columns: []column{
+		installedMetsensorMake:                {name: "Make"},
+		installedMetsensorModel:               {name: "Model"},
+		installedMetsensorSerial:              {name: "Serial"},
+		installedMetsensorMark:                {name: "Mark"},
+		installedMetsensorIMSComment:          {name: "IMS Comment"},
+		installedMetsensorHumidityAccuracy:    {name: "Humidity", kind: numberKind},
+		installedMetsensorPressureAccuracy:    {name: "Pressure", kind: numberKind},
+		installedMetsensorTemperatureAccuracy: {name: "Temperature", kind: numberKind},
+		installedMetsensorLatitude:            {name: "Latitude", kind: numberKind},
+		installedMetsensorLongitude:           {name: "Longitude", kind: numberKind},
+		installedMetsensorElevation:           {name: "Elevation", kind: numberKind},
+		installedMetsensorDatum:               {name: "Datum"},
+		installedMetsensorStart:               {name: "Start Date"},
+		installedMetsensorStop:                {name: "End Date"},
+	},
+}
+
 type MetSensorAccuracy struct {
 	Humidity    float64
 	Pressure    float64
@@ -43,27 +61,42 @@ type InstalledMetSensor struct {
 
 type InstalledMetSensorList []InstalledMetSensor
 
-func (m InstalledMetSensorList) Len() int           { return len(m) }
-func (m InstalledMetSensorList) Swap(i, j int)      { m[i], m[j] = m[j], m[i] }
-func (m InstalledMetSensorList) Less(i, j int) bool { return m[i].Install.less(m[j].Install) }
+func (m InstalledMetSensorList) Len() int                          { return len(m) }
+func (m InstalledMetSensorList) Swap(i, j int)                     { m[i], m[j] = m[j], m[i] }
+func (m InstalledMetSensorList) Less(i, j int) bool                { return m[i].Install.less(m[j].Install) }
+func (m InstalledMetSensorList) schema() schema                    { return installedMetsensorSchema }
+func (m InstalledMetSensorList) MarshalJSON() ([]byte, error)      { return marshalJSON(m) }
+func (m *InstalledMetSensorList) UnmarshalJSON(b []byte) error     { return unmarshalJSON(b, m) }
+func (m InstalledMetSensorList) MarshalYAML() (interface{}, error) { return marshalYAML(m) }
+func (m *InstalledMetSensorList) UnmarshalYAML(f func(interface{}) error) error {
+	return unmarshalYAML(f, m)
+}
+
+func (m InstalledMetSensor) MarshalJSON() ([]byte, error) {
+	return marshalRecordJSON(InstalledMetSensorList{m})
+}
+func (m *InstalledMetSensor) UnmarshalJSON(b []byte) error {
+	var l InstalledMetSensorList
+	if err := unmarshalRecordJSON(b, &l); err != nil {
+		return err
+	}
+	*m = l[0]
+	return nil
+}
+func (m InstalledMetSensor) MarshalYAML() (interface{}, error) {
+	return marshalRecordYAML(InstalledMetSensorList{m})
+}
+func (m *InstalledMetSensor) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var l InstalledMetSensorList
+	if err := unmarshalRecordYAML(unmarshal, &l); err != nil {
+		return err
+	}
+	*m = l[0]
+	return nil
+}
 
 func (m InstalledMetSensorList) encode() [][]string {
-	data := [][]string{{
-		"Make",
-		"Model",
-		"Serial",
-		"Mark",
-		"IMS Comment",
-		"Humidity",
-		"Pressure",
-		"Temperature",
-		"Latitude",
-		"Longitude",
-		"Elevation",
-		"Datum",
-		"Start Date",
-		"End Date",
-	}}
+	data := [][]string{installedMetsensorSchema.header()}
 	for _, v := range m {
 		data = append(data, []string{
 			strings.TrimSpace(v.Make),
@@ -76,10 +109,10 @@ func (m InstalledMetSensorList) encode() [][]string {
 			strconv.FormatFloat(v.Accuracy.Temperature, 'g', -1, 64),
 			strconv.FormatFloat(v.Latitude, 'g', -1, 64),
 			strconv.FormatFloat(v.Longitude, 'g', -1, 64),
-			strconv.FormatFloat(v.Elevation, 'g', -1, 64),
+			formatNullable(v.Elevation, v.nullElevation),
 			strings.TrimSpace(v.Datum),
 			v.Start.Format(DateTimeFormat),
-			v.End.Format(DateTimeFormat),
+			formatEnd(v.End),
 		})
 	}
 	return data
@@ -88,40 +121,51 @@ func (m InstalledMetSensorList) encode() [][]string {
 func (m *InstalledMetSensorList) decode(data [][]string) error {
 	var installedMetsensors []InstalledMetSensor
 	if len(data) > 1 {
-		for _, d := range data[1:] {
-			if len(d) != installedMetsensorLast {
-				return fmt.Errorf("incorrect number of installed metsensor fields")
-			}
-			var err error
+		fields, err := installedMetsensorSchema.fields(data[0])
+		if err != nil {
+			return err
+		}
+		var errs DecodeErrors
+		for i, row := range data[1:] {
+			d := fields.remap(row)
 
 			var h, p, t float64
 			if h, err = strconv.ParseFloat(d[installedMetsensorHumidityAccuracy], 64); err != nil {
-				return err
+				errs = append(errs, fields.invalid(i+1, d, installedMetsensorHumidityAccuracy, err))
+				continue
 			}
 			if p, err = strconv.ParseFloat(d[installedMetsensorPressureAccuracy], 64); err != nil {
-				return err
+				errs = append(errs, fields.invalid(i+1, d, installedMetsensorPressureAccuracy, err))
+				continue
 			}
 			if t, err = strconv.ParseFloat(d[installedMetsensorTemperatureAccuracy], 64); err != nil {
-				return err
+				errs = append(errs, fields.invalid(i+1, d, installedMetsensorTemperatureAccuracy, err))
+				continue
 			}
 
 			var lat, lon, elev float64
+			var nullElev bool
 			if lat, err = strconv.ParseFloat(d[installedMetsensorLatitude], 64); err != nil {
-				return err
+				errs = append(errs, fields.invalid(i+1, d, installedMetsensorLatitude, err))
+				continue
 			}
 			if lon, err = strconv.ParseFloat(d[installedMetsensorLongitude], 64); err != nil {
-				return err
+				errs = append(errs, fields.invalid(i+1, d, installedMetsensorLongitude, err))
+				continue
 			}
-			if elev, err = strconv.ParseFloat(d[installedMetsensorElevation], 64); err != nil {
-				return err
+			if elev, nullElev, err = parseNullable(d[installedMetsensorElevation]); err != nil {
+				errs = append(errs, fields.invalid(i+1, d, installedMetsensorElevation, err))
+				continue
 			}
 
 			var start, end time.Time
 			if start, err = time.Parse(DateTimeFormat, d[installedMetsensorStart]); err != nil {
-				return err
+				errs = append(errs, fields.invalid(i+1, d, installedMetsensorStart, err))
+				continue
 			}
-			if end, err = time.Parse(DateTimeFormat, d[installedMetsensorStop]); err != nil {
-				return err
+			if end, err = parseEnd(d[installedMetsensorStop]); err != nil {
+				errs = append(errs, fields.invalid(i+1, d, installedMetsensorStop, err))
+				continue
 			}
 
 			installedMetsensors = append(installedMetsensors, InstalledMetSensor{
@@ -141,6 +185,8 @@ func (m *InstalledMetSensorList) decode(data [][]string) error {
 					Longitude: lon,
 					Elevation: elev,
 					Datum:     strings.TrimSpace(d[installedMetsensorDatum]),
+
+					nullElevation: nullElev,
 				},
 				Mark:       strings.TrimSpace(d[installedMetsensorMark]),
 				IMSComment: strings.TrimSpace(d[installedMetsensorIMSComment]),
@@ -152,6 +198,10 @@ func (m *InstalledMetSensorList) decode(data [][]string) error {
 			})
 		}
 
+		if errs != nil {
+			return errs
+		}
+
 		*m = InstalledMetSensorList(installedMetsensors)
 	}
 	return nil
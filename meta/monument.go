@@ -1,7 +1,6 @@
 package meta
 
 import (
-	"fmt"
 	"sort"
 	"strconv"
 	"strings"
@@ -20,9 +19,25 @@ const (
 	monumentEnd
 	monumentBedrock
 	monumentGeology
-	monumentLast
 )
 
+var monumentSchema = schema{
+	version: 1,
+	columns: []column{
+		monumentMark:               {name: "Mark"},
+		monumentDomesNumber:        {name: "Domes Number"},
+		monumentMarkType:           {name: "Mark Type"},
+		monumentType:               {name: "Type"},
+		monumentGroundRelationship: {name: "Ground Relationship", kind: numberKind},
+		monumentFoundationType:     {name: "Foundation Type"},
+		monumentFoundationDepth:    {name: "Foundation Depth", kind: numberKind},
+		monumentStart:              {name: "Start Date"},
+		monumentEnd:                {name: "End Date"},
+		monumentBedrock:            {name: "Bedrock", optional: true},
+		monumentGeology:            {name: "Geology", optional: true},
+	},
+}
+
 type Monument struct {
 	Span
 
@@ -39,24 +54,38 @@ type Monument struct {
 
 type MonumentList []Monument
 
-func (m MonumentList) Len() int           { return len(m) }
-func (m MonumentList) Swap(i, j int)      { m[i], m[j] = m[j], m[i] }
-func (m MonumentList) Less(i, j int) bool { return m[i].Mark < m[j].Mark }
+func (m MonumentList) Len() int                          { return len(m) }
+func (m MonumentList) Swap(i, j int)                     { m[i], m[j] = m[j], m[i] }
+func (m MonumentList) Less(i, j int) bool                { return m[i].Mark < m[j].Mark }
+func (m MonumentList) schema() schema                    { return monumentSchema }
+func (m MonumentList) MarshalJSON() ([]byte, error)      { return marshalJSON(m) }
+func (m *MonumentList) UnmarshalJSON(b []byte) error     { return unmarshalJSON(b, m) }
+func (m MonumentList) MarshalYAML() (interface{}, error) { return marshalYAML(m) }
+func (m *MonumentList) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalYAML(unmarshal, m)
+}
+
+func (m Monument) MarshalJSON() ([]byte, error) { return marshalRecordJSON(MonumentList{m}) }
+func (m *Monument) UnmarshalJSON(b []byte) error {
+	var l MonumentList
+	if err := unmarshalRecordJSON(b, &l); err != nil {
+		return err
+	}
+	*m = l[0]
+	return nil
+}
+func (m Monument) MarshalYAML() (interface{}, error) { return marshalRecordYAML(MonumentList{m}) }
+func (m *Monument) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var l MonumentList
+	if err := unmarshalRecordYAML(unmarshal, &l); err != nil {
+		return err
+	}
+	*m = l[0]
+	return nil
+}
 
 func (m MonumentList) encode() [][]string {
-	data := [][]string{{
-		"Mark",
-		"Domes Number",
-		"Mark Type",
-		"Type",
-		"Ground Relationship",
-		"Foundation Type",
-		"Foundation Depth",
-		"Start Date",
-		"End Date",
-		"Bedrock",
-		"Geology",
-	}}
+	data := [][]string{monumentSchema.header()}
 	for _, v := range m {
 		data = append(data, []string{
 			strings.TrimSpace(v.Mark),
@@ -67,7 +96,7 @@ func (m MonumentList) encode() [][]string {
 			strings.TrimSpace(v.FoundationType),
 			strconv.FormatFloat(v.FoundationDepth, 'g', -1, 64),
 			v.Start.Format(DateTimeFormat),
-			v.End.Format(DateTimeFormat),
+			formatEnd(v.End),
 			strings.TrimSpace(v.Bedrock),
 			strings.TrimSpace(v.Geology),
 		})
@@ -78,27 +107,33 @@ func (m MonumentList) encode() [][]string {
 func (m *MonumentList) decode(data [][]string) error {
 	var monuments []Monument
 	if len(data) > 1 {
-		for _, d := range data[1:] {
-			var err error
+		fields, err := monumentSchema.fields(data[0])
+		if err != nil {
+			return err
+		}
+		var errs DecodeErrors
+		for i, row := range data[1:] {
+			d := fields.remap(row)
 
-			if len(d) != monumentLast {
-				return fmt.Errorf("incorrect number of monument fields")
-			}
 			var ground float64
 			if ground, err = strconv.ParseFloat(d[monumentGroundRelationship], 64); err != nil {
-				return err
+				errs = append(errs, fields.invalid(i+1, d, monumentGroundRelationship, err))
+				continue
 			}
 			var depth float64
 			if depth, err = strconv.ParseFloat(d[monumentFoundationDepth], 64); err != nil {
-				return err
+				errs = append(errs, fields.invalid(i+1, d, monumentFoundationDepth, err))
+				continue
 			}
 
 			var start, end time.Time
 			if start, err = time.Parse(DateTimeFormat, d[monumentStart]); err != nil {
-				return err
+				errs = append(errs, fields.invalid(i+1, d, monumentStart, err))
+				continue
 			}
-			if end, err = time.Parse(DateTimeFormat, d[monumentEnd]); err != nil {
-				return err
+			if end, err = parseEnd(d[monumentEnd]); err != nil {
+				errs = append(errs, fields.invalid(i+1, d, monumentEnd, err))
+				continue
 			}
 
 			monuments = append(monuments, Monument{
@@ -119,6 +154,10 @@ func (m *MonumentList) decode(data [][]string) error {
 			})
 		}
 
+		if errs != nil {
+			return errs
+		}
+
 		*m = MonumentList(monuments)
 	}
 	return nil
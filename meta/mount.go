@@ -1,7 +1,6 @@
 package meta
 
 import (
-	"fmt"
 	"sort"
 	"strconv"
 	"strings"
@@ -19,9 +18,24 @@ const (
 	mountDescription
 	mountStart
 	mountEnd
-	mountLast
 )
 
+var mountSchema = schema{
+	version: 1,
+	columns: []column{
+		mountCode:        {name: "Mount"},
+		mountNetwork:     {name: "Network"},
+		mountName:        {name: "Name"},
+		mountLatitude:    {name: "Latitude", kind: numberKind},
+		mountLongitude:   {name: "Longitude", kind: numberKind},
+		mountElevation:   {name: "Elevation", kind: numberKind},
+		mountDatum:       {name: "Datum"},
+		mountDescription: {name: "Description"},
+		mountStart:       {name: "Start Date"},
+		mountEnd:         {name: "End Date"},
+	},
+}
+
 type Mount struct {
 	Reference
 	Point
@@ -32,23 +46,38 @@ type Mount struct {
 
 type MountList []Mount
 
-func (m MountList) Len() int           { return len(m) }
-func (m MountList) Swap(i, j int)      { m[i], m[j] = m[j], m[i] }
-func (m MountList) Less(i, j int) bool { return m[i].Code < m[j].Code }
+func (m MountList) Len() int                          { return len(m) }
+func (m MountList) Swap(i, j int)                     { m[i], m[j] = m[j], m[i] }
+func (m MountList) Less(i, j int) bool                { return m[i].Code < m[j].Code }
+func (m MountList) schema() schema                    { return mountSchema }
+func (m MountList) MarshalJSON() ([]byte, error)      { return marshalJSON(m) }
+func (m *MountList) UnmarshalJSON(b []byte) error     { return unmarshalJSON(b, m) }
+func (m MountList) MarshalYAML() (interface{}, error) { return marshalYAML(m) }
+func (m *MountList) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalYAML(unmarshal, m)
+}
+
+func (m Mount) MarshalJSON() ([]byte, error) { return marshalRecordJSON(MountList{m}) }
+func (m *Mount) UnmarshalJSON(b []byte) error {
+	var l MountList
+	if err := unmarshalRecordJSON(b, &l); err != nil {
+		return err
+	}
+	*m = l[0]
+	return nil
+}
+func (m Mount) MarshalYAML() (interface{}, error) { return marshalRecordYAML(MountList{m}) }
+func (m *Mount) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var l MountList
+	if err := unmarshalRecordYAML(unmarshal, &l); err != nil {
+		return err
+	}
+	*m = l[0]
+	return nil
+}
 
 func (m MountList) encode() [][]string {
-	data := [][]string{{
-		"Mount",
-		"Network",
-		"Name",
-		"Latitude",
-		"Longitude",
-		"Elevation",
-		"Datum",
-		"Description",
-		"Start Date",
-		"End Date",
-	}}
+	data := [][]string{mountSchema.header()}
 	for _, v := range m {
 		data = append(data, []string{
 			strings.TrimSpace(v.Code),
@@ -56,11 +85,11 @@ func (m MountList) encode() [][]string {
 			strings.TrimSpace(v.Name),
 			strconv.FormatFloat(v.Latitude, 'g', -1, 64),
 			strconv.FormatFloat(v.Longitude, 'g', -1, 64),
-			strconv.FormatFloat(v.Elevation, 'g', -1, 64),
+			formatNullable(v.Elevation, v.nullElevation),
 			strings.TrimSpace(v.Datum),
 			strings.TrimSpace(v.Description),
 			v.Start.Format(DateTimeFormat),
-			v.End.Format(DateTimeFormat),
+			formatEnd(v.End),
 		})
 	}
 	return data
@@ -69,29 +98,37 @@ func (m MountList) encode() [][]string {
 func (m *MountList) decode(data [][]string) error {
 	var mounts []Mount
 	if len(data) > 1 {
-		for _, d := range data[1:] {
-			if len(d) != mountLast {
-				return fmt.Errorf("incorrect number of installed mount fields")
-			}
-			var err error
+		fields, err := mountSchema.fields(data[0])
+		if err != nil {
+			return err
+		}
+		var errs DecodeErrors
+		for i, row := range data[1:] {
+			d := fields.remap(row)
 
 			var lat, lon, elev float64
+			var nullElev bool
 			if lat, err = strconv.ParseFloat(d[mountLatitude], 64); err != nil {
-				return err
+				errs = append(errs, fields.invalid(i+1, d, mountLatitude, err))
+				continue
 			}
 			if lon, err = strconv.ParseFloat(d[mountLongitude], 64); err != nil {
-				return err
+				errs = append(errs, fields.invalid(i+1, d, mountLongitude, err))
+				continue
 			}
-			if elev, err = strconv.ParseFloat(d[mountElevation], 64); err != nil {
-				return err
+			if elev, nullElev, err = parseNullable(d[mountElevation]); err != nil {
+				errs = append(errs, fields.invalid(i+1, d, mountElevation, err))
+				continue
 			}
 
 			var start, end time.Time
 			if start, err = time.Parse(DateTimeFormat, d[mountStart]); err != nil {
-				return err
+				errs = append(errs, fields.invalid(i+1, d, mountStart, err))
+				continue
 			}
-			if end, err = time.Parse(DateTimeFormat, d[mountEnd]); err != nil {
-				return err
+			if end, err = parseEnd(d[mountEnd]); err != nil {
+				errs = append(errs, fields.invalid(i+1, d, mountEnd, err))
+				continue
 			}
 
 			mounts = append(mounts, Mount{
@@ -105,6 +142,8 @@ func (m *MountList) decode(data [][]string) error {
 					Longitude: lon,
 					Elevation: elev,
 					Datum:     strings.TrimSpace(d[mountDatum]),
+
+					nullElevation: nullElev,
 				},
 				Span: Span{
 					Start: start,
@@ -114,6 +153,10 @@ func (m *MountList) decode(data [][]string) error {
 			})
 		}
 
+		if errs != nil {
+			return errs
+		}
+
 		*m = MountList(mounts)
 	}
 	return nil
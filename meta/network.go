@@ -1,7 +1,6 @@
 package meta
 
 import (
-	"fmt"
 	"sort"
 	"strconv"
 	"strings"
@@ -12,9 +11,18 @@ const (
 	networkExternal
 	networkDescription
 	networkRestricted
-	networkLast
 )
 
+var networkSchema = schema{
+	version: 1,
+	columns: []column{
+		networkCode:        {name: "Network"},
+		networkExternal:    {name: "External"},
+		networkDescription: {name: "Description"},
+		networkRestricted:  {name: "Restricted", kind: boolKind},
+	},
+}
+
 type Network struct {
 	Code        string
 	External    string
@@ -24,17 +32,38 @@ type Network struct {
 
 type NetworkList []Network
 
-func (n NetworkList) Len() int           { return len(n) }
-func (n NetworkList) Swap(i, j int)      { n[i], n[j] = n[j], n[i] }
-func (n NetworkList) Less(i, j int) bool { return n[i].Code < n[j].Code }
+func (n NetworkList) Len() int                          { return len(n) }
+func (n NetworkList) Swap(i, j int)                     { n[i], n[j] = n[j], n[i] }
+func (n NetworkList) Less(i, j int) bool                { return n[i].Code < n[j].Code }
+func (n NetworkList) schema() schema                    { return networkSchema }
+func (n NetworkList) MarshalJSON() ([]byte, error)      { return marshalJSON(n) }
+func (n *NetworkList) UnmarshalJSON(b []byte) error     { return unmarshalJSON(b, n) }
+func (n NetworkList) MarshalYAML() (interface{}, error) { return marshalYAML(n) }
+func (n *NetworkList) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalYAML(unmarshal, n)
+}
+
+func (n Network) MarshalJSON() ([]byte, error) { return marshalRecordJSON(NetworkList{n}) }
+func (n *Network) UnmarshalJSON(b []byte) error {
+	var l NetworkList
+	if err := unmarshalRecordJSON(b, &l); err != nil {
+		return err
+	}
+	*n = l[0]
+	return nil
+}
+func (n Network) MarshalYAML() (interface{}, error) { return marshalRecordYAML(NetworkList{n}) }
+func (n *Network) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var l NetworkList
+	if err := unmarshalRecordYAML(unmarshal, &l); err != nil {
+		return err
+	}
+	*n = l[0]
+	return nil
+}
 
 func (n NetworkList) encode() [][]string {
-	data := [][]string{{
-		"Network",
-		"External",
-		"Description",
-		"Restricted",
-	}}
+	data := [][]string{networkSchema.header()}
 	for _, v := range n {
 		data = append(data, []string{
 			strings.TrimSpace(v.Code),
@@ -49,15 +78,18 @@ func (n NetworkList) encode() [][]string {
 func (n *NetworkList) decode(data [][]string) error {
 	var networks []Network
 	if len(data) > 1 {
-		for _, d := range data[1:] {
-			if len(d) != networkLast {
-				return fmt.Errorf("incorrect number of installed network fields")
-			}
-			var err error
+		fields, err := networkSchema.fields(data[0])
+		if err != nil {
+			return err
+		}
+		var errs DecodeErrors
+		for i, row := range data[1:] {
+			d := fields.remap(row)
 
 			var restricted bool
 			if restricted, err = strconv.ParseBool(d[networkRestricted]); err != nil {
-				return err
+				errs = append(errs, fields.invalid(i+1, d, networkRestricted, err))
+				continue
 			}
 
 			networks = append(networks, Network{
@@ -68,6 +100,10 @@ func (n *NetworkList) decode(data [][]string) error {
 			})
 		}
 
+		if errs != nil {
+			return errs
+		}
+
 		*n = NetworkList(networks)
 	}
 	return nil
@@ -1,7 +1,6 @@
 package meta
 
 import (
-	"fmt"
 	"sort"
 	"strings"
 	"time"
@@ -14,9 +13,20 @@ const (
 	installedRadomeMark
 	installedRadomeStart
 	installedRadomeEnd
-	installedRadomeLast
 )
 
+var installedRadomeSchema = schema{
+	version: 1,
+	columns: []column{
+		installedRadomeMake:   {name: "Make"},
+		installedRadomeModel:  {name: "Model"},
+		installedRadomeSerial: {name: "Serial"},
+		installedRadomeMark:   {name: "Mark"},
+		installedRadomeStart:  {name: "Start Date"},
+		installedRadomeEnd:    {name: "End Date"},
+	},
+}
+
 type InstalledRadome struct {
 	Install
 
@@ -25,19 +35,42 @@ type InstalledRadome struct {
 
 type InstalledRadomeList []InstalledRadome
 
-func (r InstalledRadomeList) Len() int           { return len(r) }
-func (r InstalledRadomeList) Swap(i, j int)      { r[i], r[j] = r[j], r[i] }
-func (r InstalledRadomeList) Less(i, j int) bool { return r[i].Install.less(r[j].Install) }
+func (r InstalledRadomeList) Len() int                          { return len(r) }
+func (r InstalledRadomeList) Swap(i, j int)                     { r[i], r[j] = r[j], r[i] }
+func (r InstalledRadomeList) Less(i, j int) bool                { return r[i].Install.less(r[j].Install) }
+func (r InstalledRadomeList) schema() schema                    { return installedRadomeSchema }
+func (r InstalledRadomeList) MarshalJSON() ([]byte, error)      { return marshalJSON(r) }
+func (r *InstalledRadomeList) UnmarshalJSON(b []byte) error     { return unmarshalJSON(b, r) }
+func (r InstalledRadomeList) MarshalYAML() (interface{}, error) { return marshalYAML(r) }
+func (r *InstalledRadomeList) UnmarshalYAML(f func(interface{}) error) error {
+	return unmarshalYAML(f, r)
+}
+
+func (r InstalledRadome) MarshalJSON() ([]byte, error) {
+	return marshalRecordJSON(InstalledRadomeList{r})
+}
+func (r *InstalledRadome) UnmarshalJSON(b []byte) error {
+	var l InstalledRadomeList
+	if err := unmarshalRecordJSON(b, &l); err != nil {
+		return err
+	}
+	*r = l[0]
+	return nil
+}
+func (r InstalledRadome) MarshalYAML() (interface{}, error) {
+	return marshalRecordYAML(InstalledRadomeList{r})
+}
+func (r *InstalledRadome) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var l InstalledRadomeList
+	if err := unmarshalRecordYAML(unmarshal, &l); err != nil {
+		return err
+	}
+	*r = l[0]
+	return nil
+}
 
 func (r InstalledRadomeList) encode() [][]string {
-	data := [][]string{{
-		"Make",
-		"Model",
-		"Serial",
-		"Mark",
-		"Start Date",
-		"End Date",
-	}}
+	data := [][]string{installedRadomeSchema.header()}
 	for _, v := range r {
 		data = append(data, []string{
 			strings.TrimSpace(v.Make),
@@ -45,7 +78,7 @@ func (r InstalledRadomeList) encode() [][]string {
 			strings.TrimSpace(v.Serial),
 			strings.TrimSpace(v.Mark),
 			v.Start.Format(DateTimeFormat),
-			v.End.Format(DateTimeFormat),
+			formatEnd(v.End),
 		})
 	}
 	return data
@@ -54,18 +87,22 @@ func (r InstalledRadomeList) encode() [][]string {
 func (r *InstalledRadomeList) decode(data [][]string) error {
 	var radomes []InstalledRadome
 	if len(data) > 1 {
-		for _, d := range data[1:] {
-			if len(d) != installedRadomeLast {
-				return fmt.Errorf("incorrect number of installed radome fields")
-			}
-			var err error
+		fields, err := installedRadomeSchema.fields(data[0])
+		if err != nil {
+			return err
+		}
+		var errs DecodeErrors
+		for i, row := range data[1:] {
+			d := fields.remap(row)
 
 			var start, end time.Time
 			if start, err = time.Parse(DateTimeFormat, d[installedRadomeStart]); err != nil {
-				return err
+				errs = append(errs, fields.invalid(i+1, d, installedRadomeStart, err))
+				continue
 			}
-			if end, err = time.Parse(DateTimeFormat, d[installedRadomeEnd]); err != nil {
-				return err
+			if end, err = parseEnd(d[installedRadomeEnd]); err != nil {
+				errs = append(errs, fields.invalid(i+1, d, installedRadomeEnd, err))
+				continue
 			}
 
 			radomes = append(radomes, InstalledRadome{
@@ -84,6 +121,10 @@ func (r *InstalledRadomeList) decode(data [][]string) error {
 			})
 		}
 
+		if errs != nil {
+			return errs
+		}
+
 		*r = InstalledRadomeList(radomes)
 	}
 	return nil
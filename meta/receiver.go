@@ -1,7 +1,6 @@
 package meta
 
 import (
-	"fmt"
 	"sort"
 	"strings"
 	"time"
@@ -14,9 +13,20 @@ const (
 	deployedReceiverMark
 	deployedReceiverStart
 	deployedReceiverEnd
-	deployedReceiverLast
 )
 
+var deployedReceiverSchema = schema{
+	version: 1,
+	columns: []column{
+		deployedReceiverMake:   {name: "Make"},
+		deployedReceiverModel:  {name: "Model"},
+		deployedReceiverSerial: {name: "Serial"},
+		deployedReceiverMark:   {name: "Mark"},
+		deployedReceiverStart:  {name: "Start Date"},
+		deployedReceiverEnd:    {name: "End Date"},
+	},
+}
+
 type DeployedReceiver struct {
 	Install
 
@@ -25,19 +35,42 @@ type DeployedReceiver struct {
 
 type DeployedReceiverList []DeployedReceiver
 
-func (r DeployedReceiverList) Len() int           { return len(r) }
-func (r DeployedReceiverList) Swap(i, j int)      { r[i], r[j] = r[j], r[i] }
-func (r DeployedReceiverList) Less(i, j int) bool { return r[i].Install.less(r[j].Install) }
+func (r DeployedReceiverList) Len() int                          { return len(r) }
+func (r DeployedReceiverList) Swap(i, j int)                     { r[i], r[j] = r[j], r[i] }
+func (r DeployedReceiverList) Less(i, j int) bool                { return r[i].Install.less(r[j].Install) }
+func (r DeployedReceiverList) schema() schema                    { return deployedReceiverSchema }
+func (r DeployedReceiverList) MarshalJSON() ([]byte, error)      { return marshalJSON(r) }
+func (r *DeployedReceiverList) UnmarshalJSON(b []byte) error     { return unmarshalJSON(b, r) }
+func (r DeployedReceiverList) MarshalYAML() (interface{}, error) { return marshalYAML(r) }
+func (r *DeployedReceiverList) UnmarshalYAML(f func(interface{}) error) error {
+	return unmarshalYAML(f, r)
+}
+
+func (r DeployedReceiver) MarshalJSON() ([]byte, error) {
+	return marshalRecordJSON(DeployedReceiverList{r})
+}
+func (r *DeployedReceiver) UnmarshalJSON(b []byte) error {
+	var l DeployedReceiverList
+	if err := unmarshalRecordJSON(b, &l); err != nil {
+		return err
+	}
+	*r = l[0]
+	return nil
+}
+func (r DeployedReceiver) MarshalYAML() (interface{}, error) {
+	return marshalRecordYAML(DeployedReceiverList{r})
+}
+func (r *DeployedReceiver) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var l DeployedReceiverList
+	if err := unmarshalRecordYAML(unmarshal, &l); err != nil {
+		return err
+	}
+	*r = l[0]
+	return nil
+}
 
 func (r DeployedReceiverList) encode() [][]string {
-	data := [][]string{{
-		"Make",
-		"Model",
-		"Serial",
-		"Mark",
-		"Start Date",
-		"End Date",
-	}}
+	data := [][]string{deployedReceiverSchema.header()}
 	for _, v := range r {
 		data = append(data, []string{
 			strings.TrimSpace(v.Make),
@@ -45,7 +78,7 @@ func (r DeployedReceiverList) encode() [][]string {
 			strings.TrimSpace(v.Serial),
 			strings.TrimSpace(v.Mark),
 			v.Start.Format(DateTimeFormat),
-			v.End.Format(DateTimeFormat),
+			formatEnd(v.End),
 		})
 	}
 	return data
@@ -54,18 +87,22 @@ func (r DeployedReceiverList) encode() [][]string {
 func (r *DeployedReceiverList) decode(data [][]string) error {
 	var receivers []DeployedReceiver
 	if len(data) > 1 {
-		for _, d := range data[1:] {
-			if len(d) != deployedReceiverLast {
-				return fmt.Errorf("incorrect number of installed receiver fields")
-			}
-			var err error
+		fields, err := deployedReceiverSchema.fields(data[0])
+		if err != nil {
+			return err
+		}
+		var errs DecodeErrors
+		for i, row := range data[1:] {
+			d := fields.remap(row)
 
 			var start, end time.Time
 			if start, err = time.Parse(DateTimeFormat, d[deployedReceiverStart]); err != nil {
-				return err
+				errs = append(errs, fields.invalid(i+1, d, deployedReceiverStart, err))
+				continue
 			}
-			if end, err = time.Parse(DateTimeFormat, d[deployedReceiverEnd]); err != nil {
-				return err
+			if end, err = parseEnd(d[deployedReceiverEnd]); err != nil {
+				errs = append(errs, fields.invalid(i+1, d, deployedReceiverEnd, err))
+				continue
 			}
 
 			receivers = append(receivers, DeployedReceiver{
@@ -84,6 +121,10 @@ func (r *DeployedReceiverList) decode(data [][]string) error {
 			})
 		}
 
+		if errs != nil {
+			return errs
+		}
+
 		*r = DeployedReceiverList(receivers)
 	}
 	return nil
@@ -1,7 +1,6 @@
 package meta
 
 import (
-	"fmt"
 	"sort"
 	"strconv"
 	"strings"
@@ -20,9 +19,25 @@ const (
 	recorderDepth
 	recorderStart
 	recorderEnd
-	recorderLast
 )
 
+var recorderSchema = schema{
+	version: 1,
+	columns: []column{
+		recorderMake:            {name: "Make"},
+		recorderSensorModel:     {name: "Sensor"},
+		recorderDataloggerModel: {name: "Datalogger"},
+		recorderSerial:          {name: "Serial"},
+		recorderStation:         {name: "Station"},
+		recorderLocation:        {name: "Location"},
+		recorderAzimuth:         {name: "Azimuth", kind: numberKind},
+		recorderDip:             {name: "Dip", kind: numberKind},
+		recorderDepth:           {name: "Depth", kind: numberKind},
+		recorderStart:           {name: "Start Date"},
+		recorderEnd:             {name: "End Date"},
+	},
+}
+
 type InstalledRecorder struct {
 	InstalledSensor
 
@@ -31,24 +46,42 @@ type InstalledRecorder struct {
 
 type InstalledRecorderList []InstalledRecorder
 
-func (r InstalledRecorderList) Len() int           { return len(r) }
-func (r InstalledRecorderList) Swap(i, j int)      { r[i], r[j] = r[j], r[i] }
-func (r InstalledRecorderList) Less(i, j int) bool { return r[i].Install.less(r[j].Install) }
+func (r InstalledRecorderList) Len() int                          { return len(r) }
+func (r InstalledRecorderList) Swap(i, j int)                     { r[i], r[j] = r[j], r[i] }
+func (r InstalledRecorderList) Less(i, j int) bool                { return r[i].Install.less(r[j].Install) }
+func (r InstalledRecorderList) schema() schema                    { return recorderSchema }
+func (r InstalledRecorderList) MarshalJSON() ([]byte, error)      { return marshalJSON(r) }
+func (r *InstalledRecorderList) UnmarshalJSON(b []byte) error     { return unmarshalJSON(b, r) }
+func (r InstalledRecorderList) MarshalYAML() (interface{}, error) { return marshalYAML(r) }
+func (r *InstalledRecorderList) UnmarshalYAML(f func(interface{}) error) error {
+	return unmarshalYAML(f, r)
+}
+
+func (r InstalledRecorder) MarshalJSON() ([]byte, error) {
+	return marshalRecordJSON(InstalledRecorderList{r})
+}
+func (r *InstalledRecorder) UnmarshalJSON(b []byte) error {
+	var l InstalledRecorderList
+	if err := unmarshalRecordJSON(b, &l); err != nil {
+		return err
+	}
+	*r = l[0]
+	return nil
+}
+func (r InstalledRecorder) MarshalYAML() (interface{}, error) {
+	return marshalRecordYAML(InstalledRecorderList{r})
+}
+func (r *InstalledRecorder) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var l InstalledRecorderList
+	if err := unmarshalRecordYAML(unmarshal, &l); err != nil {
+		return err
+	}
+	*r = l[0]
+	return nil
+}
 
 func (r InstalledRecorderList) encode() [][]string {
-	data := [][]string{{
-		"Make",
-		"Sensor",
-		"Datalogger",
-		"Serial",
-		"Station",
-		"Location",
-		"Azimuth",
-		"Dip",
-		"Depth",
-		"Start Date",
-		"End Date",
-	}}
+	data := [][]string{recorderSchema.header()}
 
 	for _, v := range r {
 		data = append(data, []string{
@@ -61,6 +94,9 @@ func (r InstalledRecorderList) encode() [][]string {
 			strconv.FormatFloat(v.Azimuth, 'g', -1, 64),
 			strconv.FormatFloat(v.Dip, 'g', -1, 64),
 			func() string {
+				if v.nullVertical {
+					return ""
+				}
 				if v.Vertical == 0.0 {
 					return strconv.FormatFloat(0.0, 'g', -1, 64)
 				} else {
@@ -68,7 +104,7 @@ func (r InstalledRecorderList) encode() [][]string {
 				}
 			}(),
 			v.Start.Format(DateTimeFormat),
-			v.End.Format(DateTimeFormat),
+			formatEnd(v.End),
 		})
 	}
 	return data
@@ -76,29 +112,37 @@ func (r InstalledRecorderList) encode() [][]string {
 func (r *InstalledRecorderList) decode(data [][]string) error {
 	var recorders []InstalledRecorder
 	if len(data) > 1 {
-		for _, d := range data[1:] {
-			if len(d) != recorderLast {
-				return fmt.Errorf("incorrect number of installed recorder fields")
-			}
-			var err error
+		fields, err := recorderSchema.fields(data[0])
+		if err != nil {
+			return err
+		}
+		var errs DecodeErrors
+		for i, row := range data[1:] {
+			d := fields.remap(row)
 
 			var azimuth, dip, depth float64
+			var nullDepth bool
 			if azimuth, err = strconv.ParseFloat(d[recorderAzimuth], 64); err != nil {
-				return err
+				errs = append(errs, fields.invalid(i+1, d, recorderAzimuth, err))
+				continue
 			}
 			if dip, err = strconv.ParseFloat(d[recorderDip], 64); err != nil {
-				return err
+				errs = append(errs, fields.invalid(i+1, d, recorderDip, err))
+				continue
 			}
-			if depth, err = strconv.ParseFloat(d[recorderDepth], 64); err != nil {
-				return err
+			if depth, nullDepth, err = parseNullable(d[recorderDepth]); err != nil {
+				errs = append(errs, fields.invalid(i+1, d, recorderDepth, err))
+				continue
 			}
 
 			var start, end time.Time
 			if start, err = time.Parse(DateTimeFormat, d[recorderStart]); err != nil {
-				return err
+				errs = append(errs, fields.invalid(i+1, d, recorderStart, err))
+				continue
 			}
-			if end, err = time.Parse(DateTimeFormat, d[recorderEnd]); err != nil {
-				return err
+			if end, err = parseEnd(d[recorderEnd]); err != nil {
+				errs = append(errs, fields.invalid(i+1, d, recorderEnd, err))
+				continue
 			}
 
 			recorders = append(recorders, InstalledRecorder{
@@ -120,6 +164,8 @@ func (r *InstalledRecorderList) decode(data [][]string) error {
 					},
 					Offset: Offset{
 						Vertical: -depth,
+
+						nullVertical: nullDepth,
 					},
 					Station:  strings.TrimSpace(d[recorderStation]),
 					Location: strings.TrimSpace(d[recorderLocation]),
@@ -128,6 +174,10 @@ func (r *InstalledRecorderList) decode(data [][]string) error {
 			})
 		}
 
+		if errs != nil {
+			return errs
+		}
+
 		*r = InstalledRecorderList(recorders)
 	}
 	return nil
@@ -0,0 +1,122 @@
+package meta
+
+import (
+	"strings"
+)
+
+// kind describes how a column value is represented when encoding lists as json or yaml.
+type kind int
+
+const (
+	stringKind kind = iota
+	numberKind
+	boolKind
+)
+
+// column describes a single csv list column, optional columns may be missing
+// from a file and will be decoded using the given default value.
+type column struct {
+	name     string
+	value    string
+	kind     kind
+	optional bool
+}
+
+// key returns the json and yaml field name for the column, this is the lower
+// case column title with spaces replaced by underscores.
+func (c column) key() string {
+	return strings.Replace(strings.ToLower(strings.TrimSpace(c.name)), " ", "_", -1)
+}
+
+// schema describes the expected csv columns of a list, the columns are indexed
+// by the field constants used when decoding each row.
+type schema struct {
+	version int
+	columns []column
+}
+
+// header returns the csv header titles for the schema.
+func (s schema) header() []string {
+	var header []string
+	for _, c := range s.columns {
+		header = append(header, c.name)
+	}
+	return header
+}
+
+// fields builds a column lookup from a csv header line, unknown columns are ignored
+// and an error is returned if any required column is missing.
+func (s schema) fields(header []string) (fields, error) {
+	titles := make(map[string]int)
+	for i, h := range header {
+		titles[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+
+	f := fields{
+		schema: s,
+		lookup: make([]int, len(s.columns)),
+	}
+	for i, c := range s.columns {
+		n, ok := titles[strings.ToLower(c.name)]
+		if !ok && !c.optional {
+			return fields{}, DecodeErrors{{
+				Line:   1,
+				Column: c.name,
+				Err:    ErrMissingColumn,
+			}}
+		}
+		if !ok {
+			n = -1
+		}
+		f.lookup[i] = n
+	}
+
+	return f, nil
+}
+
+// fields maps schema columns onto the columns present in a decoded csv file.
+type fields struct {
+	schema schema
+	lookup []int
+}
+
+// remap returns the row values indexed by the schema field constants, missing
+// optional columns are filled in using their default values.
+func (f fields) remap(row []string) []string {
+	values := make([]string, len(f.lookup))
+	for i, n := range f.lookup {
+		switch {
+		case n < 0, !(n < len(row)):
+			values[i] = f.schema.columns[i].value
+		default:
+			values[i] = row[n]
+		}
+	}
+	return values
+}
+
+// invalid returns a decode error for the given field of a csv record, the record
+// is counted from the header line.
+func (f fields) invalid(record int, values []string, field int, err error) DecodeError {
+	return DecodeError{
+		Line:   record + 1,
+		Column: f.schema.columns[field].name,
+		Value:  values[field],
+		Err:    err,
+		record: record,
+	}
+}
+
+// ListSchema is implemented by lists that are decoded using named csv columns.
+type ListSchema interface {
+	schema() schema
+}
+
+// SchemaVersion returns the version of the csv layout expected by the given list, or zero if it
+// is not known.
+func SchemaVersion(l interface{}) int {
+	if s, ok := l.(ListSchema); ok {
+		return s.schema().version
+	}
+	return 0
+}
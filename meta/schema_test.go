@@ -0,0 +1,57 @@
+package meta_test
+
+import (
+	"testing"
+
+	"github.com/GeoNet/delta/meta"
+)
+
+func TestSchema(t *testing.T) {
+
+	t.Log("Check reordered and unknown columns")
+	{
+		var networks meta.NetworkList
+		if err := meta.UnmarshalList([]byte(
+			"Restricted,Description,Comment,Network,External\nfalse,Auckland volcano seismic network,unused,AK,NZ\n"), &networks); err != nil {
+			t.Fatal(err)
+		}
+		if len(networks) != 1 {
+			t.Fatalf("invalid number of networks: got %d, expected %d", len(networks), 1)
+		}
+		if networks[0].Code != "AK" || networks[0].External != "NZ" || networks[0].Description != "Auckland volcano seismic network" {
+			t.Errorf("invalid network decode: %v", networks[0])
+		}
+	}
+
+	t.Log("Check missing optional columns")
+	{
+		var assets meta.AssetList
+		if err := meta.UnmarshalList([]byte("Make,Model,Serial,Number\nTrimble,NetR9,5034K69675,4231\n"), &assets); err != nil {
+			t.Fatal(err)
+		}
+		if len(assets) != 1 {
+			t.Fatalf("invalid number of assets: got %d, expected %d", len(assets), 1)
+		}
+		if assets[0].Number != "4231" || assets[0].Notes != "" {
+			t.Errorf("invalid asset decode: %v", assets[0])
+		}
+	}
+
+	t.Log("Check missing required columns")
+	{
+		var networks meta.NetworkList
+		if err := meta.UnmarshalList([]byte("Network,External,Description\nAK,NZ,Auckland volcano seismic network\n"), &networks); err == nil {
+			t.Error("expected missing required column error")
+		}
+	}
+
+	t.Log("Check schema versions")
+	{
+		if v := meta.SchemaVersion(meta.StationList{}); v != 1 {
+			t.Errorf("invalid station schema version: got %d, expected %d", v, 1)
+		}
+		if v := meta.SchemaVersion(struct{}{}); v != 0 {
+			t.Errorf("invalid unknown schema version: got %d, expected %d", v, 0)
+		}
+	}
+}
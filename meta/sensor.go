@@ -1,7 +1,6 @@
 package meta
 
 import (
-	"fmt"
 	"sort"
 	"strconv"
 	"strings"
@@ -23,9 +22,28 @@ const (
 	sensorScaleBias
 	sensorStart
 	sensorEnd
-	sensorLast
 )
 
+var sensorSchema = schema{
+	version: 1,
+	columns: []column{
+		sensorMake:        {name: "Make"},
+		sensorModel:       {name: "Model"},
+		sensorSerial:      {name: "Serial"},
+		sensorStation:     {name: "Station"},
+		sensorLocation:    {name: "Location"},
+		sensorAzimuth:     {name: "Azimuth", kind: numberKind},
+		sensorDip:         {name: "Dip", kind: numberKind},
+		sensorDepth:       {name: "Depth", kind: numberKind},
+		sensorNorth:       {name: "North", kind: numberKind},
+		sensorEast:        {name: "East", kind: numberKind},
+		sensorScaleFactor: {name: "Scale Factor", kind: numberKind},
+		sensorScaleBias:   {name: "Scale Bias", kind: numberKind},
+		sensorStart:       {name: "Start Date"},
+		sensorEnd:         {name: "End Date"},
+	},
+}
+
 type InstalledSensor struct {
 	Install
 	Orientation
@@ -38,27 +56,42 @@ type InstalledSensor struct {
 
 type InstalledSensorList []InstalledSensor
 
-func (s InstalledSensorList) Len() int           { return len(s) }
-func (s InstalledSensorList) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
-func (s InstalledSensorList) Less(i, j int) bool { return s[i].Install.less(s[j].Install) }
+func (s InstalledSensorList) Len() int                          { return len(s) }
+func (s InstalledSensorList) Swap(i, j int)                     { s[i], s[j] = s[j], s[i] }
+func (s InstalledSensorList) Less(i, j int) bool                { return s[i].Install.less(s[j].Install) }
+func (s InstalledSensorList) schema() schema                    { return sensorSchema }
+func (s InstalledSensorList) MarshalJSON() ([]byte, error)      { return marshalJSON(s) }
+func (s *InstalledSensorList) UnmarshalJSON(b []byte) error     { return unmarshalJSON(b, s) }
+func (s InstalledSensorList) MarshalYAML() (interface{}, error) { return marshalYAML(s) }
+func (s *InstalledSensorList) UnmarshalYAML(f func(interface{}) error) error {
+	return unmarshalYAML(f, s)
+}
+
+func (s InstalledSensor) MarshalJSON() ([]byte, error) {
+	return marshalRecordJSON(InstalledSensorList{s})
+}
+func (s *InstalledSensor) UnmarshalJSON(b []byte) error {
+	var l InstalledSensorList
+	if err := unmarshalRecordJSON(b, &l); err != nil {
+		return err
+	}
+	*s = l[0]
+	return nil
+}
+func (s InstalledSensor) MarshalYAML() (interface{}, error) {
+	return marshalRecordYAML(InstalledSensorList{s})
+}
+func (s *InstalledSensor) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var l InstalledSensorList
+	if err := unmarshalRecordYAML(unmarshal, &l); err != nil {
+		return err
+	}
+	*s = l[0]
+	return nil
+}
 
 func (s InstalledSensorList) encode() [][]string {
-	data := [][]string{{
-		"Make",
-		"Model",
-		"Serial",
-		"Station",
-		"Location",
-		"Azimuth",
-		"Dip",
-		"Depth",
-		"North",
-		"East",
-		"Scale Factor",
-		"Scale Bias",
-		"Start Date",
-		"End Date",
-	}}
+	data := [][]string{sensorSchema.header()}
 
 	for _, v := range s {
 		data = append(data, []string{
@@ -70,18 +103,21 @@ func (s InstalledSensorList) encode() [][]string {
 			strconv.FormatFloat(v.Azimuth, 'g', -1, 64),
 			strconv.FormatFloat(v.Dip, 'g', -1, 64),
 			func() string {
+				if v.nullVertical {
+					return ""
+				}
 				if v.Vertical == 0.0 {
 					return strconv.FormatFloat(0.0, 'g', -1, 64)
 				} else {
 					return strconv.FormatFloat(-v.Vertical, 'g', -1, 64)
 				}
 			}(),
-			strconv.FormatFloat(v.North, 'g', -1, 64),
-			strconv.FormatFloat(v.East, 'g', -1, 64),
-			strconv.FormatFloat(v.Factor, 'g', -1, 64),
-			strconv.FormatFloat(v.Bias, 'g', -1, 64),
+			formatNullable(v.North, v.nullNorth),
+			formatNullable(v.East, v.nullEast),
+			formatNullable(v.Factor, v.nullFactor),
+			formatNullable(v.Bias, v.nullBias),
 			v.Start.Format(DateTimeFormat),
-			v.End.Format(DateTimeFormat),
+			formatEnd(v.End),
 		})
 	}
 	return data
@@ -89,45 +125,58 @@ func (s InstalledSensorList) encode() [][]string {
 func (s *InstalledSensorList) decode(data [][]string) error {
 	var sensors []InstalledSensor
 	if len(data) > 1 {
-		for _, d := range data[1:] {
-			if len(d) != sensorLast {
-				return fmt.Errorf("incorrect number of installed sensor fields")
-			}
-			var err error
+		fields, err := sensorSchema.fields(data[0])
+		if err != nil {
+			return err
+		}
+		var errs DecodeErrors
+		for i, row := range data[1:] {
+			d := fields.remap(row)
 
 			var azimuth, dip float64
 			if azimuth, err = strconv.ParseFloat(d[sensorAzimuth], 64); err != nil {
-				return err
+				errs = append(errs, fields.invalid(i+1, d, sensorAzimuth, err))
+				continue
 			}
 			if dip, err = strconv.ParseFloat(d[sensorDip], 64); err != nil {
-				return err
+				errs = append(errs, fields.invalid(i+1, d, sensorDip, err))
+				continue
 			}
 
 			var depth, north, east float64
-			if depth, err = strconv.ParseFloat(d[sensorDepth], 64); err != nil {
-				return err
+			var nullDepth, nullNorth, nullEast bool
+			if depth, nullDepth, err = parseNullable(d[sensorDepth]); err != nil {
+				errs = append(errs, fields.invalid(i+1, d, sensorDepth, err))
+				continue
 			}
-			if north, err = strconv.ParseFloat(d[sensorNorth], 64); err != nil {
-				return err
+			if north, nullNorth, err = parseNullable(d[sensorNorth]); err != nil {
+				errs = append(errs, fields.invalid(i+1, d, sensorNorth, err))
+				continue
 			}
-			if east, err = strconv.ParseFloat(d[sensorEast], 64); err != nil {
-				return err
+			if east, nullEast, err = parseNullable(d[sensorEast]); err != nil {
+				errs = append(errs, fields.invalid(i+1, d, sensorEast, err))
+				continue
 			}
 
 			var factor, bias float64
-			if factor, err = strconv.ParseFloat(d[sensorScaleFactor], 64); err != nil {
-				return err
+			var nullFactor, nullBias bool
+			if factor, nullFactor, err = parseNullable(d[sensorScaleFactor]); err != nil {
+				errs = append(errs, fields.invalid(i+1, d, sensorScaleFactor, err))
+				continue
 			}
-			if bias, err = strconv.ParseFloat(d[sensorScaleBias], 64); err != nil {
-				return err
+			if bias, nullBias, err = parseNullable(d[sensorScaleBias]); err != nil {
+				errs = append(errs, fields.invalid(i+1, d, sensorScaleBias, err))
+				continue
 			}
 
 			var start, end time.Time
 			if start, err = time.Parse(DateTimeFormat, d[sensorStart]); err != nil {
-				return err
+				errs = append(errs, fields.invalid(i+1, d, sensorStart, err))
+				continue
 			}
-			if end, err = time.Parse(DateTimeFormat, d[sensorEnd]); err != nil {
-				return err
+			if end, err = parseEnd(d[sensorEnd]); err != nil {
+				errs = append(errs, fields.invalid(i+1, d, sensorEnd, err))
+				continue
 			}
 
 			sensors = append(sensors, InstalledSensor{
@@ -150,16 +199,27 @@ func (s *InstalledSensorList) decode(data [][]string) error {
 					Vertical: -depth,
 					North:    north,
 					East:     east,
+
+					nullVertical: nullDepth,
+					nullNorth:    nullNorth,
+					nullEast:     nullEast,
 				},
 				Scale: Scale{
 					Factor: factor,
 					Bias:   bias,
+
+					nullFactor: nullFactor,
+					nullBias:   nullBias,
 				},
 				Station:  strings.TrimSpace(d[sensorStation]),
 				Location: strings.TrimSpace(d[sensorLocation]),
 			})
 		}
 
+		if errs != nil {
+			return errs
+		}
+
 		*s = InstalledSensorList(sensors)
 	}
 	return nil
@@ -1,7 +1,6 @@
 package meta
 
 import (
-	"fmt"
 	"sort"
 	"strconv"
 	"strings"
@@ -20,9 +19,25 @@ const (
 	sessionFormat
 	sessionStart
 	sessionEnd
-	sessionLast
 )
 
+var sessionSchema = schema{
+	version: 1,
+	columns: []column{
+		sessionMark:            {name: "Mark"},
+		sessionOperator:        {name: "Operator"},
+		sessionAgency:          {name: "Agency"},
+		sessionModel:           {name: "Model"},
+		sessionSatelliteSystem: {name: "Satellite System"},
+		sessionInterval:        {name: "Interval"},
+		sessionElevationMask:   {name: "Elevation Mask", kind: numberKind},
+		sessionHeaderComment:   {name: "Header Comment"},
+		sessionFormat:          {name: "Format"},
+		sessionStart:           {name: "Start Date"},
+		sessionEnd:             {name: "End Date"},
+	},
+}
+
 type Session struct {
 	Span
 
@@ -62,24 +77,38 @@ func (s Session) Less(session Session) bool {
 
 type SessionList []Session
 
-func (s SessionList) Len() int           { return len(s) }
-func (s SessionList) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
-func (s SessionList) Less(i, j int) bool { return s[i].Less(s[j]) }
+func (s SessionList) Len() int                          { return len(s) }
+func (s SessionList) Swap(i, j int)                     { s[i], s[j] = s[j], s[i] }
+func (s SessionList) Less(i, j int) bool                { return s[i].Less(s[j]) }
+func (s SessionList) schema() schema                    { return sessionSchema }
+func (s SessionList) MarshalJSON() ([]byte, error)      { return marshalJSON(s) }
+func (s *SessionList) UnmarshalJSON(b []byte) error     { return unmarshalJSON(b, s) }
+func (s SessionList) MarshalYAML() (interface{}, error) { return marshalYAML(s) }
+func (s *SessionList) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalYAML(unmarshal, s)
+}
+
+func (s Session) MarshalJSON() ([]byte, error) { return marshalRecordJSON(SessionList{s}) }
+func (s *Session) UnmarshalJSON(b []byte) error {
+	var l SessionList
+	if err := unmarshalRecordJSON(b, &l); err != nil {
+		return err
+	}
+	*s = l[0]
+	return nil
+}
+func (s Session) MarshalYAML() (interface{}, error) { return marshalRecordYAML(SessionList{s}) }
+func (s *Session) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var l SessionList
+	if err := unmarshalRecordYAML(unmarshal, &l); err != nil {
+		return err
+	}
+	*s = l[0]
+	return nil
+}
 
 func (s SessionList) encode() [][]string {
-	data := [][]string{{
-		"Mark",
-		"Operator",
-		"Agency",
-		"Model",
-		"Satellite System",
-		"Interval",
-		"Elevation Mask",
-		"Header Comment",
-		"Format",
-		"Start Date",
-		"End Date",
-	}}
+	data := [][]string{sessionSchema.header()}
 	for _, v := range s {
 		data = append(data, []string{
 			strings.TrimSpace(v.Mark),
@@ -92,7 +121,7 @@ func (s SessionList) encode() [][]string {
 			strings.TrimSpace(v.HeaderComment),
 			strings.TrimSpace(v.Format),
 			v.Start.Format(DateTimeFormat),
-			v.End.Format(DateTimeFormat),
+			formatEnd(v.End),
 		})
 	}
 	return data
@@ -101,28 +130,34 @@ func (s SessionList) encode() [][]string {
 func (c *SessionList) decode(data [][]string) error {
 	var sessions []Session
 	if len(data) > 1 {
-		for _, v := range data[1:] {
-			if len(v) != sessionLast {
-				return fmt.Errorf("incorrect number of installed session fields")
-			}
-			var err error
+		fields, err := sessionSchema.fields(data[0])
+		if err != nil {
+			return err
+		}
+		var errs DecodeErrors
+		for i, row := range data[1:] {
+			v := fields.remap(row)
 
 			var interval time.Duration
 			if interval, err = time.ParseDuration(v[sessionInterval]); err != nil {
-				return err
+				errs = append(errs, fields.invalid(i+1, v, sessionInterval, err))
+				continue
 			}
 
 			var start, end time.Time
 			if start, err = time.Parse(DateTimeFormat, v[sessionStart]); err != nil {
-				return err
+				errs = append(errs, fields.invalid(i+1, v, sessionStart, err))
+				continue
 			}
-			if end, err = time.Parse(DateTimeFormat, v[sessionEnd]); err != nil {
-				return err
+			if end, err = parseEnd(v[sessionEnd]); err != nil {
+				errs = append(errs, fields.invalid(i+1, v, sessionEnd, err))
+				continue
 			}
 
 			var mask float64
 			if mask, err = strconv.ParseFloat(v[sessionElevationMask], 64); err != nil {
-				return err
+				errs = append(errs, fields.invalid(i+1, v, sessionElevationMask, err))
+				continue
 			}
 
 			sessions = append(sessions, Session{
@@ -142,6 +177,10 @@ func (c *SessionList) decode(data [][]string) error {
 			})
 		}
 
+		if errs != nil {
+			return errs
+		}
+
 		*c = SessionList(sessions)
 	}
 	return nil
@@ -1,7 +1,6 @@
 package meta
 
 import (
-	"fmt"
 	"sort"
 	"strconv"
 	"strings"
@@ -18,9 +17,23 @@ const (
 	siteSurvey
 	siteStart
 	siteEnd
-	siteLast
 )
 
+var siteSchema = schema{
+	version: 1,
+	columns: []column{
+		siteStation:   {name: "Station"},
+		siteLocation:  {name: "Location"},
+		siteLatitude:  {name: "Latitude", kind: numberKind},
+		siteLongitude: {name: "Longitude", kind: numberKind},
+		siteElevation: {name: "Elevation", kind: numberKind},
+		siteDatum:     {name: "Datum"},
+		siteSurvey:    {name: "Survey"},
+		siteStart:     {name: "Start Date"},
+		siteEnd:       {name: "End Date"},
+	},
+}
+
 type Site struct {
 	Point
 	Span
@@ -47,33 +60,49 @@ func (s Site) Less(site Site) bool {
 
 type SiteList []Site
 
-func (s SiteList) Len() int           { return len(s) }
-func (s SiteList) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
-func (s SiteList) Less(i, j int) bool { return s[i].Less(s[j]) }
+func (s SiteList) Len() int                          { return len(s) }
+func (s SiteList) Swap(i, j int)                     { s[i], s[j] = s[j], s[i] }
+func (s SiteList) Less(i, j int) bool                { return s[i].Less(s[j]) }
+func (s SiteList) schema() schema                    { return siteSchema }
+func (s SiteList) MarshalJSON() ([]byte, error)      { return marshalJSON(s) }
+func (s *SiteList) UnmarshalJSON(b []byte) error     { return unmarshalJSON(b, s) }
+func (s SiteList) MarshalYAML() (interface{}, error) { return marshalYAML(s) }
+func (s *SiteList) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalYAML(unmarshal, s)
+}
+
+func (s Site) MarshalJSON() ([]byte, error) { return marshalRecordJSON(SiteList{s}) }
+func (s *Site) UnmarshalJSON(b []byte) error {
+	var l SiteList
+	if err := unmarshalRecordJSON(b, &l); err != nil {
+		return err
+	}
+	*s = l[0]
+	return nil
+}
+func (s Site) MarshalYAML() (interface{}, error) { return marshalRecordYAML(SiteList{s}) }
+func (s *Site) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var l SiteList
+	if err := unmarshalRecordYAML(unmarshal, &l); err != nil {
+		return err
+	}
+	*s = l[0]
+	return nil
+}
 
 func (s SiteList) encode() [][]string {
-	data := [][]string{{
-		"Station",
-		"Location",
-		"Latitude",
-		"Longitude",
-		"Elevation",
-		"Datum",
-		"Survey",
-		"Start Date",
-		"End Date",
-	}}
+	data := [][]string{siteSchema.header()}
 	for _, v := range s {
 		data = append(data, []string{
 			strings.TrimSpace(v.Station),
 			strings.TrimSpace(v.Location),
 			strconv.FormatFloat(v.Latitude, 'g', -1, 64),
 			strconv.FormatFloat(v.Longitude, 'g', -1, 64),
-			strconv.FormatFloat(v.Elevation, 'g', -1, 64),
+			formatNullable(v.Elevation, v.nullElevation),
 			strings.TrimSpace(v.Datum),
 			strings.TrimSpace(v.Survey),
 			v.Start.Format(DateTimeFormat),
-			v.End.Format(DateTimeFormat),
+			formatEnd(v.End),
 		})
 	}
 	return data
@@ -82,29 +111,37 @@ func (s SiteList) encode() [][]string {
 func (s *SiteList) decode(data [][]string) error {
 	var sites []Site
 	if len(data) > 1 {
-		for _, d := range data[1:] {
-			if len(d) != siteLast {
-				return fmt.Errorf("incorrect number of installed site fields")
-			}
-			var err error
+		fields, err := siteSchema.fields(data[0])
+		if err != nil {
+			return err
+		}
+		var errs DecodeErrors
+		for i, row := range data[1:] {
+			d := fields.remap(row)
 
 			var lat, lon, elev float64
+			var nullElev bool
 			if lat, err = strconv.ParseFloat(d[siteLatitude], 64); err != nil {
-				return err
+				errs = append(errs, fields.invalid(i+1, d, siteLatitude, err))
+				continue
 			}
 			if lon, err = strconv.ParseFloat(d[siteLongitude], 64); err != nil {
-				return err
+				errs = append(errs, fields.invalid(i+1, d, siteLongitude, err))
+				continue
 			}
-			if elev, err = strconv.ParseFloat(d[siteElevation], 64); err != nil {
-				return err
+			if elev, nullElev, err = parseNullable(d[siteElevation]); err != nil {
+				errs = append(errs, fields.invalid(i+1, d, siteElevation, err))
+				continue
 			}
 
 			var start, end time.Time
 			if start, err = time.Parse(DateTimeFormat, d[siteStart]); err != nil {
-				return err
+				errs = append(errs, fields.invalid(i+1, d, siteStart, err))
+				continue
 			}
-			if end, err = time.Parse(DateTimeFormat, d[siteEnd]); err != nil {
-				return err
+			if end, err = parseEnd(d[siteEnd]); err != nil {
+				errs = append(errs, fields.invalid(i+1, d, siteEnd, err))
+				continue
 			}
 
 			sites = append(sites, Site{
@@ -113,6 +150,8 @@ func (s *SiteList) decode(data [][]string) error {
 					Longitude: lon,
 					Elevation: elev,
 					Datum:     strings.TrimSpace(d[siteDatum]),
+
+					nullElevation: nullElev,
 				},
 				Span: Span{
 					Start: start,
@@ -124,6 +163,10 @@ func (s *SiteList) decode(data [][]string) error {
 			})
 		}
 
+		if errs != nil {
+			return errs
+		}
+
 		*s = SiteList(sites)
 	}
 	return nil
@@ -1,7 +1,6 @@
 package meta
 
 import (
-	"fmt"
 	"sort"
 	"strconv"
 	"strings"
@@ -18,9 +17,24 @@ const (
 	stationDatum
 	stationStart
 	stationEnd
-	stationLast
 )
 
+var stationSchema = schema{
+	version: 1,
+	columns: []column{
+		stationCode:      {name: "Station"},
+		stationNetwork:   {name: "Network"},
+		stationName:      {name: "Name"},
+		stationLatitude:  {name: "Latitude", kind: numberKind},
+		stationLongitude: {name: "Longitude", kind: numberKind},
+		stationHeight:    {name: "Elevation", kind: numberKind},
+		stationDatum:     {name: "Datum"},
+		stationStart:     {name: "Start Date"},
+		stationEnd:       {name: "End Date"},
+		//stationNotes:   {name: "Notes", optional: true},
+	},
+}
+
 type Station struct {
 	Reference
 	Point
@@ -31,23 +45,38 @@ type Station struct {
 
 type StationList []Station
 
-func (s StationList) Len() int           { return len(s) }
-func (s StationList) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
-func (s StationList) Less(i, j int) bool { return s[i].Code < s[j].Code }
+func (s StationList) Len() int                          { return len(s) }
+func (s StationList) Swap(i, j int)                     { s[i], s[j] = s[j], s[i] }
+func (s StationList) Less(i, j int) bool                { return s[i].Code < s[j].Code }
+func (s StationList) schema() schema                    { return stationSchema }
+func (s StationList) MarshalJSON() ([]byte, error)      { return marshalJSON(s) }
+func (s *StationList) UnmarshalJSON(b []byte) error     { return unmarshalJSON(b, s) }
+func (s StationList) MarshalYAML() (interface{}, error) { return marshalYAML(s) }
+func (s *StationList) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalYAML(unmarshal, s)
+}
+
+func (s Station) MarshalJSON() ([]byte, error) { return marshalRecordJSON(StationList{s}) }
+func (s *Station) UnmarshalJSON(b []byte) error {
+	var l StationList
+	if err := unmarshalRecordJSON(b, &l); err != nil {
+		return err
+	}
+	*s = l[0]
+	return nil
+}
+func (s Station) MarshalYAML() (interface{}, error) { return marshalRecordYAML(StationList{s}) }
+func (s *Station) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var l StationList
+	if err := unmarshalRecordYAML(unmarshal, &l); err != nil {
+		return err
+	}
+	*s = l[0]
+	return nil
+}
 
 func (s StationList) encode() [][]string {
-	data := [][]string{{
-		"Station",
-		"Network",
-		"Name",
-		"Latitude",
-		"Longitude",
-		"Elevation",
-		"Datum",
-		"Start Date",
-		"End Date",
-		//"Notes",
-	}}
+	data := [][]string{stationSchema.header()}
 	for _, v := range s {
 		data = append(data, []string{
 			strings.TrimSpace(v.Code),
@@ -55,10 +84,10 @@ func (s StationList) encode() [][]string {
 			strings.TrimSpace(v.Name),
 			strconv.FormatFloat(v.Latitude, 'g', -1, 64),
 			strconv.FormatFloat(v.Longitude, 'g', -1, 64),
-			strconv.FormatFloat(v.Elevation, 'g', -1, 64),
+			formatNullable(v.Elevation, v.nullElevation),
 			strings.TrimSpace(v.Datum),
 			v.Start.Format(DateTimeFormat),
-			v.End.Format(DateTimeFormat),
+			formatEnd(v.End),
 			//strings.TrimSpace(v.Notes),
 		})
 	}
@@ -68,29 +97,37 @@ func (s StationList) encode() [][]string {
 func (s *StationList) decode(data [][]string) error {
 	var stations []Station
 	if len(data) > 1 {
-		for _, d := range data[1:] {
-			if len(d) != stationLast {
-				return fmt.Errorf("incorrect number of installed station fields")
-			}
-			var err error
+		fields, err := stationSchema.fields(data[0])
+		if err != nil {
+			return err
+		}
+		var errs DecodeErrors
+		for i, row := range data[1:] {
+			d := fields.remap(row)
 
 			var lat, lon, elev float64
-			if lat, err = strconv.ParseFloat(d[3], stationLatitude); err != nil {
-				return err
+			var nullElev bool
+			if lat, err = strconv.ParseFloat(d[stationLatitude], 64); err != nil {
+				errs = append(errs, fields.invalid(i+1, d, stationLatitude, err))
+				continue
 			}
-			if lon, err = strconv.ParseFloat(d[4], stationLongitude); err != nil {
-				return err
+			if lon, err = strconv.ParseFloat(d[stationLongitude], 64); err != nil {
+				errs = append(errs, fields.invalid(i+1, d, stationLongitude, err))
+				continue
 			}
-			if elev, err = strconv.ParseFloat(d[5], stationHeight); err != nil {
-				return err
+			if elev, nullElev, err = parseNullable(d[stationHeight]); err != nil {
+				errs = append(errs, fields.invalid(i+1, d, stationHeight, err))
+				continue
 			}
 
 			var start, end time.Time
 			if start, err = time.Parse(DateTimeFormat, d[stationStart]); err != nil {
-				return err
+				errs = append(errs, fields.invalid(i+1, d, stationStart, err))
+				continue
 			}
-			if end, err = time.Parse(DateTimeFormat, d[stationEnd]); err != nil {
-				return err
+			if end, err = parseEnd(d[stationEnd]); err != nil {
+				errs = append(errs, fields.invalid(i+1, d, stationEnd, err))
+				continue
 			}
 
 			stations = append(stations, Station{
@@ -108,11 +145,17 @@ func (s *StationList) decode(data [][]string) error {
 					Longitude: lon,
 					Elevation: elev,
 					Datum:     strings.TrimSpace(d[stationDatum]),
+
+					nullElevation: nullElev,
 				},
 				//Notes: strings.TrimSpace(d[9]),
 			})
 		}
 
+		if errs != nil {
+			return errs
+		}
+
 		*s = StationList(stations)
 	}
 	return nil
@@ -1,7 +1,6 @@
 package meta
 
 import (
-	"fmt"
 	"sort"
 	"strconv"
 	"strings"
@@ -17,9 +16,22 @@ const (
 	streamTriggered
 	streamStart
 	streamEnd
-	streamLast
 )
 
+var streamSchema = schema{
+	version: 1,
+	columns: []column{
+		streamStation:      {name: "Station"},
+		streamLocation:     {name: "Location"},
+		streamSamplingRate: {name: "Sampling Rate", kind: numberKind},
+		streamAxial:        {name: "Axial", kind: boolKind},
+		streamReversed:     {name: "Reversed", kind: boolKind},
+		streamTriggered:    {name: "Triggered", kind: boolKind},
+		streamStart:        {name: "Start Date"},
+		streamEnd:          {name: "End Date"},
+	},
+}
+
 type Stream struct {
 	Span
 
@@ -56,21 +68,38 @@ func (s Stream) Less(stream Stream) bool {
 
 type StreamList []Stream
 
-func (s StreamList) Len() int           { return len(s) }
-func (s StreamList) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
-func (s StreamList) Less(i, j int) bool { return s[i].Less(s[j]) }
+func (s StreamList) Len() int                          { return len(s) }
+func (s StreamList) Swap(i, j int)                     { s[i], s[j] = s[j], s[i] }
+func (s StreamList) Less(i, j int) bool                { return s[i].Less(s[j]) }
+func (s StreamList) schema() schema                    { return streamSchema }
+func (s StreamList) MarshalJSON() ([]byte, error)      { return marshalJSON(s) }
+func (s *StreamList) UnmarshalJSON(b []byte) error     { return unmarshalJSON(b, s) }
+func (s StreamList) MarshalYAML() (interface{}, error) { return marshalYAML(s) }
+func (s *StreamList) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalYAML(unmarshal, s)
+}
+
+func (s Stream) MarshalJSON() ([]byte, error) { return marshalRecordJSON(StreamList{s}) }
+func (s *Stream) UnmarshalJSON(b []byte) error {
+	var l StreamList
+	if err := unmarshalRecordJSON(b, &l); err != nil {
+		return err
+	}
+	*s = l[0]
+	return nil
+}
+func (s Stream) MarshalYAML() (interface{}, error) { return marshalRecordYAML(StreamList{s}) }
+func (s *Stream) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var l StreamList
+	if err := unmarshalRecordYAML(unmarshal, &l); err != nil {
+		return err
+	}
+	*s = l[0]
+	return nil
+}
 
 func (s StreamList) encode() [][]string {
-	data := [][]string{{
-		"Station",
-		"Location",
-		"Sampling Rate",
-		"Axial",
-		"Reversed",
-		"Triggered",
-		"Start Date",
-		"End Date",
-	}}
+	data := [][]string{streamSchema.header()}
 	for _, v := range s {
 		data = append(data, []string{
 			strings.TrimSpace(v.Station),
@@ -80,7 +109,7 @@ func (s StreamList) encode() [][]string {
 			strings.TrimSpace(strconv.FormatBool(v.Reversed)),
 			strings.TrimSpace(strconv.FormatBool(v.Triggered)),
 			v.Start.Format(DateTimeFormat),
-			v.End.Format(DateTimeFormat),
+			formatEnd(v.End),
 		})
 	}
 	return data
@@ -89,34 +118,42 @@ func (s StreamList) encode() [][]string {
 func (c *StreamList) decode(data [][]string) error {
 	var streams []Stream
 	if len(data) > 1 {
-		for _, v := range data[1:] {
-			if len(v) != streamLast {
-				return fmt.Errorf("incorrect number of installed stream fields")
-			}
-			var err error
+		fields, err := streamSchema.fields(data[0])
+		if err != nil {
+			return err
+		}
+		var errs DecodeErrors
+		for i, row := range data[1:] {
+			v := fields.remap(row)
 
 			var start, end time.Time
 			if start, err = time.Parse(DateTimeFormat, v[streamStart]); err != nil {
-				return err
+				errs = append(errs, fields.invalid(i+1, v, streamStart, err))
+				continue
 			}
-			if end, err = time.Parse(DateTimeFormat, v[streamEnd]); err != nil {
-				return err
+			if end, err = parseEnd(v[streamEnd]); err != nil {
+				errs = append(errs, fields.invalid(i+1, v, streamEnd, err))
+				continue
 			}
 
 			var rate float64
 			if rate, err = strconv.ParseFloat(v[streamSamplingRate], 64); err != nil {
-				return err
+				errs = append(errs, fields.invalid(i+1, v, streamSamplingRate, err))
+				continue
 			}
 
 			var axial, reversed, triggered bool
 			if axial, err = strconv.ParseBool(v[streamAxial]); err != nil {
-				return err
+				errs = append(errs, fields.invalid(i+1, v, streamAxial, err))
+				continue
 			}
 			if reversed, err = strconv.ParseBool(v[streamReversed]); err != nil {
-				return err
+				errs = append(errs, fields.invalid(i+1, v, streamReversed, err))
+				continue
 			}
 			if triggered, err = strconv.ParseBool(v[streamTriggered]); err != nil {
-				return err
+				errs = append(errs, fields.invalid(i+1, v, streamTriggered, err))
+				continue
 			}
 
 			streams = append(streams, Stream{
@@ -133,6 +170,10 @@ func (c *StreamList) decode(data [][]string) error {
 			})
 		}
 
+		if errs != nil {
+			return errs
+		}
+
 		*c = StreamList(streams)
 	}
 	return nil
@@ -1,7 +1,6 @@
 package meta
 
 import (
-	"fmt"
 	"sort"
 	"strings"
 	"time"
@@ -12,9 +11,18 @@ const (
 	visibilitySkyVisibility
 	visibilityStartTime
 	visibilityEndTime
-	visibilityLast
 )
 
+var visibilitySchema = schema{
+	version: 1,
+	columns: []column{
+		visibilityCode:          {name: "Code"},
+		visibilitySkyVisibility: {name: "Sky Visibility"},
+		visibilityStartTime:     {name: "Start Date"},
+		visibilityEndTime:       {name: "End Date"},
+	},
+}
+
 type Visibility struct {
 	Span
 	Code          string
@@ -36,19 +44,41 @@ func (m VisibilityList) Less(i, j int) bool {
 	}
 }
 
+func (m VisibilityList) schema() schema                    { return visibilitySchema }
+func (m VisibilityList) MarshalJSON() ([]byte, error)      { return marshalJSON(m) }
+func (m *VisibilityList) UnmarshalJSON(b []byte) error     { return unmarshalJSON(b, m) }
+func (m VisibilityList) MarshalYAML() (interface{}, error) { return marshalYAML(m) }
+func (m *VisibilityList) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalYAML(unmarshal, m)
+}
+
+func (m Visibility) MarshalJSON() ([]byte, error) { return marshalRecordJSON(VisibilityList{m}) }
+func (m *Visibility) UnmarshalJSON(b []byte) error {
+	var l VisibilityList
+	if err := unmarshalRecordJSON(b, &l); err != nil {
+		return err
+	}
+	*m = l[0]
+	return nil
+}
+func (m Visibility) MarshalYAML() (interface{}, error) { return marshalRecordYAML(VisibilityList{m}) }
+func (m *Visibility) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var l VisibilityList
+	if err := unmarshalRecordYAML(unmarshal, &l); err != nil {
+		return err
+	}
+	*m = l[0]
+	return nil
+}
+
 func (m VisibilityList) encode() [][]string {
-	data := [][]string{{
-		"Code",
-		"Sky Visibility",
-		"Start Date",
-		"End Date",
-	}}
+	data := [][]string{visibilitySchema.header()}
 	for _, v := range m {
 		data = append(data, []string{
 			strings.TrimSpace(v.Code),
 			strings.TrimSpace(v.SkyVisibility),
 			v.Start.Format(DateTimeFormat),
-			v.End.Format(DateTimeFormat),
+			formatEnd(v.End),
 		})
 	}
 	return data
@@ -57,18 +87,22 @@ func (m VisibilityList) encode() [][]string {
 func (m *VisibilityList) decode(data [][]string) error {
 	var visibilities []Visibility
 	if len(data) > 1 {
-		for _, d := range data[1:] {
-			if len(d) != visibilityLast {
-				return fmt.Errorf("incorrect number of installed visibility fields")
-			}
-			var err error
+		fields, err := visibilitySchema.fields(data[0])
+		if err != nil {
+			return err
+		}
+		var errs DecodeErrors
+		for i, row := range data[1:] {
+			d := fields.remap(row)
 
 			var start, end time.Time
 			if start, err = time.Parse(DateTimeFormat, d[visibilityStartTime]); err != nil {
-				return err
+				errs = append(errs, fields.invalid(i+1, d, visibilityStartTime, err))
+				continue
 			}
-			if end, err = time.Parse(DateTimeFormat, d[visibilityEndTime]); err != nil {
-				return err
+			if end, err = parseEnd(d[visibilityEndTime]); err != nil {
+				errs = append(errs, fields.invalid(i+1, d, visibilityEndTime, err))
+				continue
 			}
 			visibilities = append(visibilities, Visibility{
 				Code:          strings.TrimSpace(d[visibilityCode]),
@@ -80,6 +114,10 @@ func (m *VisibilityList) decode(data [][]string) error {
 			})
 		}
 
+		if errs != nil {
+			return errs
+		}
+
 		*m = VisibilityList(visibilities)
 	}
 	return nil
@@ -0,0 +1,266 @@
+package metadb
+
+import (
+	"time"
+
+	"github.com/GeoNet/delta/meta"
+)
+
+// Snapshot is a view of the meta data which only returns entries that were valid at a given instant,
+// i.e. where the instant is at or after the start time and before the end time.
+type Snapshot struct {
+	db *MetaDB
+	at time.Time
+}
+
+// At returns a Snapshot of the meta data as it was at the given time.
+func (m *MetaDB) At(at time.Time) *Snapshot {
+	return &Snapshot{
+		db: m,
+		at: at,
+	}
+}
+
+// Time returns the instant the snapshot represents.
+func (s *Snapshot) Time() time.Time {
+	return s.at
+}
+
+// Stations returns the stations operational at the snapshot time.
+func (s *Snapshot) Stations() ([]meta.Station, error) {
+	list, err := s.db.Stations()
+	if err != nil {
+		return nil, err
+	}
+
+	var stations []meta.Station
+	for _, v := range list {
+		if v.Contains(s.at) {
+			stations = append(stations, v)
+		}
+	}
+
+	return stations, nil
+}
+
+// Station returns the given station if it was operational at the snapshot time.
+func (s *Snapshot) Station(code string) (*meta.Station, error) {
+	station, err := s.db.Station(code)
+	if err != nil || station == nil || !station.Contains(s.at) {
+		return nil, err
+	}
+	return station, nil
+}
+
+// Sites returns the sites of the given station that were operational at the snapshot time.
+func (s *Snapshot) Sites(sta string) ([]meta.Site, error) {
+	list, err := s.db.Sites(sta)
+	if err != nil {
+		return nil, err
+	}
+
+	var sites []meta.Site
+	for _, v := range list {
+		if v.Contains(s.at) {
+			sites = append(sites, v)
+		}
+	}
+
+	return sites, nil
+}
+
+// Site returns the given station location if it was operational at the snapshot time.
+func (s *Snapshot) Site(sta, loc string) (*meta.Site, error) {
+	site, err := s.db.Site(sta, loc)
+	if err != nil || site == nil || !site.Contains(s.at) {
+		return nil, err
+	}
+	return site, nil
+}
+
+// Marks returns the GNSS marks that were operational at the snapshot time.
+func (s *Snapshot) Marks() ([]meta.Mark, error) {
+	list, err := s.db.Marks()
+	if err != nil {
+		return nil, err
+	}
+
+	var marks []meta.Mark
+	for _, v := range list {
+		if v.Contains(s.at) {
+			marks = append(marks, v)
+		}
+	}
+
+	return marks, nil
+}
+
+// Mark returns the given GNSS mark if it was operational at the snapshot time.
+func (s *Snapshot) Mark(code string) (*meta.Mark, error) {
+	mark, err := s.db.Mark(code)
+	if err != nil || mark == nil || !mark.Contains(s.at) {
+		return nil, err
+	}
+	return mark, nil
+}
+
+// InstalledSensors returns the sensors installed at the given station at the snapshot time.
+func (s *Snapshot) InstalledSensors(sta string) ([]meta.InstalledSensor, error) {
+	list, err := s.db.StationInstalledSensors(sta)
+	if err != nil {
+		return nil, err
+	}
+
+	var sensors []meta.InstalledSensor
+	for _, v := range list {
+		if v.Contains(s.at) {
+			sensors = append(sensors, v)
+		}
+	}
+
+	return sensors, nil
+}
+
+// InstalledRecorders returns the recorders installed at the given station at the snapshot time.
+func (s *Snapshot) InstalledRecorders(sta string) ([]meta.InstalledRecorder, error) {
+	list, err := s.db.StationInstalledRecorders(sta)
+	if err != nil {
+		return nil, err
+	}
+
+	var recorders []meta.InstalledRecorder
+	for _, v := range list {
+		if v.Contains(s.at) {
+			recorders = append(recorders, v)
+		}
+	}
+
+	return recorders, nil
+}
+
+// DeployedDataloggers returns the dataloggers deployed at the given place and role at the snapshot time.
+func (s *Snapshot) DeployedDataloggers(place, role string) ([]meta.DeployedDatalogger, error) {
+	list, err := s.db.PlaceRoleDeployedDataloggers(place, role)
+	if err != nil {
+		return nil, err
+	}
+
+	var dataloggers []meta.DeployedDatalogger
+	for _, v := range list {
+		if v.Contains(s.at) {
+			dataloggers = append(dataloggers, v)
+		}
+	}
+
+	return dataloggers, nil
+}
+
+// Connections returns the datalogger connections of the given station at the snapshot time.
+func (s *Snapshot) Connections(sta string) ([]meta.Connection, error) {
+	list, err := s.db.StationConnections(sta)
+	if err != nil {
+		return nil, err
+	}
+
+	var connections []meta.Connection
+	for _, v := range list {
+		if v.Contains(s.at) {
+			connections = append(connections, v)
+		}
+	}
+
+	return connections, nil
+}
+
+// Streams returns the recording streams of the given station location at the snapshot time.
+func (s *Snapshot) Streams(sta, loc string) ([]meta.Stream, error) {
+	list, err := s.db.StationLocationStreams(sta, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	var streams []meta.Stream
+	for _, v := range list {
+		if v.Contains(s.at) {
+			streams = append(streams, v)
+		}
+	}
+
+	return streams, nil
+}
+
+// Sessions returns the GNSS recording sessions of the given mark at the snapshot time.
+func (s *Snapshot) Sessions(mark string) ([]meta.Session, error) {
+	list, err := s.db.MarkSessions(mark)
+	if err != nil {
+		return nil, err
+	}
+
+	var sessions []meta.Session
+	for _, v := range list {
+		if v.Contains(s.at) {
+			sessions = append(sessions, v)
+		}
+	}
+
+	return sessions, nil
+}
+
+// Firmware returns the firmware installed in the given GNSS receiver at the snapshot time.
+func (s *Snapshot) Firmware(model, serial string) (*meta.FirmwareHistory, error) {
+	return s.db.ReceiverFirmwareAt(model, serial, s.at)
+}
+
+// Antenna returns the antenna installed at the given GNSS mark at the snapshot time.
+func (s *Snapshot) Antenna(mark string) (*meta.InstalledAntenna, error) {
+	return s.db.MarkInstalledAntennaAt(mark, s.at)
+}
+
+// Receiver returns the receiver deployed at the given GNSS mark at the snapshot time.
+func (s *Snapshot) Receiver(mark string) (*meta.DeployedReceiver, error) {
+	return s.db.MarkDeployedReceiverAt(mark, s.at)
+}
+
+// Radome returns the radome installed at the given GNSS mark at the snapshot time.
+func (s *Snapshot) Radome(mark string) (*meta.InstalledRadome, error) {
+	return s.db.MarkInstalledRadomeAt(mark, s.at)
+}
+
+// MetSensor returns the meteorological sensor installed at the given GNSS mark at the snapshot time.
+func (s *Snapshot) MetSensor(mark string) (*meta.InstalledMetSensor, error) {
+	return s.db.MarkInstalledMetSensorAt(mark, s.at)
+}
+
+// Installations returns the sensor and datalogger pairs recording at the given station at the snapshot time.
+func (s *Snapshot) Installations(sta string) ([]Installation, error) {
+	list, err := s.db.Installations(sta)
+	if err != nil {
+		return nil, err
+	}
+
+	var installations []Installation
+	for _, v := range list {
+		if v.Contains(s.at) {
+			installations = append(installations, v)
+		}
+	}
+
+	return installations, nil
+}
+
+// Channels returns the channels recorded at the given station at the snapshot time.
+func (s *Snapshot) Channels(sta string) ([]Channel, error) {
+	list, err := s.db.Channels(sta)
+	if err != nil {
+		return nil, err
+	}
+
+	var channels []Channel
+	for _, v := range list {
+		if (meta.Span{Start: v.Start, End: v.End}).Contains(s.at) {
+			channels = append(channels, v)
+		}
+	}
+
+	return channels, nil
+}
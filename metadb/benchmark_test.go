@@ -0,0 +1,71 @@
+package metadb_test
+
+import (
+	"testing"
+
+	"github.com/GeoNet/delta/metadb"
+)
+
+// benchmarks are run against the full delta dataset
+func BenchmarkInstallations(b *testing.B) {
+	db := metadb.NewMetaDB("..")
+
+	stations, err := db.Stations()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, s := range stations {
+			if _, err := db.Installations(s.Code); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkStationLocationSamplingRateStartStream(b *testing.B) {
+	db := metadb.NewMetaDB("..")
+
+	stations, err := db.Stations()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	var installations []metadb.Installation
+	for _, s := range stations {
+		list, err := db.Installations(s.Code)
+		if err != nil {
+			b.Fatal(err)
+		}
+		installations = append(installations, list...)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, v := range installations {
+			if _, err := db.StationLocationSamplingRateStartStream(v.Station, v.Location, 100.0, v.Start); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkChannels(b *testing.B) {
+	db := metadb.NewMetaDB("..")
+
+	stations, err := db.Stations()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, s := range stations {
+			if _, err := db.Channels(s.Code); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
@@ -2,10 +2,9 @@ package metadb
 
 import (
 	"time"
-
-	"github.com/GeoNet/delta/resp"
 )
 
+// Channel describes a recorded stream component of a station installation.
 type Channel struct {
 	Network  string
 	External string
@@ -18,16 +17,17 @@ type Channel struct {
 	End        time.Time
 }
 
+// Channels returns the recorded channels of the given station based on the known response streams.
 func (m *MetaDB) Channels(sta string) ([]Channel, error) {
 	var channels []Channel
 
 	station, err := m.Station(sta)
-	if err != nil {
+	if err != nil || station == nil {
 		return nil, err
 	}
 
 	network, err := m.Network(station.Network)
-	if err != nil {
+	if err != nil || network == nil {
 		return nil, err
 	}
 
@@ -45,7 +45,7 @@ func (m *MetaDB) Channels(sta string) ([]Channel, error) {
 			continue
 		}
 
-		for _, response := range resp.Streams(installation.Datalogger.Model, installation.Sensor.Model) {
+		for _, response := range installation.Streams() {
 
 			stream, err := m.StationLocationSamplingRateStartStream(
 				station.Code,
@@ -0,0 +1,107 @@
+package metadb
+
+import (
+	"io/fs"
+	"sync"
+
+	"github.com/GeoNet/delta/meta"
+)
+
+type connections struct {
+	list      meta.ConnectionList
+	stations  map[string][]meta.Connection
+	locations map[string]map[string][]meta.Connection
+	spans     map[string]map[string]*intervals
+	err       error
+	once      sync.Once
+}
+
+func (c *connections) loadConnections(fsys fs.FS) error {
+	c.once.Do(func() {
+		if c.err = meta.LoadListFS(fsys, "install/connections.csv", &c.list); c.err == nil {
+			stations := make(map[string][]meta.Connection)
+			for _, v := range c.list {
+				if _, ok := stations[v.Station]; !ok {
+					stations[v.Station] = []meta.Connection{}
+				}
+				stations[v.Station] = append(stations[v.Station], v)
+			}
+			c.stations = stations
+
+			locations := make(map[string]map[string][]meta.Connection)
+			for _, v := range c.list {
+				if _, ok := locations[v.Station]; !ok {
+					locations[v.Station] = make(map[string][]meta.Connection)
+				}
+				if _, ok := locations[v.Station][v.Location]; !ok {
+					locations[v.Station][v.Location] = []meta.Connection{}
+				}
+				locations[v.Station][v.Location] = append(locations[v.Station][v.Location], v)
+			}
+			c.locations = locations
+
+			spans := make(map[string]map[string]*intervals)
+			for sta, l := range locations {
+				spans[sta] = make(map[string]*intervals)
+				for loc, v := range l {
+					var list []meta.Span
+					for _, r := range v {
+						list = append(list, r.Span)
+					}
+					spans[sta][loc] = newIntervals(list)
+				}
+			}
+			c.spans = spans
+		}
+	})
+
+	return c.err
+}
+
+// StationConnections returns the datalogger connections of the given station.
+func (m *MetaDB) StationConnections(sta string) ([]meta.Connection, error) {
+	if err := m.loadConnections(m.fsys); err != nil {
+		return nil, err
+	}
+
+	if c, ok := m.connections.stations[sta]; ok {
+		return c, nil
+	}
+
+	return nil, nil
+}
+
+// StationLocationConnections returns the datalogger connections of the given station location.
+func (m *MetaDB) StationLocationConnections(sta, loc string) ([]meta.Connection, error) {
+	if err := m.loadConnections(m.fsys); err != nil {
+		return nil, err
+	}
+
+	if s, ok := m.connections.locations[sta]; ok {
+		if l, ok := s[loc]; ok {
+			return l, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// StationLocationSpanConnections returns the datalogger connections of the given station location at
+// any time during the given span, the span boundaries are inclusive.
+func (m *MetaDB) StationLocationSpanConnections(sta, loc string, span meta.Span) ([]meta.Connection, error) {
+	if err := m.loadConnections(m.fsys); err != nil {
+		return nil, err
+	}
+
+	var connections []meta.Connection
+
+	if s, ok := m.connections.locations[sta]; ok {
+		if l, ok := s[loc]; ok {
+			for _, n := range m.connections.spans[sta][loc].overlaps(span.Start, span.End) {
+				connections = append(connections, l[n])
+			}
+		}
+	}
+
+	return connections, nil
+}
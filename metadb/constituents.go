@@ -1,7 +1,7 @@
 package metadb
 
 import (
-	"path/filepath"
+	"io/fs"
 	"sync"
 
 	"github.com/GeoNet/delta/meta"
@@ -10,14 +10,13 @@ import (
 type constituents struct {
 	list   meta.ConstituentList
 	gauges map[string][]meta.Constituent
+	err    error
 	once   sync.Once
 }
 
-func (c *constituents) loadConstituents(base string) error {
-	var err error
-
+func (c *constituents) loadConstituents(fsys fs.FS) error {
 	c.once.Do(func() {
-		if err = meta.LoadList(filepath.Join(base, "environment", "constituents.csv"), &c.list); err == nil {
+		if c.err = meta.LoadListFS(fsys, "environment/constituents.csv", &c.list); c.err == nil {
 			gauges := make(map[string][]meta.Constituent)
 			for _, v := range c.list {
 				if _, ok := gauges[v.Gauge]; !ok {
@@ -29,11 +28,12 @@ func (c *constituents) loadConstituents(base string) error {
 		}
 	})
 
-	return err
+	return c.err
 }
 
+// GaugeConstituents returns the tidal constituents of the given gauge.
 func (m *MetaDB) GaugeConstituents(gauge string) ([]meta.Constituent, error) {
-	if err := m.loadConstituents(m.base); err != nil {
+	if err := m.loadConstituents(m.fsys); err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,128 @@
+package metadb
+
+import (
+	"io/fs"
+	"sync"
+
+	"github.com/GeoNet/delta/meta"
+)
+
+type dataloggers struct {
+	list  meta.DeployedDataloggerList
+	roles map[string]map[string][]meta.DeployedDatalogger
+	spans map[string]map[string]*intervals
+	err   error
+	once  sync.Once
+}
+
+func (d *dataloggers) loadDeployedDataloggers(fsys fs.FS) error {
+	d.once.Do(func() {
+		if d.err = meta.LoadListFS(fsys, "install/dataloggers.csv", &d.list); d.err == nil {
+			roles := make(map[string]map[string][]meta.DeployedDatalogger)
+			for _, v := range d.list {
+				if _, ok := roles[v.Place]; !ok {
+					roles[v.Place] = make(map[string][]meta.DeployedDatalogger)
+				}
+				if _, ok := roles[v.Place][v.Role]; !ok {
+					roles[v.Place][v.Role] = []meta.DeployedDatalogger{}
+				}
+				roles[v.Place][v.Role] = append(roles[v.Place][v.Role], v)
+			}
+			d.roles = roles
+
+			spans := make(map[string]map[string]*intervals)
+			for place, p := range roles {
+				spans[place] = make(map[string]*intervals)
+				for role, v := range p {
+					var list []meta.Span
+					for _, r := range v {
+						list = append(list, r.Span)
+					}
+					spans[place][role] = newIntervals(list)
+				}
+			}
+			d.spans = spans
+		}
+	})
+
+	return d.err
+}
+
+// PlaceRoleDeployedDataloggers returns the dataloggers deployed at the given place and role.
+func (m *MetaDB) PlaceRoleDeployedDataloggers(place, role string) ([]meta.DeployedDatalogger, error) {
+
+	if err := m.loadDeployedDataloggers(m.fsys); err != nil {
+		return nil, err
+	}
+
+	if p, ok := m.dataloggers.roles[place]; ok {
+		if r, ok := p[role]; ok {
+			return r, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// PlaceRoleSpanDeployedDataloggers returns the dataloggers deployed at the given place and role at
+// any time during the given span, the span boundaries are inclusive.
+func (m *MetaDB) PlaceRoleSpanDeployedDataloggers(place, role string, span meta.Span) ([]meta.DeployedDatalogger, error) {
+
+	if err := m.loadDeployedDataloggers(m.fsys); err != nil {
+		return nil, err
+	}
+
+	var loggers []meta.DeployedDatalogger
+
+	if p, ok := m.dataloggers.roles[place]; ok {
+		if r, ok := p[role]; ok {
+			for _, n := range m.dataloggers.spans[place][role].overlaps(span.Start, span.End) {
+				loggers = append(loggers, r[n])
+			}
+		}
+	}
+
+	return loggers, nil
+}
+
+// ConnectionInstalledSensorDeployedDataloggers returns the dataloggers deployed for the given connection
+// which overlap the time the sensor was installed.
+func (m *MetaDB) ConnectionInstalledSensorDeployedDataloggers(con meta.Connection, sen meta.InstalledSensor) ([]meta.DeployedDatalogger, error) {
+
+	if con.Station != sen.Station || con.Location != sen.Location {
+		return nil, nil
+	}
+
+	// deployments need to start before both the connection and the sensor installation end,
+	// and finish after both have started.
+	start, end := con.Start, con.End
+	if sen.Start.After(start) {
+		start = sen.Start
+	}
+	if sen.End.Before(end) {
+		end = sen.End
+	}
+
+	return m.PlaceRoleSpanDeployedDataloggers(con.Place, con.Role, meta.Span{Start: start, End: end})
+}
+
+// DeployedDataloggerConnections returns the dataloggers connected to the given station location which
+// overlap the time the sensor was installed.
+func (m *MetaDB) DeployedDataloggerConnections(sensor meta.InstalledSensor, station, location string) ([]meta.DeployedDatalogger, error) {
+
+	var dataloggers []meta.DeployedDatalogger
+
+	connections, err := m.StationLocationSpanConnections(station, location, sensor.Span)
+	if err != nil {
+		return nil, err
+	}
+	for _, connection := range connections {
+		deployed, err := m.PlaceRoleSpanDeployedDataloggers(connection.Place, connection.Role, connection.Span)
+		if err != nil {
+			return nil, err
+		}
+		dataloggers = append(dataloggers, deployed...)
+	}
+
+	return dataloggers, nil
+}
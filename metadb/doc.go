@@ -0,0 +1,44 @@
+/*
+Package metadb provides cached and indexed access to the delta meta data files.
+
+A MetaDB is built over either a directory, using NewMetaDB, or any fs.FS, using NewMetaDBFS,
+holding the raw csv files laid out as in the delta repository, i.e.
+
+	network/networks.csv
+	network/stations.csv
+	network/sites.csv
+	network/marks.csv
+	network/monuments.csv
+	install/sensors.csv
+	install/recorders.csv
+	install/dataloggers.csv
+	install/gains.csv
+	install/connections.csv
+	install/streams.csv
+	install/sessions.csv
+	install/firmware.csv
+	install/antennas.csv
+	install/receivers.csv
+	install/radomes.csv
+	install/metsensors.csv
+	environment/gauges.csv
+	environment/constituents.csv
+
+Each file is only read the first time it is needed, any error found while reading a file is cached
+and returned by every later lookup that needs it. Lookups that find no matching entry return a nil
+value and a nil error. The optional gains file is treated as empty if it is missing.
+
+Long running services can use a Reloader, which builds and fully loads a new MetaDB on each Reload
+and only replaces the current one if the load succeeds. The Watch method can be used to reload once
+the meta files have changed.
+
+The higher level joins, such as Installations and Channels, combine the sensor, connection and
+datalogger files into the equipment actually recording at a station location over time.
+
+The exported API is versioned by Version, which is incremented whenever an exported type or
+method changes in an incompatible way.
+*/
+package metadb
+
+// Version is the current version of the metadb API.
+const Version = 1
@@ -0,0 +1,236 @@
+package metadb
+
+import (
+	"io/fs"
+	"sync"
+	"time"
+
+	"github.com/GeoNet/delta/meta"
+)
+
+// markEntry gives the mark and span of an entry in a list of GNSS mark equipment.
+type markEntry struct {
+	mark string
+	span meta.Span
+}
+
+// markIndex caches a list of equipment installed at GNSS marks, entries are referred to by their
+// position in the list and are indexed by mark and by their installation spans.
+type markIndex struct {
+	marks map[string][]int
+	spans map[string]*intervals
+	err   error
+	once  sync.Once
+}
+
+// load decodes the list once and indexes it, the entries function gives the mark and span of
+// each of the decoded list entries in list order.
+func (x *markIndex) load(fsys fs.FS, path string, list meta.ListDecoder, entries func() []markEntry) error {
+	x.once.Do(func() {
+		if x.err = meta.LoadListFS(fsys, path, list); x.err != nil {
+			return
+		}
+
+		marks := make(map[string][]int)
+		spans := make(map[string][]meta.Span)
+		for n, v := range entries() {
+			marks[v.mark] = append(marks[v.mark], n)
+			spans[v.mark] = append(spans[v.mark], v.span)
+		}
+		x.marks = marks
+
+		x.spans = make(map[string]*intervals)
+		for k, v := range spans {
+			x.spans[k] = newIntervals(v)
+		}
+	})
+
+	return x.err
+}
+
+// at returns the list position of the entry installed at the given mark at the given time, the
+// contains function checks the list entry itself. A negative value is returned if there is none.
+func (x *markIndex) at(mark string, at time.Time, contains func(int) bool) int {
+	list, ok := x.marks[mark]
+	if !ok {
+		return -1
+	}
+	for _, n := range x.spans[mark].contains(at) {
+		if contains(list[n]) {
+			return list[n]
+		}
+	}
+	return -1
+}
+
+type antennas struct {
+	list  meta.InstalledAntennaList
+	index markIndex
+}
+
+func (a *antennas) loadInstalledAntennas(fsys fs.FS) error {
+	return a.index.load(fsys, "install/antennas.csv", &a.list, func() []markEntry {
+		var entries []markEntry
+		for _, v := range a.list {
+			entries = append(entries, markEntry{mark: v.Mark, span: v.Span})
+		}
+		return entries
+	})
+}
+
+// MarkInstalledAntennas returns the antennas installed at the given GNSS mark.
+func (m *MetaDB) MarkInstalledAntennas(mark string) ([]meta.InstalledAntenna, error) {
+	if err := m.loadInstalledAntennas(m.fsys); err != nil {
+		return nil, err
+	}
+
+	var list []meta.InstalledAntenna
+	for _, n := range m.antennas.index.marks[mark] {
+		list = append(list, m.antennas.list[n])
+	}
+
+	return list, nil
+}
+
+// MarkInstalledAntennaAt returns the antenna installed at the given GNSS mark at the given time.
+func (m *MetaDB) MarkInstalledAntennaAt(mark string, at time.Time) (*meta.InstalledAntenna, error) {
+	if err := m.loadInstalledAntennas(m.fsys); err != nil {
+		return nil, err
+	}
+
+	if n := m.antennas.index.at(mark, at, func(n int) bool { return m.antennas.list[n].Contains(at) }); n >= 0 {
+		v := m.antennas.list[n]
+		return &v, nil
+	}
+
+	return nil, nil
+}
+
+type receivers struct {
+	list  meta.DeployedReceiverList
+	index markIndex
+}
+
+func (r *receivers) loadDeployedReceivers(fsys fs.FS) error {
+	return r.index.load(fsys, "install/receivers.csv", &r.list, func() []markEntry {
+		var entries []markEntry
+		for _, v := range r.list {
+			entries = append(entries, markEntry{mark: v.Mark, span: v.Span})
+		}
+		return entries
+	})
+}
+
+// MarkDeployedReceivers returns the receivers deployed at the given GNSS mark.
+func (m *MetaDB) MarkDeployedReceivers(mark string) ([]meta.DeployedReceiver, error) {
+	if err := m.loadDeployedReceivers(m.fsys); err != nil {
+		return nil, err
+	}
+
+	var list []meta.DeployedReceiver
+	for _, n := range m.receivers.index.marks[mark] {
+		list = append(list, m.receivers.list[n])
+	}
+
+	return list, nil
+}
+
+// MarkDeployedReceiverAt returns the receiver deployed at the given GNSS mark at the given time.
+func (m *MetaDB) MarkDeployedReceiverAt(mark string, at time.Time) (*meta.DeployedReceiver, error) {
+	if err := m.loadDeployedReceivers(m.fsys); err != nil {
+		return nil, err
+	}
+
+	if n := m.receivers.index.at(mark, at, func(n int) bool { return m.receivers.list[n].Contains(at) }); n >= 0 {
+		v := m.receivers.list[n]
+		return &v, nil
+	}
+
+	return nil, nil
+}
+
+type radomes struct {
+	list  meta.InstalledRadomeList
+	index markIndex
+}
+
+func (r *radomes) loadInstalledRadomes(fsys fs.FS) error {
+	return r.index.load(fsys, "install/radomes.csv", &r.list, func() []markEntry {
+		var entries []markEntry
+		for _, v := range r.list {
+			entries = append(entries, markEntry{mark: v.Mark, span: v.Span})
+		}
+		return entries
+	})
+}
+
+// MarkInstalledRadomes returns the radomes installed at the given GNSS mark.
+func (m *MetaDB) MarkInstalledRadomes(mark string) ([]meta.InstalledRadome, error) {
+	if err := m.loadInstalledRadomes(m.fsys); err != nil {
+		return nil, err
+	}
+
+	var list []meta.InstalledRadome
+	for _, n := range m.radomes.index.marks[mark] {
+		list = append(list, m.radomes.list[n])
+	}
+
+	return list, nil
+}
+
+// MarkInstalledRadomeAt returns the radome installed at the given GNSS mark at the given time.
+func (m *MetaDB) MarkInstalledRadomeAt(mark string, at time.Time) (*meta.InstalledRadome, error) {
+	if err := m.loadInstalledRadomes(m.fsys); err != nil {
+		return nil, err
+	}
+
+	if n := m.radomes.index.at(mark, at, func(n int) bool { return m.radomes.list[n].Contains(at) }); n >= 0 {
+		v := m.radomes.list[n]
+		return &v, nil
+	}
+
+	return nil, nil
+}
+
+type metsensors struct {
+	list  meta.InstalledMetSensorList
+	index markIndex
+}
+
+func (s *metsensors) loadInstalledMetSensors(fsys fs.FS) error {
+	return s.index.load(fsys, "install/metsensors.csv", &s.list, func() []markEntry {
+		var entries []markEntry
+		for _, v := range s.list {
+			entries = append(entries, markEntry{mark: v.Mark, span: v.Span})
+		}
+		return entries
+	})
+}
+
+// MarkInstalledMetSensors returns the meteorological sensors installed at the given GNSS mark.
+func (m *MetaDB) MarkInstalledMetSensors(mark string) ([]meta.InstalledMetSensor, error) {
+	if err := m.loadInstalledMetSensors(m.fsys); err != nil {
+		return nil, err
+	}
+
+	var list []meta.InstalledMetSensor
+	for _, n := range m.metsensors.index.marks[mark] {
+		list = append(list, m.metsensors.list[n])
+	}
+
+	return list, nil
+}
+
+// MarkInstalledMetSensorAt returns the meteorological sensor installed at the given GNSS mark at the given time.
+func (m *MetaDB) MarkInstalledMetSensorAt(mark string, at time.Time) (*meta.InstalledMetSensor, error) {
+	if err := m.loadInstalledMetSensors(m.fsys); err != nil {
+		return nil, err
+	}
+
+	if n := m.metsensors.index.at(mark, at, func(n int) bool { return m.metsensors.list[n].Contains(at) }); n >= 0 {
+		v := m.metsensors.list[n]
+		return &v, nil
+	}
+
+	return nil, nil
+}
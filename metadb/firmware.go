@@ -0,0 +1,85 @@
+package metadb
+
+import (
+	"io/fs"
+	"sync"
+	"time"
+
+	"github.com/GeoNet/delta/meta"
+)
+
+type firmware struct {
+	list    meta.FirmwareHistoryList
+	serials map[string]map[string][]meta.FirmwareHistory
+	spans   map[string]map[string]*intervals
+	err     error
+	once    sync.Once
+}
+
+func (f *firmware) loadFirmwareHistory(fsys fs.FS) error {
+	f.once.Do(func() {
+		if f.err = meta.LoadListFS(fsys, "install/firmware.csv", &f.list); f.err == nil {
+			serials := make(map[string]map[string][]meta.FirmwareHistory)
+			for _, v := range f.list {
+				if _, ok := serials[v.Model]; !ok {
+					serials[v.Model] = make(map[string][]meta.FirmwareHistory)
+				}
+				if _, ok := serials[v.Model][v.Serial]; !ok {
+					serials[v.Model][v.Serial] = []meta.FirmwareHistory{}
+				}
+				serials[v.Model][v.Serial] = append(serials[v.Model][v.Serial], v)
+			}
+			f.serials = serials
+
+			spans := make(map[string]map[string]*intervals)
+			for model, s := range serials {
+				spans[model] = make(map[string]*intervals)
+				for serial, v := range s {
+					var list []meta.Span
+					for _, i := range v {
+						list = append(list, i.Span)
+					}
+					spans[model][serial] = newIntervals(list)
+				}
+			}
+			f.spans = spans
+		}
+	})
+
+	return f.err
+}
+
+// ReceiverFirmware returns the firmware history of the given GNSS receiver model and serial number.
+func (m *MetaDB) ReceiverFirmware(model, serial string) ([]meta.FirmwareHistory, error) {
+	if err := m.loadFirmwareHistory(m.fsys); err != nil {
+		return nil, err
+	}
+
+	if s, ok := m.firmware.serials[model]; ok {
+		if f, ok := s[serial]; ok {
+			return f, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// ReceiverFirmwareAt returns the firmware installed in the given GNSS receiver model and serial number
+// at the given time.
+func (m *MetaDB) ReceiverFirmwareAt(model, serial string, at time.Time) (*meta.FirmwareHistory, error) {
+	if err := m.loadFirmwareHistory(m.fsys); err != nil {
+		return nil, err
+	}
+
+	if s, ok := m.firmware.serials[model]; ok {
+		if f, ok := s[serial]; ok {
+			for _, n := range m.firmware.spans[model][serial].contains(at) {
+				if v := f[n]; v.Contains(at) {
+					return &v, nil
+				}
+			}
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,55 @@
+package metadb
+
+import (
+	"errors"
+	"io/fs"
+	"sort"
+	"sync"
+
+	"github.com/GeoNet/delta/meta"
+)
+
+type gains struct {
+	list    meta.DataloggerGainList
+	serials map[string]map[string][]meta.DataloggerGain
+	err     error
+	once    sync.Once
+}
+
+func (g *gains) loadDataloggerGains(fsys fs.FS) error {
+	g.once.Do(func() {
+		// the gains file is optional, dataloggers without an entry use their model response
+		if err := meta.LoadListFS(fsys, "install/gains.csv", &g.list); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			g.err = err
+			return
+		}
+
+		sort.Sort(g.list)
+
+		serials := make(map[string]map[string][]meta.DataloggerGain)
+		for _, v := range g.list {
+			if _, ok := serials[v.Model]; !ok {
+				serials[v.Model] = make(map[string][]meta.DataloggerGain)
+			}
+			serials[v.Model][v.Serial] = append(serials[v.Model][v.Serial], v)
+		}
+		g.serials = serials
+	})
+
+	return g.err
+}
+
+// DataloggerGains returns the non-default settings of the given datalogger model and serial number.
+func (m *MetaDB) DataloggerGains(model, serial string) ([]meta.DataloggerGain, error) {
+	if err := m.loadDataloggerGains(m.fsys); err != nil {
+		return nil, err
+	}
+
+	if s, ok := m.gains.serials[model]; ok {
+		if g, ok := s[serial]; ok {
+			return g, nil
+		}
+	}
+
+	return nil, nil
+}
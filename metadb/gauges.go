@@ -1,7 +1,7 @@
 package metadb
 
 import (
-	"path/filepath"
+	"io/fs"
 	"sync"
 
 	"github.com/GeoNet/delta/meta"
@@ -9,21 +9,21 @@ import (
 
 type gauges struct {
 	list meta.GaugeList
+	err  error
 	once sync.Once
 }
 
-func (g *gauges) loadGauges(base string) error {
-	var err error
-
+func (g *gauges) loadGauges(fsys fs.FS) error {
 	g.once.Do(func() {
-		err = meta.LoadList(filepath.Join(base, "environment", "gauges.csv"), &g.list)
+		g.err = meta.LoadListFS(fsys, "environment/gauges.csv", &g.list)
 	})
 
-	return err
+	return g.err
 }
 
+// Gauges returns all the known tide gauges.
 func (m *MetaDB) Gauges() ([]meta.Gauge, error) {
-	if err := m.loadGauges(m.base); err != nil {
+	if err := m.loadGauges(m.fsys); err != nil {
 		return nil, err
 	}
 	return m.gauges.list, nil
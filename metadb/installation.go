@@ -0,0 +1,185 @@
+package metadb
+
+import (
+	"fmt"
+
+	"github.com/GeoNet/delta/meta"
+	"github.com/GeoNet/delta/resp"
+)
+
+// Installation describes a sensor and datalogger pair recording at a station location.
+type Installation struct {
+	meta.Span
+
+	Station    string
+	Location   string
+	Sensor     meta.InstalledSensor
+	Datalogger meta.DeployedDatalogger
+
+	// any non-default datalogger settings used during the installation
+	Gain *meta.DataloggerGain
+}
+
+// Streams returns the responses of the installed datalogger and sensor pair, any configured datalogger
+// filter is used to choose between alternative responses and the datalogger A2D stage is scaled for any
+// non-default gain settings.
+func (i Installation) Streams() []resp.Stream {
+	streams := resp.Streams(i.Datalogger.Model, i.Sensor.Model)
+	if i.Gain == nil {
+		return streams
+	}
+
+	if i.Gain.Filter != "" {
+		streams = resp.SelectFilter(streams, i.Gain.Filter)
+	}
+
+	// a missing bit weight is decoded as zero which keeps the model gain
+	for n, s := range streams {
+		streams[n] = s.Scale(i.Gain.Gain, i.Gain.BitWeight)
+	}
+
+	return streams
+}
+
+// splitGains splits an installation at any changes in the datalogger gain settings.
+func (m *MetaDB) splitGains(installation Installation) ([]Installation, error) {
+	gains, err := m.DataloggerGains(installation.Datalogger.Model, installation.Datalogger.Serial)
+	if err != nil {
+		return nil, err
+	}
+
+	var installations []Installation
+
+	start := installation.Start
+	for _, g := range gains {
+		span, ok := installation.Span.Intersect(g.Span)
+		if !ok {
+			continue
+		}
+		if span.Start.Before(start) {
+			return nil, fmt.Errorf("datalogger %s [%s] has overlapping gains at %s",
+				installation.Datalogger.Model, installation.Datalogger.Serial, span.Start.Format(meta.DateTimeFormat))
+		}
+		if start.Before(span.Start) {
+			v := installation
+			v.Span = meta.Span{Start: start, End: span.Start}
+			installations = append(installations, v)
+		}
+
+		gain := g
+
+		v := installation
+		v.Span, v.Gain = span, &gain
+		installations = append(installations, v)
+
+		start = span.End
+	}
+	if start.Before(installation.End) {
+		v := installation
+		v.Span = meta.Span{Start: start, End: installation.End}
+		installations = append(installations, v)
+	}
+
+	return installations, nil
+}
+
+// Installations returns the sensor and datalogger pairs installed at the given station, the span
+// of each installation is the overlap of the sensor, connection and datalogger deployments.
+func (m *MetaDB) Installations(station string) ([]Installation, error) {
+	var installations []Installation
+
+	recorders, err := m.StationInstalledRecorders(station)
+	if err != nil {
+		return nil, err
+	}
+	for _, recorder := range recorders {
+		installations = append(installations, Installation{
+			Station:  station,
+			Location: recorder.Location,
+			Sensor:   recorder.InstalledSensor,
+			Datalogger: meta.DeployedDatalogger{
+				Install: meta.Install{
+					Equipment: meta.Equipment{
+						Model: recorder.DataloggerModel,
+					},
+					Span: meta.Span{
+						Start: recorder.Start,
+						End:   recorder.End,
+					},
+				},
+			},
+			Span: meta.Span{
+				Start: recorder.Start,
+				End:   recorder.End,
+			},
+		})
+	}
+
+	connections, err := m.StationConnections(station)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, connection := range connections {
+		location, err := m.Site(station, connection.Location)
+		if err != nil {
+			return nil, err
+		}
+		if location == nil {
+			return nil, nil
+		}
+
+		installs, err := m.StationLocationInstalledSensors(station, connection.Location)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, sensorInstall := range installs {
+
+			deploys, err := m.ConnectionInstalledSensorDeployedDataloggers(connection, sensorInstall)
+			if err != nil {
+				return nil, err
+			}
+			if deploys == nil {
+				continue
+			}
+			for _, dataloggerDeploy := range deploys {
+
+				start, end := connection.Start, connection.End
+				if sensorInstall.Start.After(start) {
+					start = sensorInstall.Start
+				}
+				if dataloggerDeploy.Start.After(start) {
+					start = dataloggerDeploy.Start
+				}
+				if sensorInstall.End.Before(end) {
+					end = sensorInstall.End
+				}
+				if dataloggerDeploy.End.Before(end) {
+					end = dataloggerDeploy.End
+				}
+				if !start.Before(end) {
+					continue
+				}
+
+				list, err := m.splitGains(Installation{
+					Station:    station,
+					Location:   connection.Location,
+					Sensor:     sensorInstall,
+					Datalogger: dataloggerDeploy,
+					Span: meta.Span{
+						Start: start,
+						End:   end,
+					},
+				})
+				if err != nil {
+					return nil, err
+				}
+
+				installations = append(installations, list...)
+			}
+		}
+	}
+
+	return installations, nil
+}
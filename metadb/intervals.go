@@ -0,0 +1,103 @@
+package metadb
+
+import (
+	"sort"
+	"time"
+
+	"github.com/GeoNet/delta/meta"
+)
+
+// intervals is a static interval tree used to find the spans that overlap a time range, it is stored
+// as an implicit balanced tree over the spans sorted by start time. Each node records the latest
+// end time found in its subtree which allows whole subtrees to be skipped.
+type intervals struct {
+	order []int
+	spans []meta.Span
+	ends  []time.Time
+}
+
+// newIntervals builds an interval index over the given spans, the index refers to the spans
+// by their position in the slice.
+func newIntervals(spans []meta.Span) *intervals {
+	order := make([]int, len(spans))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return spans[order[i]].Start.Before(spans[order[j]].Start)
+	})
+
+	sorted := make([]meta.Span, len(spans))
+	for i, n := range order {
+		sorted[i] = spans[n]
+	}
+
+	t := intervals{
+		order: order,
+		spans: sorted,
+		ends:  make([]time.Time, len(spans)),
+	}
+	t.build(0, len(sorted))
+
+	return &t
+}
+
+// build fills in the latest end times of the subtree covering the given range.
+func (t *intervals) build(lo, hi int) time.Time {
+	if !(lo < hi) {
+		return time.Time{}
+	}
+	mid := (lo + hi) / 2
+
+	end := t.spans[mid].End
+	if e := t.build(lo, mid); e.After(end) {
+		end = e
+	}
+	if e := t.build(mid+1, hi); e.After(end) {
+		end = e
+	}
+	t.ends[mid] = end
+
+	return end
+}
+
+// overlaps returns the positions of the spans that start no later than end and finish no earlier
+// than start, i.e. both boundaries are inclusive. The positions are returned in ascending order.
+func (t *intervals) overlaps(start, end time.Time) []int {
+	var found []int
+
+	t.search(0, len(t.spans), start, end, &found)
+	sort.Ints(found)
+
+	return found
+}
+
+// contains returns the positions of the spans which include the given time, both span boundaries
+// are inclusive.
+func (t *intervals) contains(at time.Time) []int {
+	return t.overlaps(at, at)
+}
+
+func (t *intervals) search(lo, hi int, start, end time.Time, found *[]int) {
+	if !(lo < hi) {
+		return
+	}
+	mid := (lo + hi) / 2
+
+	// no span in this subtree ends late enough
+	if t.ends[mid].Before(start) {
+		return
+	}
+
+	t.search(lo, mid, start, end, found)
+
+	// spans to the right start even later
+	if t.spans[mid].Start.After(end) {
+		return
+	}
+	if !t.spans[mid].End.Before(start) {
+		*found = append(*found, t.order[mid])
+	}
+
+	t.search(mid+1, hi, start, end, found)
+}
@@ -0,0 +1,49 @@
+package metadb
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/GeoNet/delta/meta"
+)
+
+func TestIntervals(t *testing.T) {
+
+	r := rand.New(rand.NewSource(1))
+
+	epoch := time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+	at := func(n int) time.Time {
+		return epoch.Add(time.Duration(n) * time.Hour)
+	}
+
+	var spans []meta.Span
+	for i := 0; i < 500; i++ {
+		start := r.Intn(1000)
+		spans = append(spans, meta.Span{Start: at(start), End: at(start + r.Intn(100))})
+	}
+	index := newIntervals(spans)
+
+	for i := 0; i < 1000; i++ {
+		start := r.Intn(1100) - 50
+		end := start + r.Intn(50)
+
+		var expected []int
+		for n, s := range spans {
+			switch {
+			case s.Start.After(at(end)):
+			case s.End.Before(at(start)):
+			default:
+				expected = append(expected, n)
+			}
+		}
+		if found := index.overlaps(at(start), at(end)); !reflect.DeepEqual(found, expected) {
+			t.Fatalf("invalid overlaps [%d, %d]: got %v, expected %v", start, end, found, expected)
+		}
+	}
+
+	if found := newIntervals(nil).contains(epoch); found != nil {
+		t.Errorf("invalid empty index lookup: %v", found)
+	}
+}
@@ -0,0 +1,77 @@
+package metadb
+
+import (
+	"io/fs"
+	"sync"
+
+	"github.com/GeoNet/delta/meta"
+)
+
+type marks struct {
+	list     meta.MarkList
+	lookup   map[string]meta.Mark
+	networks map[string][]meta.Mark
+	err      error
+	once     sync.Once
+}
+
+func (m *marks) loadMarks(fsys fs.FS) error {
+	m.once.Do(func() {
+		if m.err = meta.LoadListFS(fsys, "network/marks.csv", &m.list); m.err == nil {
+			lookup := make(map[string]meta.Mark)
+			for _, v := range m.list {
+				lookup[v.Code] = v
+			}
+			m.lookup = lookup
+
+			networks := make(map[string][]meta.Mark)
+			for _, v := range m.list {
+				if _, ok := networks[v.Network]; !ok {
+					networks[v.Network] = []meta.Mark{}
+				}
+				networks[v.Network] = append(networks[v.Network], v)
+			}
+			m.networks = networks
+		}
+	})
+
+	return m.err
+}
+
+// Marks returns all the known GNSS marks.
+func (m *MetaDB) Marks() ([]meta.Mark, error) {
+
+	if err := m.loadMarks(m.fsys); err != nil {
+		return nil, err
+	}
+
+	return m.marks.list, nil
+}
+
+// Mark returns the GNSS mark with the given code.
+func (m *MetaDB) Mark(code string) (*meta.Mark, error) {
+
+	if err := m.loadMarks(m.fsys); err != nil {
+		return nil, err
+	}
+
+	if s, ok := m.marks.lookup[code]; ok {
+		return &s, nil
+	}
+
+	return nil, nil
+}
+
+// NetworkMarks returns the GNSS marks that belong to the given network.
+func (m *MetaDB) NetworkMarks(code string) ([]meta.Mark, error) {
+
+	if err := m.loadMarks(m.fsys); err != nil {
+		return nil, err
+	}
+
+	if s, ok := m.marks.networks[code]; ok {
+		return s, nil
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,86 @@
+package metadb
+
+import (
+	"io/fs"
+	"os"
+)
+
+// MetaDB provides cached lookups into the delta meta data files.
+type MetaDB struct {
+	// network details
+	networks
+	stations
+	sites
+	gauges
+	constituents
+
+	// gnss details
+	marks
+	monuments
+	sessions
+	firmware
+	antennas
+	receivers
+	radomes
+	metsensors
+
+	// instrument details
+	sensors
+	recorders
+	dataloggers
+	gains
+
+	// instrment configuration
+	connections
+	streams
+
+	// file system holding the raw meta files
+	fsys fs.FS
+}
+
+// NewMetaDB returns a MetaDB which reads the raw meta files found below the given base directory.
+func NewMetaDB(base string) *MetaDB {
+	return NewMetaDBFS(os.DirFS(base))
+}
+
+// NewMetaDBFS returns a MetaDB which reads the raw meta files from the given file system, the
+// files are expected to be laid out as in the delta repository, e.g. "network/stations.csv".
+func NewMetaDBFS(fsys fs.FS) *MetaDB {
+	return &MetaDB{
+		fsys: fsys,
+	}
+}
+
+// Load reads all the meta files, rather than waiting until they are first needed, and returns the
+// first error found. Errors are cached, later lookups will return the same error.
+func (m *MetaDB) Load() error {
+	loaders := []func(fs.FS) error{
+		m.loadNetworks,
+		m.loadStations,
+		m.loadSites,
+		m.loadGauges,
+		m.loadConstituents,
+		m.loadMarks,
+		m.loadMonuments,
+		m.loadSessions,
+		m.loadFirmwareHistory,
+		m.loadInstalledAntennas,
+		m.loadDeployedReceivers,
+		m.loadInstalledRadomes,
+		m.loadInstalledMetSensors,
+		m.loadInstalledSensors,
+		m.loadInstalledRecorders,
+		m.loadDeployedDataloggers,
+		m.loadDataloggerGains,
+		m.loadConnections,
+		m.loadStreams,
+	}
+
+	for _, load := range loaders {
+		if err := load(m.fsys); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,244 @@
+package metadb_test
+
+import (
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/GeoNet/delta/metadb"
+)
+
+func testFS() fstest.MapFS {
+	return fstest.MapFS{
+		"network/networks.csv": &fstest.MapFile{Data: []byte(
+			"Network,External,Description,Restricted\nNZ,NZ,New Zealand National Seismograph Network,false\n")},
+		"network/stations.csv": &fstest.MapFile{Data: []byte(
+			"Station,Network,Name,Latitude,Longitude,Elevation,Datum,Start Date,End Date\n" +
+				"WEL,NZ,Wellington,-41.28,174.76,138,WGS84,1990-01-01T00:00:00Z,9999-01-01T00:00:00Z\n")},
+		"network/sites.csv": &fstest.MapFile{Data: []byte(
+			"Station,Location,Latitude,Longitude,Elevation,Datum,Survey,Start Date,End Date\n" +
+				"WEL,10,-41.28,174.76,138,WGS84,GPS,1990-01-01T00:00:00Z,9999-01-01T00:00:00Z\n")},
+		"network/marks.csv": &fstest.MapFile{Data: []byte(
+			"Mark,Network,Igs,Name,Latitude,Longitude,Elevation,Datum,Start Date,End Date\n" +
+				"WGTN,LI,yes,Wellington,-41.32,174.80,26,NZGD2000,1996-01-01T00:00:00Z,9999-01-01T00:00:00Z\n")},
+		"install/sensors.csv": &fstest.MapFile{Data: []byte(
+			"Make,Model,Serial,Station,Location,Azimuth,Dip,Depth,North,East,Scale Factor,Scale Bias,Start Date,End Date\n" +
+				"Guralp,CMG-3ESP,T3A40,WEL,10,0,0,0,0,0,1,0,2000-01-01T00:00:00Z,2010-01-01T00:00:00Z\n")},
+		"install/recorders.csv": &fstest.MapFile{Data: []byte(
+			"Make,Sensor,Datalogger,Serial,Station,Location,Azimuth,Dip,Depth,Start Date,End Date\n")},
+		"install/connections.csv": &fstest.MapFile{Data: []byte(
+			"Station,Location,Place,Role,Start Date,End Date\n" +
+				"WEL,10,Wellington,,1990-01-01T00:00:00Z,9999-01-01T00:00:00Z\n")},
+		"install/sessions.csv": &fstest.MapFile{Data: []byte(
+			"Mark,Operator,Agency,Model,Satellite System,Interval,Elevation Mask,Header Comment,Format,Start Date,End Date\n" +
+				"WGTN,GeoNet,GNS,TRIMBLE NETR9,GPS,30s,0,geonet,trimble_netr9,2010-01-01T00:00:00Z,9999-01-01T00:00:00Z\n")},
+		"install/firmware.csv": &fstest.MapFile{Data: []byte(
+			"Make,Model,Serial,Version,Start Date,End Date,Notes\n" +
+				"Trimble Navigation Ltd.,TRIMBLE NETR9,5034K69675,4.70,2010-01-01T00:00:00Z,2015-01-01T00:00:00Z,\n" +
+				"Trimble Navigation Ltd.,TRIMBLE NETR9,5034K69675,4.85,2015-01-01T00:00:00Z,9999-01-01T00:00:00Z,\n")},
+		"install/antennas.csv": &fstest.MapFile{Data: []byte(
+			"Make,Model,Serial,Mark,Height,North,East,Azimuth,Start Date,End Date\n" +
+				"Trimble Navigation Ltd.,TRM57971.00,1441112501,WGTN,0.055,0,0,0,2010-01-01T00:00:00Z,2015-01-01T00:00:00Z\n" +
+				"Trimble Navigation Ltd.,TRM59800.00,5000118381,WGTN,0.055,0,0,0,2015-01-01T00:00:00Z,9999-01-01T00:00:00Z\n")},
+		"install/receivers.csv": &fstest.MapFile{Data: []byte(
+			"Make,Model,Serial,Mark,Start Date,End Date\n" +
+				"Trimble Navigation Ltd.,TRIMBLE NETR9,5034K69675,WGTN,2010-01-01T00:00:00Z,9999-01-01T00:00:00Z\n")},
+		"install/dataloggers.csv": &fstest.MapFile{Data: []byte(
+			"Make,Model,Serial,Place,Role,Start Date,End Date\n" +
+				"Quanterra,Q330/3,1234,Wellington,,2005-01-01T00:00:00Z,9999-01-01T00:00:00Z\n")},
+	}
+}
+
+func TestMetaDB(t *testing.T) {
+
+	db := metadb.NewMetaDBFS(testFS())
+
+	station, err := db.Station("WEL")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if station == nil || station.Network != "NZ" {
+		t.Fatalf("invalid station: %v", station)
+	}
+
+	mark, err := db.Mark("WGTN")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mark == nil || !mark.Igs {
+		t.Fatalf("invalid mark: %v", mark)
+	}
+
+	installations, err := db.Installations("WEL")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(installations) != 1 {
+		t.Fatalf("invalid number of installations: got %d, expected %d", len(installations), 1)
+	}
+	if s := installations[0].Start.Format("2006"); s != "2005" {
+		t.Errorf("invalid installation start: got %s, expected %s", s, "2005")
+	}
+	if s := installations[0].End.Format("2006"); s != "2010" {
+		t.Errorf("invalid installation end: got %s, expected %s", s, "2010")
+	}
+
+	t.Log("Check datalogger gain settings")
+	{
+		fsys := testFS()
+		fsys["install/gains.csv"] = &fstest.MapFile{Data: []byte(
+			"Make,Model,Serial,Gain,Bit Weight,Start Date,End Date,Notes\n" +
+				"Quanterra,Q330/3,1234,20,,2007-01-01T00:00:00Z,2008-01-01T00:00:00Z,\n")}
+
+		installations, err := metadb.NewMetaDBFS(fsys).Installations("WEL")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(installations) != 3 {
+			t.Fatalf("invalid number of gain installations: got %d, expected %d", len(installations), 3)
+		}
+		for n, v := range installations {
+			if (v.Gain != nil) != (n == 1) {
+				t.Errorf("invalid installation gain %d: %v", n, v.Gain)
+			}
+		}
+		if s := installations[1].Start.Format("2006") + "-" + installations[1].End.Format("2006"); s != "2007-2008" {
+			t.Errorf("invalid gain installation span: got %s, expected %s", s, "2007-2008")
+		}
+
+		fsys["install/gains.csv"] = &fstest.MapFile{Data: []byte(
+			"Make,Model,Serial,Gain,Bit Weight,Start Date,End Date,Notes\n" +
+				"Quanterra,Q330/3,1234,20,,2007-01-01T00:00:00Z,2008-01-01T00:00:00Z,\n" +
+				"Quanterra,Q330/3,1234,10,,2007-06-01T00:00:00Z,2009-01-01T00:00:00Z,\n")}
+
+		if _, err := metadb.NewMetaDBFS(fsys).Installations("WEL"); err == nil {
+			t.Error("expected overlapping gains error")
+		}
+	}
+
+	if _, err := metadb.NewMetaDB("testdata/missing").Stations(); err == nil {
+		t.Error("expected missing directory error")
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+
+	db := metadb.NewMetaDBFS(testFS())
+
+	at := func(s string) time.Time {
+		v, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v
+	}
+
+	t.Log("Check snapshot before the datalogger was deployed")
+	{
+		snapshot := db.At(at("2001-01-01T00:00:00Z"))
+
+		sensors, err := snapshot.InstalledSensors("WEL")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(sensors) != 1 {
+			t.Errorf("invalid number of sensors: got %d, expected %d", len(sensors), 1)
+		}
+		installations, err := snapshot.Installations("WEL")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(installations) != 0 {
+			t.Errorf("invalid number of installations: got %d, expected %d", len(installations), 0)
+		}
+	}
+
+	t.Log("Check snapshot during an installation")
+	{
+		snapshot := db.At(at("2016-11-13T11:02:00Z"))
+
+		station, err := snapshot.Station("WEL")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if station == nil {
+			t.Error("expected an operational station")
+		}
+		sensors, err := snapshot.InstalledSensors("WEL")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(sensors) != 0 {
+			t.Errorf("invalid number of sensors: got %d, expected %d", len(sensors), 0)
+		}
+		sessions, err := snapshot.Sessions("WGTN")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(sessions) != 1 {
+			t.Errorf("invalid number of sessions: got %d, expected %d", len(sessions), 1)
+		}
+		firmware, err := snapshot.Firmware("TRIMBLE NETR9", "5034K69675")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if firmware == nil || firmware.Version != "4.85" {
+			t.Errorf("invalid firmware: %v", firmware)
+		}
+	}
+
+	t.Log("Check snapshot before the station opened")
+	{
+		station, err := db.At(at("1980-01-01T00:00:00Z")).Station("WEL")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if station != nil {
+			t.Errorf("unexpected station: %v", station)
+		}
+	}
+}
+
+func TestGNSS(t *testing.T) {
+
+	db := metadb.NewMetaDBFS(testFS())
+
+	at := time.Date(2016, time.November, 13, 11, 2, 0, 0, time.UTC)
+
+	antennas, err := db.MarkInstalledAntennas("WGTN")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(antennas) != 2 {
+		t.Fatalf("invalid number of antennas: got %d, expected %d", len(antennas), 2)
+	}
+
+	antenna, err := db.MarkInstalledAntennaAt("WGTN", at)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if antenna == nil || antenna.Model != "TRM59800.00" {
+		t.Errorf("invalid antenna: %v", antenna)
+	}
+
+	receiver, err := db.MarkDeployedReceiverAt("WGTN", at)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if receiver == nil {
+		t.Fatal("expected a deployed receiver")
+	}
+
+	firmware, err := db.ReceiverFirmwareAt(receiver.Model, receiver.Serial, at)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if firmware == nil || firmware.Version != "4.85" {
+		t.Errorf("invalid firmware: %v", firmware)
+	}
+
+	radome, err := db.MarkInstalledRadomeAt("WGTN", at)
+	if err == nil {
+		t.Errorf("expected missing radome file error: %v", radome)
+	}
+}
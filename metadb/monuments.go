@@ -0,0 +1,53 @@
+package metadb
+
+import (
+	"io/fs"
+	"sync"
+
+	"github.com/GeoNet/delta/meta"
+)
+
+type monuments struct {
+	list   meta.MonumentList
+	lookup map[string]meta.Monument
+	err    error
+	once   sync.Once
+}
+
+func (m *monuments) loadMonuments(fsys fs.FS) error {
+	m.once.Do(func() {
+		if m.err = meta.LoadListFS(fsys, "network/monuments.csv", &m.list); m.err == nil {
+			lookup := make(map[string]meta.Monument)
+			for _, v := range m.list {
+				lookup[v.Mark] = v
+			}
+			m.lookup = lookup
+		}
+	})
+
+	return m.err
+}
+
+// Monuments returns all the known GNSS mark monuments.
+func (m *MetaDB) Monuments() ([]meta.Monument, error) {
+
+	if err := m.loadMonuments(m.fsys); err != nil {
+		return nil, err
+	}
+
+	return m.monuments.list, nil
+}
+
+// Monument returns the monument details of the given GNSS mark.
+func (m *MetaDB) Monument(mark string) (*meta.Monument, error) {
+
+	if err := m.loadMonuments(m.fsys); err != nil {
+		return nil, err
+	}
+
+	if s, ok := m.monuments.lookup[mark]; ok {
+		return &s, nil
+	}
+
+	return nil, nil
+}
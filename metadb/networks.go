@@ -1,7 +1,7 @@
 package metadb
 
 import (
-	"path/filepath"
+	"io/fs"
 	"sync"
 
 	"github.com/GeoNet/delta/meta"
@@ -10,14 +10,13 @@ import (
 type networks struct {
 	list   meta.NetworkList
 	lookup map[string]meta.Network
+	err    error
 	once   sync.Once
 }
 
-func (n *networks) loadNetworks(base string) error {
-	var err error
-
+func (n *networks) loadNetworks(fsys fs.FS) error {
 	n.once.Do(func() {
-		if err = meta.LoadList(filepath.Join(base, "network", "networks.csv"), &n.list); err == nil {
+		if n.err = meta.LoadListFS(fsys, "network/networks.csv", &n.list); n.err == nil {
 			lookup := make(map[string]meta.Network)
 			for _, v := range n.list {
 				lookup[v.Code] = v
@@ -26,12 +25,13 @@ func (n *networks) loadNetworks(base string) error {
 		}
 	})
 
-	return err
+	return n.err
 }
 
+// Network returns the network with the given code.
 func (m *MetaDB) Network(code string) (*meta.Network, error) {
 
-	if err := m.loadNetworks(m.base); err != nil {
+	if err := m.loadNetworks(m.fsys); err != nil {
 		return nil, err
 	}
 
@@ -1,7 +1,7 @@
 package metadb
 
 import (
-	"path/filepath"
+	"io/fs"
 	"sync"
 
 	"github.com/GeoNet/delta/meta"
@@ -10,14 +10,13 @@ import (
 type recorders struct {
 	list     meta.InstalledRecorderList
 	stations map[string][]meta.InstalledRecorder
+	err      error
 	once     sync.Once
 }
 
-func (r *recorders) loadInstalledRecorders(base string) error {
-	var err error
-
+func (r *recorders) loadInstalledRecorders(fsys fs.FS) error {
 	r.once.Do(func() {
-		if err = meta.LoadList(filepath.Join(base, "install", "recorders.csv"), &r.list); err == nil {
+		if r.err = meta.LoadListFS(fsys, "install/recorders.csv", &r.list); r.err == nil {
 			stations := make(map[string][]meta.InstalledRecorder)
 			for _, v := range r.list {
 				if _, ok := stations[v.Station]; !ok {
@@ -29,11 +28,12 @@ func (r *recorders) loadInstalledRecorders(base string) error {
 		}
 	})
 
-	return err
+	return r.err
 }
 
+// StationInstalledRecorders returns the recorders installed at the given station.
 func (m *MetaDB) StationInstalledRecorders(sta string) ([]meta.InstalledRecorder, error) {
-	if err := m.loadInstalledRecorders(m.base); err != nil {
+	if err := m.loadInstalledRecorders(m.fsys); err != nil {
 		return nil, err
 	}
 
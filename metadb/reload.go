@@ -0,0 +1,135 @@
+package metadb
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"sync"
+	"time"
+)
+
+// Reloader manages a MetaDB which can be refreshed while a process is running. Each reload builds and
+// fully loads a new MetaDB before replacing the current one, callers holding a previous MetaDB will
+// continue to see a consistent view of the data that was loaded at the time.
+type Reloader struct {
+	fsys fs.FS
+
+	mu  sync.RWMutex
+	db  *MetaDB
+	sum string
+}
+
+// NewReloader returns a Reloader for the meta files found below the given base directory.
+func NewReloader(base string) (*Reloader, error) {
+	return NewReloaderFS(os.DirFS(base))
+}
+
+// NewReloaderFS returns a Reloader for the meta files held in the given file system, an error
+// is returned if the initial load fails.
+func NewReloaderFS(fsys fs.FS) (*Reloader, error) {
+	r := Reloader{
+		fsys: fsys,
+	}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// MetaDB returns the most recently loaded MetaDB.
+func (r *Reloader) MetaDB() *MetaDB {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.db
+}
+
+// Reload reads all the meta files into a new MetaDB, the current MetaDB is only replaced if the
+// load succeeds.
+func (r *Reloader) Reload() error {
+	sum, err := checksum(r.fsys)
+	if err != nil {
+		return err
+	}
+
+	db := NewMetaDBFS(r.fsys)
+	if err := db.Load(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.db, r.sum = db, sum
+
+	return nil
+}
+
+// Watch checks the meta files for changes at the given interval until the context is cancelled.
+// A reload is only attempted once the files have stopped changing for at least one interval,
+// which avoids loading a partially updated set of files. Any reload errors are passed to the
+// report function if it is not nil.
+func (r *Reloader) Watch(ctx context.Context, interval time.Duration, report func(error)) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var last string
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		sum, err := checksum(r.fsys)
+		switch {
+		case err != nil:
+			if report != nil {
+				report(err)
+			}
+		case sum != last:
+			last = sum
+		case sum != r.current():
+			if err := r.Reload(); err != nil && report != nil {
+				report(err)
+			}
+		}
+	}
+}
+
+func (r *Reloader) current() string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.sum
+}
+
+// checksum summarises the names, sizes and modification times of the meta files.
+func checksum(fsys fs.FS) (string, error) {
+	h := sha256.New()
+
+	for _, dir := range []string{"network", "install", "environment"} {
+		err := fs.WalkDir(fsys, dir, func(p string, d fs.DirEntry, err error) error {
+			switch {
+			case err != nil:
+				return err
+			case d.IsDir(), path.Ext(p) != ".csv":
+				return nil
+			}
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(h, "%s %d %d\n", p, info.Size(), info.ModTime().UnixNano())
+			return nil
+		})
+		if err != nil && !os.IsNotExist(err) {
+			return "", err
+		}
+	}
+
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
+}
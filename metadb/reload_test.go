@@ -0,0 +1,83 @@
+package metadb_test
+
+import (
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/GeoNet/delta/metadb"
+)
+
+func TestReloader(t *testing.T) {
+
+	fsys := testFS()
+	for k, v := range map[string]string{
+		"network/monuments.csv":        "Mark,Domes Number,Mark Type,Type,Ground Relationship,Foundation Type,Foundation Depth,Start Date,End Date\n",
+		"install/radomes.csv":          "Make,Model,Serial,Mark,Start Date,End Date\n",
+		"install/metsensors.csv":       "Make,Model,Serial,Mark,IMS Comment,Humidity,Pressure,Temperature,Latitude,Longitude,Elevation,Datum,Start Date,End Date\n",
+		"install/streams.csv":          "Station,Location,Sampling Rate,Axial,Reversed,Triggered,Start Date,End Date\n",
+		"environment/gauges.csv":       "Gauge,Network,LINZ Number,Analysis Time Zone,Analysis Latitude,Analysis Longitude,Crex Tag\n",
+		"environment/constituents.csv": "Gauge,Number,Constituent,Amplitude,Lag\n",
+	} {
+		fsys[k] = &fstest.MapFile{Data: []byte(v)}
+	}
+
+	reloader, err := metadb.NewReloaderFS(fsys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	original := reloader.MetaDB()
+
+	t.Log("Check a successful reload")
+	{
+		fsys["network/stations.csv"] = &fstest.MapFile{
+			Data: []byte("Station,Network,Name,Latitude,Longitude,Elevation,Datum,Start Date,End Date\n" +
+				"WEL,NZ,Wellington,-41.28,174.76,138,WGS84,1990-01-01T00:00:00Z,9999-01-01T00:00:00Z\n" +
+				"SNZO,NZ,South Karori,-41.31,174.70,120,WGS84,1992-01-01T00:00:00Z,9999-01-01T00:00:00Z\n"),
+			ModTime: time.Now(),
+		}
+		if err := reloader.Reload(); err != nil {
+			t.Fatal(err)
+		}
+
+		stations, err := reloader.MetaDB().Stations()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(stations) != 2 {
+			t.Errorf("invalid number of reloaded stations: got %d, expected %d", len(stations), 2)
+		}
+		if stations, err := original.Stations(); err != nil || len(stations) != 1 {
+			t.Errorf("original stations should be unchanged: %v %v", stations, err)
+		}
+	}
+
+	t.Log("Check a failed reload")
+	{
+		current := reloader.MetaDB()
+
+		fsys["network/sites.csv"] = &fstest.MapFile{
+			Data:    []byte("Station,Location,Latitude,Longitude,Elevation,Datum,Survey,Start Date,End Date\nWEL,10,bad\n"),
+			ModTime: time.Now(),
+		}
+		if err := reloader.Reload(); err == nil {
+			t.Fatal("expected reload error")
+		}
+		if reloader.MetaDB() != current {
+			t.Error("a failed reload should keep the current data")
+		}
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+
+	db := metadb.NewMetaDB("testdata/missing")
+	for i := 0; i < 2; i++ {
+		if _, err := db.Stations(); err == nil {
+			t.Errorf("expected cached missing file error on call %d", i+1)
+		}
+	}
+	if err := db.Load(); err == nil {
+		t.Error("expected load error")
+	}
+}
@@ -1,7 +1,7 @@
 package metadb
 
 import (
-	"path/filepath"
+	"io/fs"
 	"sync"
 
 	"github.com/GeoNet/delta/meta"
@@ -11,14 +11,13 @@ type sensors struct {
 	list      meta.InstalledSensorList
 	stations  map[string][]meta.InstalledSensor
 	locations map[string]map[string][]meta.InstalledSensor
+	err       error
 	once      sync.Once
 }
 
-func (s *sensors) loadInstalledSensors(base string) error {
-	var err error
-
+func (s *sensors) loadInstalledSensors(fsys fs.FS) error {
 	s.once.Do(func() {
-		if err = meta.LoadList(filepath.Join(base, "install", "sensors.csv"), &s.list); err == nil {
+		if s.err = meta.LoadListFS(fsys, "install/sensors.csv", &s.list); s.err == nil {
 			stations := make(map[string][]meta.InstalledSensor)
 			for _, v := range s.list {
 				if _, ok := stations[v.Station]; !ok {
@@ -42,11 +41,12 @@ func (s *sensors) loadInstalledSensors(base string) error {
 		}
 	})
 
-	return err
+	return s.err
 }
 
+// StationInstalledSensors returns the sensors installed at the given station.
 func (m *MetaDB) StationInstalledSensors(sta string) ([]meta.InstalledSensor, error) {
-	if err := m.loadInstalledSensors(m.base); err != nil {
+	if err := m.loadInstalledSensors(m.fsys); err != nil {
 		return nil, err
 	}
 
@@ -57,8 +57,9 @@ func (m *MetaDB) StationInstalledSensors(sta string) ([]meta.InstalledSensor, er
 	return nil, nil
 }
 
+// StationLocationInstalledSensors returns the sensors installed at the given station location.
 func (m *MetaDB) StationLocationInstalledSensors(sta, loc string) ([]meta.InstalledSensor, error) {
-	if err := m.loadInstalledSensors(m.base); err != nil {
+	if err := m.loadInstalledSensors(m.fsys); err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,45 @@
+package metadb
+
+import (
+	"io/fs"
+	"sync"
+
+	"github.com/GeoNet/delta/meta"
+)
+
+type sessions struct {
+	list  meta.SessionList
+	marks map[string][]meta.Session
+	err   error
+	once  sync.Once
+}
+
+func (s *sessions) loadSessions(fsys fs.FS) error {
+	s.once.Do(func() {
+		if s.err = meta.LoadListFS(fsys, "install/sessions.csv", &s.list); s.err == nil {
+			marks := make(map[string][]meta.Session)
+			for _, v := range s.list {
+				if _, ok := marks[v.Mark]; !ok {
+					marks[v.Mark] = []meta.Session{}
+				}
+				marks[v.Mark] = append(marks[v.Mark], v)
+			}
+			s.marks = marks
+		}
+	})
+
+	return s.err
+}
+
+// MarkSessions returns the GNSS recording sessions of the given mark.
+func (m *MetaDB) MarkSessions(mark string) ([]meta.Session, error) {
+	if err := m.loadSessions(m.fsys); err != nil {
+		return nil, err
+	}
+
+	if s, ok := m.sessions.marks[mark]; ok {
+		return s, nil
+	}
+
+	return nil, nil
+}
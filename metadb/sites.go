@@ -1,7 +1,7 @@
 package metadb
 
 import (
-	"path/filepath"
+	"io/fs"
 	"sync"
 
 	"github.com/GeoNet/delta/meta"
@@ -11,14 +11,13 @@ type sites struct {
 	list      meta.SiteList
 	stations  map[string][]meta.Site
 	locations map[string]map[string]meta.Site
+	err       error
 	once      sync.Once
 }
 
-func (s *sites) loadSites(base string) error {
-	var err error
-
+func (s *sites) loadSites(fsys fs.FS) error {
 	s.once.Do(func() {
-		if err = meta.LoadList(filepath.Join(base, "network", "sites.csv"), &s.list); err == nil {
+		if s.err = meta.LoadListFS(fsys, "network/sites.csv", &s.list); s.err == nil {
 			stations := make(map[string][]meta.Site)
 			for _, v := range s.list {
 				if _, ok := stations[v.Station]; !ok {
@@ -39,11 +38,12 @@ func (s *sites) loadSites(base string) error {
 		}
 	})
 
-	return err
+	return s.err
 }
 
+// Sites returns the sites of the given station.
 func (m *MetaDB) Sites(sta string) ([]meta.Site, error) {
-	if err := m.loadSites(m.base); err != nil {
+	if err := m.loadSites(m.fsys); err != nil {
 		return nil, err
 	}
 
@@ -54,8 +54,9 @@ func (m *MetaDB) Sites(sta string) ([]meta.Site, error) {
 	return nil, nil
 }
 
+// Site returns the site at the given station location.
 func (m *MetaDB) Site(sta, loc string) (*meta.Site, error) {
-	if err := m.loadSites(m.base); err != nil {
+	if err := m.loadSites(m.fsys); err != nil {
 		return nil, err
 	}
 
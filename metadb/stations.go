@@ -1,7 +1,7 @@
 package metadb
 
 import (
-	"path/filepath"
+	"io/fs"
 	"sync"
 
 	"github.com/GeoNet/delta/meta"
@@ -11,14 +11,13 @@ type stations struct {
 	list     meta.StationList
 	lookup   map[string]meta.Station
 	networks map[string][]meta.Station
+	err      error
 	once     sync.Once
 }
 
-func (s *stations) loadStations(base string) error {
-	var err error
-
+func (s *stations) loadStations(fsys fs.FS) error {
 	s.once.Do(func() {
-		if err = meta.LoadList(filepath.Join(base, "network", "stations.csv"), &s.list); err == nil {
+		if s.err = meta.LoadListFS(fsys, "network/stations.csv", &s.list); s.err == nil {
 			lookup := make(map[string]meta.Station)
 			for _, v := range s.list {
 				lookup[v.Code] = v
@@ -36,21 +35,23 @@ func (s *stations) loadStations(base string) error {
 		}
 	})
 
-	return err
+	return s.err
 }
 
+// Stations returns all the known stations.
 func (m *MetaDB) Stations() ([]meta.Station, error) {
 
-	if err := m.loadStations(m.base); err != nil {
+	if err := m.loadStations(m.fsys); err != nil {
 		return nil, err
 	}
 
 	return m.stations.list, nil
 }
 
+// Station returns the station with the given code.
 func (m *MetaDB) Station(code string) (*meta.Station, error) {
 
-	if err := m.loadStations(m.base); err != nil {
+	if err := m.loadStations(m.fsys); err != nil {
 		return nil, err
 	}
 
@@ -61,9 +62,10 @@ func (m *MetaDB) Station(code string) (*meta.Station, error) {
 	return nil, nil
 }
 
+// NetworkStation returns the stations that belong to the given network.
 func (m *MetaDB) NetworkStation(code string) ([]meta.Station, error) {
 
-	if err := m.loadStations(m.base); err != nil {
+	if err := m.loadStations(m.fsys); err != nil {
 		return nil, err
 	}
 
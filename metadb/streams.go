@@ -0,0 +1,85 @@
+package metadb
+
+import (
+	"io/fs"
+	"sync"
+	"time"
+
+	"github.com/GeoNet/delta/meta"
+)
+
+type streams struct {
+	list      meta.StreamList
+	locations map[string]map[string][]meta.Stream
+	spans     map[string]map[string]*intervals
+	err       error
+	once      sync.Once
+}
+
+func (s *streams) loadStreams(fsys fs.FS) error {
+	s.once.Do(func() {
+		if s.err = meta.LoadListFS(fsys, "install/streams.csv", &s.list); s.err == nil {
+			locations := make(map[string]map[string][]meta.Stream)
+			for _, v := range s.list {
+				if _, ok := locations[v.Station]; !ok {
+					locations[v.Station] = make(map[string][]meta.Stream)
+				}
+				if _, ok := locations[v.Station][v.Location]; !ok {
+					locations[v.Station][v.Location] = []meta.Stream{}
+				}
+				locations[v.Station][v.Location] = append(locations[v.Station][v.Location], v)
+			}
+			s.locations = locations
+
+			spans := make(map[string]map[string]*intervals)
+			for sta, l := range locations {
+				spans[sta] = make(map[string]*intervals)
+				for loc, v := range l {
+					var list []meta.Span
+					for _, r := range v {
+						list = append(list, r.Span)
+					}
+					spans[sta][loc] = newIntervals(list)
+				}
+			}
+			s.spans = spans
+		}
+	})
+
+	return s.err
+}
+
+// StationLocationSamplingRateStartStream returns the stream at the given station location with the
+// given sampling rate which was operational at the start time.
+func (m *MetaDB) StationLocationSamplingRateStartStream(sta, loc string, rate float64, start time.Time) (*meta.Stream, error) {
+	if err := m.loadStreams(m.fsys); err != nil {
+		return nil, err
+	}
+
+	if s, ok := m.streams.locations[sta]; ok {
+		if l, ok := s[loc]; ok {
+			for _, n := range m.streams.spans[sta][loc].contains(start) {
+				if r := l[n]; r.SamplingRate == rate {
+					return &r, nil
+				}
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// StationLocationStreams returns the recording streams configured at the given station location.
+func (m *MetaDB) StationLocationStreams(sta, loc string) ([]meta.Stream, error) {
+	if err := m.loadStreams(m.fsys); err != nil {
+		return nil, err
+	}
+
+	if s, ok := m.streams.locations[sta]; ok {
+		if l, ok := s[loc]; ok {
+			return l, nil
+		}
+	}
+
+	return nil, nil
+}
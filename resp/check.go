@@ -0,0 +1,191 @@
+package resp
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"strings"
+)
+
+// Problem describes an inconsistency found when checking a response configuration.
+type Problem struct {
+	Response   string
+	Datalogger string
+	Sensor     string
+	Label      string
+	Stage      int
+	Message    string
+}
+
+func (p Problem) String() string {
+	var parts []string
+	parts = append(parts, fmt.Sprintf("%s: %s/%s", p.Response, p.Datalogger, p.Sensor))
+	if p.Label != "" {
+		parts = append(parts, p.Label)
+	}
+	if p.Stage > 0 {
+		parts = append(parts, fmt.Sprintf("stage %d", p.Stage))
+	}
+	parts = append(parts, p.Message)
+
+	return strings.Join(parts, ": ")
+}
+
+// nominal returns the configured gain of a stage, using the same defaults as ResponseStage.Response.
+func nominal(stage ResponseStage) float64 {
+	switch s := stage.StageSet.(type) {
+	case FIR:
+		if s.Gain != 0.0 {
+			return s.Gain
+		}
+	case Polynomial:
+		if s.Gain != 0.0 {
+			return s.Gain
+		}
+	}
+	if stage.Gain != 0.0 {
+		return stage.Gain
+	}
+	return 1.0
+}
+
+// normalisation returns the unscaled amplitude of the poles and zeros of a stage at the stage frequency.
+func normalisation(stage ResponseStage, paz PAZ) float64 {
+	return cmplx.Abs(paz.Response(stage.Frequency, stage.SampleRate))
+}
+
+// actual returns the gain of a stage at the given frequency as derived from the poles and zeros, or
+// the FIR filter coefficients, rather than from the configured stage gain. Poles and zeros are scaled
+// directly by their amplitude at the stage frequency, a NaN is returned if this is zero or infinite.
+func actual(stage ResponseStage, freq float64) float64 {
+	switch s := stage.StageSet.(type) {
+	case PAZ:
+		norm := normalisation(stage, s)
+		if !(norm > 0.0) || math.IsInf(norm, 0) {
+			return math.NaN()
+		}
+		return nominal(stage) * cmplx.Abs(s.Response(freq, stage.SampleRate)) / norm
+	case FIR:
+		return cmplx.Abs(s.Response(freq, stage.InputSampleRate()))
+	default:
+		return nominal(stage)
+	}
+}
+
+// unitAliases maps alternative names for units, in upper case, onto a common name.
+var unitAliases = map[string]string{
+	"COUNTS": "COUNT",
+	"VOLT":   "V",
+	"VOLTS":  "V",
+	"M/S/S":  "M/S**2",
+	"M/S2":   "M/S**2",
+	"M/S^2":  "M/S**2",
+}
+
+// sameUnits returns whether two unit names refer to the same units, ignoring case and known aliases.
+func sameUnits(a, b string) bool {
+	unit := func(s string) string {
+		s = strings.ToUpper(strings.TrimSpace(s))
+		if u, ok := unitAliases[s]; ok {
+			return u
+		}
+		return s
+	}
+	return unit(a) == unit(b)
+}
+
+// mismatch returns the relative difference between two values.
+func mismatch(value, expected float64) float64 {
+	if expected == 0.0 {
+		return math.Abs(value)
+	}
+	return math.Abs(value-expected) / math.Abs(expected)
+}
+
+// CheckStream checks the stages of a stream for inconsistent gains and units, the tolerance is
+// the allowed relative difference in gains.
+func CheckStream(stream Stream, tolerance float64) []Problem {
+	var problems []Problem
+
+	report := func(stage int, format string, args ...interface{}) {
+		problems = append(problems, Problem{
+			Datalogger: strings.Join(stream.Datalogger.DataloggerList, ","),
+			Sensor:     strings.Join(stream.Sensor.SensorList, ","),
+			Label:      stream.Datalogger.Label,
+			Stage:      stage,
+			Message:    fmt.Sprintf(format, args...),
+		})
+	}
+
+	freq := stream.Datalogger.Frequency
+
+	var stages []ResponseStage
+	for _, s := range append(append([]ResponseStage{}, stream.Sensor.Stages...), stream.Datalogger.Stages...) {
+		if s.StageSet != nil {
+			stages = append(stages, s)
+		}
+	}
+
+	expected, found := 1.0, 1.0
+	for i, stage := range stages {
+		n := i + 1
+
+		if i > 0 {
+			if in, out := stage.InputUnits, stages[i-1].OutputUnits; !sameUnits(in, out) {
+				report(n, "input units %q do not match the previous output units %q", in, out)
+			}
+		}
+
+		switch s := stage.StageSet.(type) {
+		case PAZ:
+			if norm := normalisation(stage, s); !(norm > 0.0) || math.IsInf(norm, 0) {
+				report(n, "paz %s cannot be normalised at %g Hz", s.Name, stage.Frequency)
+				break
+			}
+			g := actual(stage, freq)
+			if mismatch(g, nominal(stage)) > tolerance {
+				report(n, "paz %s gain at %g Hz is %g, expected %g", s.Name, freq, g, nominal(stage))
+			}
+			if h := cmplx.Abs(stage.Response(freq)); mismatch(h, g) > tolerance {
+				report(n, "paz %s response at %g Hz is %g, expected %g", s.Name, freq, h, g)
+			}
+		case FIR:
+			gain := s.Gain
+			if gain == 0.0 {
+				gain = 1.0
+			}
+			if sum := s.Sum(); mismatch(sum, gain) > tolerance {
+				report(n, "fir %s coefficients sum to %g, expected %g", s.Name, sum, gain)
+			}
+		}
+
+		expected *= nominal(stage)
+		found *= actual(stage, freq)
+	}
+
+	// a stage that could not be normalised gives a NaN which would otherwise never mismatch
+	if !(mismatch(found, expected) <= tolerance) {
+		report(0, "sensitivity at %g Hz is %g, expected %g", freq, found, expected)
+	}
+
+	return problems
+}
+
+// Check checks every datalogger and sensor pair of the given responses, the tolerance is the
+// allowed relative difference in gains.
+func Check(responses []Response, tolerance float64) []Problem {
+	var problems []Problem
+
+	for _, response := range responses {
+		for _, datalogger := range response.Dataloggers {
+			for _, sensor := range response.Sensors {
+				for _, p := range CheckStream(Stream{Datalogger: datalogger, Sensor: sensor}, tolerance) {
+					p.Response = response.Name
+					problems = append(problems, p)
+				}
+			}
+		}
+	}
+
+	return problems
+}
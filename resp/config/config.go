@@ -0,0 +1,184 @@
+/*
+Package config decodes the YAML response configuration files found in the resp/responses directory.
+
+The configuration is used to generate the compiled response library in package resp, to load an
+alternative library at run time, and to add new responses via the respimport tool. Keeping the
+layout here means any new configuration field only needs to be added once.
+*/
+package config
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+
+	"gopkg.in/yaml.v2"
+)
+
+// ResponseInfo holds the merged contents of a set of response configuration files.
+type ResponseInfo struct {
+	SensorModel     map[string]SensorModel     `yaml:"sensor-model,omitempty"`
+	DataloggerModel map[string]DataloggerModel `yaml:"datalogger-model,omitempty"`
+	PAZ             map[string]PAZ             `yaml:"paz,omitempty"`
+	Polynomial      map[string]Polynomial      `yaml:"polynomial,omitempty"`
+	FIR             map[string]FIR             `yaml:"fir,omitempty"`
+	Filter          map[string][]ResponseStage `yaml:"filter,omitempty"`
+	SensorGroup     map[string][]Sensor        `yaml:"sensor-group,omitempty"`
+	Profile         map[string][]Datalogger    `yaml:"profile,omitempty"`
+	Response        map[string]Response        `yaml:"response,omitempty"`
+}
+
+func NewResponseInfo() *ResponseInfo {
+	return &ResponseInfo{
+		SensorModel:     make(map[string]SensorModel),
+		DataloggerModel: make(map[string]DataloggerModel),
+		PAZ:             make(map[string]PAZ),
+		Polynomial:      make(map[string]Polynomial),
+		FIR:             make(map[string]FIR),
+		Filter:          make(map[string][]ResponseStage),
+		SensorGroup:     make(map[string][]Sensor),
+		Profile:         make(map[string][]Datalogger),
+		Response:        make(map[string]Response),
+	}
+}
+
+// Merge adds the entries of another configuration, later entries replace earlier ones.
+func (r *ResponseInfo) Merge(info ResponseInfo) {
+	for k, v := range info.SensorModel {
+		r.SensorModel[k] = v
+	}
+	for k, v := range info.DataloggerModel {
+		r.DataloggerModel[k] = v
+	}
+	for k, v := range info.PAZ {
+		r.PAZ[k] = v
+	}
+	for k, v := range info.Polynomial {
+		r.Polynomial[k] = v
+	}
+	for k, v := range info.FIR {
+		r.FIR[k] = v
+	}
+	for k, v := range info.Filter {
+		r.Filter[k] = v
+	}
+	for k, v := range info.SensorGroup {
+		r.SensorGroup[k] = v
+	}
+	for k, v := range info.Profile {
+		r.Profile[k] = v
+	}
+	for k, v := range info.Response {
+		r.Response[k] = v
+	}
+}
+
+// Responses returns the responses with any sensor group or datalogger profile references replaced
+// by the entries they refer to, the final sample rate of each datalogger filter list is checked
+// against the configured datalogger sample rate.
+func (r ResponseInfo) Responses() (map[string]Response, error) {
+	responses := make(map[string]Response)
+	for k, v := range r.Response {
+		res, err := v.expand(r.SensorGroup, r.Profile)
+		if err != nil {
+			return nil, fmt.Errorf("invalid response %q: %v", k, err)
+		}
+		for _, d := range res.Dataloggers {
+			label := fmt.Sprintf("\"%s\" [%s]", k, d.Label)
+
+			// the last sample rate of the filters
+			var rate float64
+			for _, f := range d.Filters {
+				stages, ok := r.Filter[f]
+				if !ok {
+					return nil, fmt.Errorf("invalid filter %s: %s", label, f)
+				}
+				for _, s := range stages {
+					rate = s.SampleRate
+				}
+			}
+			if rate != d.SampleRate {
+				return nil, fmt.Errorf("invalid sample rate %s: found %v, expected %v", label, rate, d.SampleRate)
+			}
+		}
+		responses[k] = res
+	}
+	return responses, nil
+}
+
+// SensorStages returns the stages of a filter as used by a sensor.
+func (r ResponseInfo) SensorStages(filter string) []ResponseStage {
+	return r.Filter[filter]
+}
+
+// DataloggerStages returns the stages of a filter as used by a datalogger, FIR stages take their
+// decimation from the FIR filter and, unless a correction has been given, their delay corrections
+// are derived from the filter length. Other stages have no delay corrections.
+func (r ResponseInfo) DataloggerStages(filter string) []ResponseStage {
+	var stages []ResponseStage
+	for _, s := range r.Filter[filter] {
+		stage := s
+		stage.Correction, stage.Delay = 0.0, 0.0
+		if s.Type == "fir" {
+			if f, ok := r.FIR[s.Lookup]; ok {
+				stage.Decimate = int32(f.Decimation)
+				switch {
+				case s.Correction == 0.0 && f.Decimation > 1.0:
+					stage.Correction = f.Correction(s.SampleRate)
+				default:
+					stage.Correction = s.Correction
+				}
+				stage.Delay = stage.Correction
+			}
+		}
+		stages = append(stages, stage)
+	}
+	return stages
+}
+
+// Decode returns the configuration held in a single YAML document.
+func Decode(data []byte) (ResponseInfo, error) {
+	var info ResponseInfo
+	if err := yaml.Unmarshal(data, &info); err != nil {
+		return ResponseInfo{}, err
+	}
+	return info, nil
+}
+
+// LoadFS reads and merges all the YAML files found in the file system, the files are merged
+// in lexical order with later definitions replacing earlier ones.
+func LoadFS(fsys fs.FS) (*ResponseInfo, error) {
+	info := NewResponseInfo()
+
+	if err := fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || path.Ext(name) != ".yaml" {
+			return nil
+		}
+		b, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return err
+		}
+		r, err := Decode(b)
+		if err != nil {
+			return fmt.Errorf("%s: %v", name, err)
+		}
+		info.Merge(r)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return info, nil
+}
+
+// LoadDir reads and merges all the YAML files found under the given directory.
+func LoadDir(dir string) (*ResponseInfo, error) {
+	if _, err := os.Stat(dir); err != nil {
+		return nil, err
+	}
+	return LoadFS(os.DirFS(dir))
+}
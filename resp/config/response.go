@@ -0,0 +1,175 @@
+package config
+
+import (
+	"fmt"
+)
+
+type Sensor struct {
+	Group    string   `yaml:"group"`
+	Sensors  []string `yaml:"sensors"`
+	Filters  []string `yaml:"filters"`
+	Channels string   `yaml:"channels"`
+	Reversed bool     `yaml:"reversed"`
+
+	// the fields given in the configuration, used when overriding a sensor group
+	keys map[string]bool
+}
+
+func (s *Sensor) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type sensor Sensor
+
+	var v sensor
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+	var keys map[string]interface{}
+	if err := unmarshal(&keys); err != nil {
+		return err
+	}
+
+	*s = Sensor(v)
+	s.keys = make(map[string]bool)
+	for k := range keys {
+		s.keys[k] = true
+	}
+
+	return nil
+}
+
+// inherit returns a copy of a sensor group entry with any fields given in the sensor configuration
+// replacing those of the group.
+func (s Sensor) inherit(group Sensor) Sensor {
+	if s.keys["sensors"] {
+		group.Sensors = s.Sensors
+	}
+	if s.keys["filters"] {
+		group.Filters = s.Filters
+	}
+	if s.keys["channels"] {
+		group.Channels = s.Channels
+	}
+	if s.keys["reversed"] {
+		group.Reversed = s.Reversed
+	}
+	group.Group, group.keys = "", nil
+
+	return group
+}
+
+type Datalogger struct {
+	Profile       string   `yaml:"profile"`
+	Dataloggers   []string `yaml:"dataloggers"`
+	Type          string   `yaml:"type"`
+	Label         string   `yaml:"label"`
+	SampleRate    float64  `yaml:"samplerate"`
+	Frequency     float64  `yaml:"frequency"`
+	StorageFormat string   `yaml:"storageformat"`
+	ClockDrift    float64  `yaml:"clockdrift"`
+	Filters       []string `yaml:"filters"`
+	Reversed      bool     `yaml:"reversed"`
+
+	// the fields given in the configuration, used when overriding a datalogger profile
+	keys map[string]bool
+}
+
+func (d *Datalogger) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type datalogger Datalogger
+
+	var v datalogger
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+	var keys map[string]interface{}
+	if err := unmarshal(&keys); err != nil {
+		return err
+	}
+
+	*d = Datalogger(v)
+	d.keys = make(map[string]bool)
+	for k := range keys {
+		d.keys[k] = true
+	}
+
+	return nil
+}
+
+// inherit returns a copy of a datalogger profile entry with any fields given in the datalogger
+// configuration replacing those of the profile.
+func (d Datalogger) inherit(profile Datalogger) Datalogger {
+	if d.keys["dataloggers"] {
+		profile.Dataloggers = d.Dataloggers
+	}
+	if d.keys["type"] {
+		profile.Type = d.Type
+	}
+	if d.keys["label"] {
+		profile.Label = d.Label
+	}
+	if d.keys["samplerate"] {
+		profile.SampleRate = d.SampleRate
+	}
+	if d.keys["frequency"] {
+		profile.Frequency = d.Frequency
+	}
+	if d.keys["storageformat"] {
+		profile.StorageFormat = d.StorageFormat
+	}
+	if d.keys["clockdrift"] {
+		profile.ClockDrift = d.ClockDrift
+	}
+	if d.keys["filters"] {
+		profile.Filters = d.Filters
+	}
+	if d.keys["reversed"] {
+		profile.Reversed = d.Reversed
+	}
+	profile.Profile, profile.keys = "", nil
+
+	return profile
+}
+
+type Response struct {
+	Sensors     []Sensor     `yaml:"sensors"`
+	Dataloggers []Datalogger `yaml:"dataloggers"`
+}
+
+// expand replaces any sensor group or datalogger profile references with the entries they refer to.
+func (r Response) expand(groups map[string][]Sensor, profiles map[string][]Datalogger) (Response, error) {
+	var res Response
+
+	for _, s := range r.Sensors {
+		if s.Group == "" {
+			res.Sensors = append(res.Sensors, s)
+			continue
+		}
+		group, ok := groups[s.Group]
+		if !ok {
+			return Response{}, fmt.Errorf("unknown sensor group: %s", s.Group)
+		}
+		for _, g := range group {
+			if g.Group != "" {
+				return Response{}, fmt.Errorf("nested sensor group %s: %s", s.Group, g.Group)
+			}
+			res.Sensors = append(res.Sensors, s.inherit(g))
+		}
+	}
+
+	for _, d := range r.Dataloggers {
+		if d.Profile == "" {
+			res.Dataloggers = append(res.Dataloggers, d)
+			continue
+		}
+		profile, ok := profiles[d.Profile]
+		if !ok {
+			return Response{}, fmt.Errorf("unknown datalogger profile: %s", d.Profile)
+		}
+		for _, p := range profile {
+			if p.Profile != "" {
+				return Response{}, fmt.Errorf("nested datalogger profile %s: %s", d.Profile, p.Profile)
+			}
+			res.Dataloggers = append(res.Dataloggers, d.inherit(p))
+		}
+	}
+
+	return res, nil
+}
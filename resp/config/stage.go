@@ -0,0 +1,95 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// yaml is unable to handle complex numbers
+type Complex128 complex128
+
+func (c *Complex128) UnmarshalText(text []byte) error {
+	_, err := fmt.Sscanf(string(text), "%v", c)
+	return err
+}
+
+func (c Complex128) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("%v", complex128(c))), nil
+}
+
+type SensorComponent struct {
+	Azimuth float64 `yaml:"azimuth"`
+	Dip     float64 `yaml:"dip"`
+}
+
+type SensorModel struct {
+	Type         string `yaml:"type"`
+	Description  string `yaml:"description"`
+	Manufacturer string `yaml:"manufacturer"`
+	Vendor       string `yaml:"vendor"`
+
+	Components []SensorComponent `yaml:"components"`
+}
+
+type DataloggerModel struct {
+	Type         string `yaml:"type"`
+	Description  string `yaml:"description"`
+	Manufacturer string `yaml:"manufacturer"`
+	Vendor       string `yaml:"vendor"`
+}
+
+type PAZ struct {
+	Code  string       `yaml:"code"`
+	Type  string       `yaml:"type"`
+	Notes string       `yaml:"notes"`
+	Poles []Complex128 `yaml:"poles"`
+	Zeros []Complex128 `yaml:"zeros"`
+}
+
+type FIR struct {
+	Causal     bool      `yaml:"causal"`
+	Symmetry   string    `yaml:"symmetry"`
+	Decimation float64   `yaml:"decimation"`
+	Gain       float64   `yaml:"gain"`
+	Notes      string    `yaml:"notes,omitempty"`
+	Factors    []float64 `yaml:"factors"`
+}
+
+// Correction returns the delay introduced by the filter at the given sample rate.
+func (f FIR) Correction(sps float64) float64 {
+	switch strings.ToUpper(f.Symmetry) {
+	case "EVEN":
+		return float64(len(f.Factors)*2-1) / (2.0 * sps)
+	case "ODD":
+		return float64((len(f.Factors)-1)*2+1-1) / (2.0 * sps)
+	default:
+		return float64(len(f.Factors)-1) / (2.0 * sps)
+	}
+}
+
+type Polynomial struct {
+	Gain                    float64 `yaml:"gain"`
+	ApproximationType       string  `yaml:"approximationtype"`
+	FrequencyLowerBound     float64 `yaml:"frequencylowerbound"`
+	FrequencyUpperBound     float64 `yaml:"frequencyupperbound"`
+	ApproximationLowerBound float64 `yaml:"approximationlowerbound"`
+	ApproximationUpperBound float64 `yaml:"approximationupperbound"`
+	MaximumError            float64 `yaml:"maximumerror"`
+	Notes                   string  `yaml:"notes,omitempty"`
+
+	Coefficients []float64 `yaml:"coefficients"`
+}
+
+type ResponseStage struct {
+	Type        string  `yaml:"type"`
+	Lookup      string  `yaml:"lookup"`
+	Frequency   float64 `yaml:"frequency"`
+	SampleRate  float64 `yaml:"samplerate"`
+	Decimate    int32   `yaml:"decimate"`
+	Gain        float64 `yaml:"gain"`
+	Scale       float64 `yaml:"scale"`
+	Correction  float64 `yaml:"correction"`
+	Delay       float64 `yaml:"delay"`
+	InputUnits  string  `yaml:"inputunits"`
+	OutputUnits string  `yaml:"outputunits"`
+}
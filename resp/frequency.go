@@ -0,0 +1,143 @@
+package resp
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// Response returns the complex, unnormalised, response of the poles and zeros at the given frequency,
+// digital (z-transform) responses need the sample rate of the stage.
+func (p PAZ) Response(freq, rate float64) complex128 {
+	var w complex128
+	switch p.Code {
+	case PZFunctionLaplaceRadiansPerSecond:
+		w = complex(0.0, 2.0*math.Pi*freq)
+	case PZFunctionLaplaceZTransform:
+		if rate > 0.0 {
+			w = cmplx.Exp(complex(0.0, 2.0*math.Pi*freq/rate))
+		}
+	default:
+		w = complex(0.0, freq)
+	}
+
+	h := complex(1.0, 0.0)
+	for _, zero := range p.Zeros {
+		h *= (w - zero)
+	}
+	for _, pole := range p.Poles {
+		h /= (w - pole)
+	}
+
+	return h
+}
+
+// Coefficients returns the full set of filter coefficients, expanding any symmetrical filters which
+// only list the first half of their coefficients.
+func (f FIR) Coefficients() []float64 {
+	coeffs := append([]float64{}, f.Factors...)
+
+	switch f.Symmetry {
+	case SymmetryEven:
+		for i := len(f.Factors) - 1; i >= 0; i-- {
+			coeffs = append(coeffs, f.Factors[i])
+		}
+	case SymmetryOdd:
+		for i := len(f.Factors) - 2; i >= 0; i-- {
+			coeffs = append(coeffs, f.Factors[i])
+		}
+	}
+
+	return coeffs
+}
+
+// Response returns the complex response of the filter at the given frequency, the rate is the input
+// sample rate of the filter, i.e. prior to any decimation. The response includes the filter delay.
+func (f FIR) Response(freq, rate float64) complex128 {
+	if !(rate > 0.0) {
+		return complex(f.Sum(), 0.0)
+	}
+
+	var h complex128
+	for k, c := range f.Coefficients() {
+		h += complex(c, 0.0) * cmplx.Exp(complex(0.0, -2.0*math.Pi*freq*float64(k)/rate))
+	}
+
+	return h
+}
+
+// Sum returns the sum of the filter coefficients, this is the filter gain at zero frequency.
+func (f FIR) Sum() float64 {
+	var sum float64
+	for _, c := range f.Coefficients() {
+		sum += c
+	}
+	return sum
+}
+
+// InputSampleRate returns the sample rate of the data entering the stage, for decimating stages the
+// stage sample rate refers to the output.
+func (r ResponseStage) InputSampleRate() float64 {
+	if f, ok := r.StageSet.(FIR); ok && f.Decimation > 0.0 {
+		return f.Decimation * r.SampleRate
+	}
+	if r.Decimate > 1 {
+		return float64(r.Decimate) * r.SampleRate
+	}
+	return r.SampleRate
+}
+
+// Response returns the complex response of the stage at the given frequency. Poles and zeros are scaled
+// to give the stage gain at the stage frequency, FIR filters are normalised to give the filter gain at
+// zero frequency, whereas polynomial and A2D stages are treated as a frequency independent gain.
+func (r ResponseStage) Response(freq float64) complex128 {
+
+	gain := r.Gain
+	if gain == 0.0 {
+		gain = 1.0
+	}
+
+	switch s := r.StageSet.(type) {
+	case PAZ:
+		h := s.Response(freq, r.SampleRate)
+		if n := cmplx.Abs(s.Response(r.Frequency, r.SampleRate)); n > 0.0 && !math.IsInf(n, 0) {
+			h /= complex(n, 0.0)
+		}
+		return complex(gain, 0.0) * h
+	case FIR:
+		if s.Gain != 0.0 {
+			gain = s.Gain
+		}
+		h := s.Response(freq, r.InputSampleRate())
+		if sum := s.Sum(); sum != 0.0 {
+			h /= complex(sum, 0.0)
+		}
+		return complex(gain, 0.0) * h
+	case Polynomial:
+		if s.Gain != 0.0 {
+			return complex(s.Gain, 0.0)
+		}
+		return complex(gain, 0.0)
+	default:
+		return complex(gain, 0.0)
+	}
+}
+
+// Response returns the complex response of the combined sensor and datalogger stages at each of the
+// given frequencies, the amplitude and phase are given by cmplx.Abs and cmplx.Phase.
+func (s Stream) Response(freqs []float64) []complex128 {
+	stages := append(append([]ResponseStage{}, s.Sensor.Stages...), s.Datalogger.Stages...)
+
+	response := make([]complex128, len(freqs))
+	for i, f := range freqs {
+		h := complex(1.0, 0.0)
+		for _, stage := range stages {
+			if stage.StageSet == nil {
+				continue
+			}
+			h *= stage.Response(f)
+		}
+		response[i] = h
+	}
+
+	return response
+}
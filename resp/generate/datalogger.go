@@ -4,14 +4,9 @@ import (
 	"io"
 	"strings"
 	"text/template"
-)
 
-type DataloggerModel struct {
-	Type         string `yaml:"type"`
-	Description  string `yaml:"description"`
-	Manufacturer string `yaml:"manufacturer"`
-	Vendor       string `yaml:"vendor"`
-}
+	"github.com/GeoNet/delta/resp/config"
+)
 
 var dataloggerModelTemplate = `
 
@@ -26,7 +21,7 @@ var DataloggerModels map[string]DataloggerModel = map[string]DataloggerModel{
 {{end}}{{"}"}}
 `
 
-func dataloggermodel(w io.Writer, dl map[string]DataloggerModel) error {
+func dataloggermodel(w io.Writer, dl map[string]config.DataloggerModel) error {
 
 	t, err := template.New("dataloggermodels").Funcs(
 		template.FuncMap{
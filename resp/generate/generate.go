@@ -1,10 +1,11 @@
 package main
 
 import (
-	"fmt"
 	"io"
 	"strings"
 	"text/template"
+
+	"github.com/GeoNet/delta/resp/config"
 )
 
 var generateTemplate = `
@@ -16,14 +17,14 @@ var generateTemplate = `
   {{ range $v := $r.Sensors }}    Sensor{
                 SensorList: []string{{"{"}}{{range $s := $v.Sensors}}"{{$s}}",{{end}}{{"}"}},
                 FilterList: []string{{"{"}}{{range $s := $v.Filters}}"{{$s}}",{{end}}{{"}"}},
-		Stages: []ResponseStage{{"{"}}{{range $s := $v.Filters}}{{with $f := $b.Filter $s}}
+		Stages: []ResponseStage{{"{"}}{{range $s := $v.Filters}}{{with $f := $b.SensorStages $s}}
 		{{ range $v := $f }}ResponseStage{
 		Type: "{{$v.Type}}",
 		Lookup: "{{$v.Lookup}}",
 		Filter: "{{$s}}",{{if eq $v.Type "paz" }}{{with $b.PAZ $v.Lookup}}
 		StageSet: PAZ{
 			Name: "{{$v.Lookup}}",
-			Code: {{.PzTransferFunction}},
+			Code: {{. | pzfunction}},
 			Type: "{{.Type}}",{{if .Notes }}
 			Notes: "{{.Notes|escape}}",{{end}}{{if .Poles }}
 			Poles: []complex128{{"{"}}{{ range $p := .Poles}}{{ $p }},{{end}}{{"}"}},{{ end }}{{if .Zeros }}
@@ -31,14 +32,14 @@ var generateTemplate = `
 		},{{end}}{{end}}{{if eq $v.Type "a2d" }}{{with $b.PAZ $v.Lookup}}
 		StageSet: A2D{
 			Name: "{{$v.Lookup}}",
-			Code: {{.PzTransferFunction}},
+			Code: {{. | pzfunction}},
 			Type: "{{.Type}}",{{if .Notes }}
 			Notes: "{{.Notes|escape}}",{{end}}
 		},{{end}}{{end}}{{if eq $v.Type "fir" }}{{with $b.FIR $v.Lookup}}
 		StageSet: FIR{
 			Name: "{{$v.Lookup}}",
 			Causal: {{.Causal}},
-			Symmetry: {{.SymmetryLookup}},
+			Symmetry: {{. | symmetry}},
 			Decimation: {{.Decimation}},
 			Gain: {{.Gain}},{{if .Notes }}
 			Notes: &[]string{"{{.Notes|escape}}"}[0],{{end}}
@@ -47,7 +48,7 @@ var generateTemplate = `
 		StageSet: Polynomial{
 			Name: "{{$v.Lookup}}",
 			Gain: {{.Gain}},
-			ApproximationType: {{.ApproximationTypeLookup}},
+			ApproximationType: {{. | approximation}},
 			FrequencyLowerBound: {{.FrequencyLowerBound}},
 			FrequencyUpperBound: {{.FrequencyUpperBound}},
 			ApproximationLowerBound: {{.ApproximationLowerBound}},
@@ -81,14 +82,14 @@ var generateTemplate = `
                 StorageFormat: "{{$v.StorageFormat}}",
                 ClockDrift: {{$v.ClockDrift}},
                 FilterList: []string{{"{"}}{{range $s := $v.Filters}}"{{$s}}",{{end}}{{"}"}},
-		Stages: []ResponseStage{{"{"}}{{range $s := $v.Filters}}{{with $f := $b.Filter $s}}
+		Stages: []ResponseStage{{"{"}}{{range $s := $v.Filters}}{{with $f := $b.DataloggerStages $s}}
 		{{ range $v := $f }}ResponseStage{
 		Type: "{{$v.Type}}",
 		Lookup: "{{$v.Lookup}}",
 		Filter: "{{$s}}",{{if eq $v.Type "paz" }}{{with $b.PAZ $v.Lookup}}
 		StageSet: PAZ{
 			Name: "{{$v.Lookup}}",
-			Code: {{.PzTransferFunction}},
+			Code: {{. | pzfunction}},
 			Type: "{{.Type}}",{{if .Notes }}
 			Notes: "{{.Notes|escape}}",{{end}}{{if .Poles }}
 			Poles: []complex128{{"{"}}{{ range $p := .Poles}}{{ $p }},{{end}}{{"}"}},{{ end }}{{if .Zeros }}
@@ -96,14 +97,14 @@ var generateTemplate = `
 		},{{end}}{{end}}{{if eq $v.Type "a2d" }}{{with $b.PAZ $v.Lookup}}
 		StageSet: A2D{
 			Name: "{{$v.Lookup}}",
-			Code: {{.PzTransferFunction}},
+			Code: {{. | pzfunction}},
 			Type: "{{.Type}}",{{if .Notes }}
 			Notes: "{{.Notes|escape}}",{{end}}
 		},{{end}}{{end}}{{if eq $v.Type "fir" }}{{with $b.FIR $v.Lookup}}
 		StageSet: FIR{
 			Name: "{{$v.Lookup}}",
 			Causal: {{.Causal}},
-			Symmetry: {{.SymmetryLookup}},
+			Symmetry: {{. | symmetry}},
 			Decimation: {{.Decimation}},
 			Gain: {{.Gain}},{{if .Notes }}
 			Notes: &[]string{"{{.Notes|escape}}"}[0],{{end}}
@@ -112,7 +113,7 @@ var generateTemplate = `
 		StageSet: Polynomial{
 			Name: "{{$v.Lookup}}",
 			Gain: {{.Gain}},
-			ApproximationType: {{.ApproximationTypeLookup}},
+			ApproximationType: {{. | approximation}},
 			FrequencyLowerBound: {{.FrequencyLowerBound}},
 			FrequencyUpperBound: {{.FrequencyUpperBound}},
 			ApproximationLowerBound: {{.ApproximationLowerBound}},
@@ -123,13 +124,11 @@ var generateTemplate = `
 		},{{end}}{{end}}
 		Frequency: {{$v.Frequency}},
 		SampleRate: {{$v.SampleRate}},
-		Decimate: {{if eq $v.Type "fir"}}{{with $b.FIR $v.Lookup}}{{.Decimation}}{{end}}{{else}}{{$v.Decimate}}{{end}},
+		Decimate: {{$v.Decimate}},
 		Gain: {{$v.Gain}},
-		//Scale: {{$v.Scale}},{{if eq $v.Type "fir"}}{{with $b.FIR $v.Lookup}}{{if and (eq $v.Correction 0.0) (gt .Decimation 1.0)}}
-		Correction: {{.Correction $v.SampleRate}},
-		Delay: {{.Correction $v.SampleRate}},{{else}}
+		//Scale: {{$v.Scale}},{{if eq $v.Type "fir"}}
 		Correction: {{$v.Correction}},
-		Delay: {{$v.Correction}},{{end}}{{end}}{{end}}
+		Delay: {{$v.Delay}},{{end}}
 		InputUnits: "{{$v.InputUnits}}",
 		OutputUnits: "{{$v.OutputUnits}}",
 		},{{end}}{{end}}{{end}}
@@ -141,85 +140,81 @@ var generateTemplate = `
 {{"}"}}
 `
 
-//{{with $b.FIR $v.Lookup}}{{if and (gt .Decimation 1) (eq $v.Correction 0.0)}}{{.Correction $v.SampleRate}}{{else}}{{$v.Correction}}{{end}}{{end}}{{else}}{{$v.Correction}}{{end}},
-//{{if eq $v.Type "fir"}}{{with $b.FIR $v.Lookup}}{{if gt .Decimation 1}}Correction: {{if eq $v.Correction 0.0}}{{.Correction $v.SampleRate}}{{else}}{{$v.Correction}}{{end}},
-//{{if gt .Decimation 1}}Delay: {{if eq $v.Correction 0.0}}{{.Correction $v.SampleRate}}{{else}}{{$v.Correction}}{{end}},{{else}}Correction: {{$v.Correction}},
-//Delay: {{$v.Delay}},{{end}}
-
 type Generate struct {
-	ResponseMap responseMap
-	FilterMap   filterMap
-	PazMap      pazMap
-	FirMap      firMap
-	PolyMap     polynomialMap
+	Info        *config.ResponseInfo
+	ResponseMap map[string]config.Response
 }
 
-func (g Generate) Filter(filter string) *[]ResponseStage {
-	if f, ok := g.FilterMap[filter]; ok {
-		return &f
-	}
-	return nil
+func (g Generate) SensorStages(filter string) []config.ResponseStage {
+	return g.Info.SensorStages(filter)
+}
+
+func (g Generate) DataloggerStages(filter string) []config.ResponseStage {
+	return g.Info.DataloggerStages(filter)
 }
 
-func (g Generate) PAZ(paz string) *PAZ {
-	if p, ok := g.PazMap[paz]; ok {
+func (g Generate) PAZ(paz string) *config.PAZ {
+	if p, ok := g.Info.PAZ[paz]; ok {
 		return &p
 	}
 	return nil
 }
 
-func (g Generate) FIR(fir string) *FIR {
-	if f, ok := g.FirMap[fir]; ok {
+func (g Generate) FIR(fir string) *config.FIR {
+	if f, ok := g.Info.FIR[fir]; ok {
 		return &f
 	}
 	return nil
 }
 
-func (g Generate) Polynomial(poly string) *Polynomial {
-	if p, ok := g.PolyMap[poly]; ok {
+func (g Generate) Polynomial(poly string) *config.Polynomial {
+	if p, ok := g.Info.Polynomial[poly]; ok {
 		return &p
 	}
 	return nil
 }
 
-func (g Generate) Validate() error {
-
-	// check responses
-	for k, r := range g.ResponseMap {
-		// check dataloger responses
-		for _, d := range r.Dataloggers {
-			label := fmt.Sprintf("\"%s\" [%s]", k, d.Label)
-
-			// check sampling rates
-			var r float64
-			for _, f := range d.Filters {
-				// look for a configured map
-				m, ok := g.FilterMap[f]
-				if !ok {
-					return fmt.Errorf("invalid filter %s: %s", label, f)
-				}
-				// choose the last sample rate
-				for _, s := range m {
-					r = s.SampleRate
-				}
-			}
-			if r != d.SampleRate {
-				return fmt.Errorf("invalid sample rate %s: found %v, expected %v", label, r, d.SampleRate)
-			}
-		}
+func pzfunction(p config.PAZ) string {
+	switch p.Code {
+	case "A":
+		return "PZFunctionLaplaceRadiansPerSecond"
+	case "B":
+		return "PZFunctionLaplaceHertz"
+	case "D":
+		return "PZFunctionLaplaceZTransform"
+	default:
+		return "PZFunctionUnknown"
 	}
-	return nil
 }
 
-func (g Generate) generate(w io.Writer) error {
+func symmetry(f config.FIR) string {
+	switch strings.ToUpper(f.Symmetry) {
+	case "EVEN":
+		return "SymmetryEven"
+	case "ODD":
+		return "SymmetryOdd"
+	default:
+		return "SymmetryNone"
+	}
+}
 
-	if err := g.Validate(); err != nil {
-		return err
+func approximation(p config.Polynomial) string {
+	switch p.ApproximationType {
+	case "MACLAURIN":
+		return "ApproximationTypeMaclaurin"
+	default:
+		return "ApproximationTypeUnknown"
 	}
+}
+
+func (g Generate) generate(w io.Writer) error {
 
 	t, err := template.New("generate").Funcs(
 		template.FuncMap{
-			"escape": func(s string) string { return strings.Join(strings.Fields(s), " ") },
+			"escape":        func(s string) string { return strings.Join(strings.Fields(s), " ") },
+			"pzfunction":    pzfunction,
+			"symmetry":      symmetry,
+			"approximation": approximation,
 		},
 	).Parse(generateTemplate)
 	if err != nil {
@@ -1,8 +1,9 @@
 package main
 
 import (
-	"fmt"
 	"io"
+
+	"github.com/GeoNet/delta/resp/config"
 )
 
 var header = `
@@ -21,81 +22,25 @@ package resp
 
 `
 
-// yaml is unable to handle complex numbers
-type Complex128 complex128
-
-func (c *Complex128) UnmarshalText(text []byte) error {
-	_, err := fmt.Sscanf(string(text), "%v", c)
-	return err
-}
-
-func (c Complex128) MarshalText() ([]byte, error) {
-	return []byte(fmt.Sprintf("%v", c)), nil
-}
-
-// manage response information
-type ResponseInfo struct {
-	PAZ             map[string]PAZ             `yaml:"paz"`
-	Polynomial      map[string]Polynomial      `yaml:"polynomial"`
-	FIR             map[string]FIR             `yaml:"fir"`
-	DataloggerModel map[string]DataloggerModel `yaml:"datalogger-model"`
-	SensorModel     map[string]SensorModel     `yaml:"sensor-model"`
-	Filter          map[string][]ResponseStage `yaml:"filter"`
-	Response        map[string]Response        `yaml:"response"`
-}
-
-func NewResponseInfo() *ResponseInfo {
-	return &ResponseInfo{
-		PAZ:             make(map[string]PAZ),
-		Polynomial:      make(map[string]Polynomial),
-		FIR:             make(map[string]FIR),
-		DataloggerModel: make(map[string]DataloggerModel),
-		SensorModel:     make(map[string]SensorModel),
-		Filter:          make(map[string][]ResponseStage),
-		Response:        make(map[string]Response),
-	}
-}
-
-func (r *ResponseInfo) Merge(resp ResponseInfo) {
-	for k, v := range resp.PAZ {
-		r.PAZ[k] = v
-	}
-	for k, v := range resp.Polynomial {
-		r.Polynomial[k] = v
-	}
-	for k, v := range resp.FIR {
-		r.FIR[k] = v
-	}
-	for k, v := range resp.DataloggerModel {
-		r.DataloggerModel[k] = v
-	}
-	for k, v := range resp.SensorModel {
-		r.SensorModel[k] = v
-	}
-	for k, v := range resp.Filter {
-		r.Filter[k] = v
-	}
-	for k, v := range resp.Response {
-		r.Response[k] = v
+// render writes the go source code for the configured responses and models.
+func render(w io.Writer, info *config.ResponseInfo) error {
+	responses, err := info.Responses()
+	if err != nil {
+		return err
 	}
-}
 
-func (r ResponseInfo) Generate(w io.Writer) error {
 	g := Generate{
-		ResponseMap: responseMap(r.Response),
-		FilterMap:   filterMap(r.Filter),
-		PazMap:      pazMap(r.PAZ),
-		FirMap:      firMap(r.FIR),
-		PolyMap:     polynomialMap(r.Polynomial),
+		Info:        info,
+		ResponseMap: responses,
 	}
 	if _, err := w.Write([]byte(header)); err != nil {
 		return err
 	}
 
-	if err := dataloggermodel(w, r.DataloggerModel); err != nil {
+	if err := dataloggermodel(w, info.DataloggerModel); err != nil {
 		return err
 	}
-	if err := sensormodel(w, r.SensorModel); err != nil {
+	if err := sensormodel(w, info.SensorModel); err != nil {
 		return err
 	}
 	if err := g.generate(w); err != nil {
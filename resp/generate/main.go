@@ -2,12 +2,11 @@ package main
 
 import (
 	"flag"
-	"io/ioutil"
+	"fmt"
 	"log"
 	"os"
-	"path/filepath"
 
-	"gopkg.in/yaml.v2"
+	"github.com/GeoNet/delta/resp/config"
 )
 
 func main() {
@@ -15,32 +14,40 @@ func main() {
 	var dir string
 	flag.StringVar(&dir, "dir", "responses", "response YAML directory")
 
+	var strict bool
+	flag.BoolVar(&strict, "strict", false, "validate the response YAML files, reporting any problems found rather than generating code")
+
 	flag.Parse()
 
 	if _, err := os.Stat(dir); os.IsNotExist(err) {
 		log.Fatal(err)
 	}
 
-	response := NewResponseInfo()
+	if strict {
+		var validator Validator
+		if err := validator.AddDir(dir); err != nil {
+			log.Fatal(err)
+		}
 
-	if err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
-		if !info.IsDir() && filepath.Ext(path) == ".yaml" {
-			b, err := ioutil.ReadFile(path)
-			if err != nil {
-				return err
-			}
-			var r ResponseInfo
-			if err := yaml.Unmarshal(b, &r); err != nil {
-				return err
+		var failed bool
+		for _, d := range validator.Validate() {
+			fmt.Fprintln(os.Stderr, d)
+			if !d.Warning {
+				failed = true
 			}
-			response.Merge(r)
 		}
-		return nil
-	}); err != nil {
+		if failed {
+			os.Exit(1)
+		}
+		return
+	}
+
+	info, err := config.LoadDir(dir)
+	if err != nil {
 		log.Fatal(err)
 	}
 
-	if err := response.Generate(os.Stdout); err != nil {
+	if err := render(os.Stdout, info); err != nil {
 		log.Fatal(err)
 	}
 }
@@ -4,21 +4,9 @@ import (
 	"io"
 	"strings"
 	"text/template"
-)
-
-type SensorComponent struct {
-	Azimuth float64 `yaml:"azimuth"`
-	Dip     float64 `yaml:"dip"`
-}
 
-type SensorModel struct {
-	Type         string `yaml:"type"`
-	Description  string `yaml:"description"`
-	Manufacturer string `yaml:"manufacturer"`
-	Vendor       string `yaml:"vendor"`
-
-	Components []SensorComponent `yaml:"components"`
-}
+	"github.com/GeoNet/delta/resp/config"
+)
 
 var sensorModelTemplate = `
 
@@ -34,7 +22,7 @@ var SensorModels map[string]SensorModel = map[string]SensorModel{
 {{end}}{{"}"}}
 `
 
-func sensormodel(w io.Writer, dl map[string]SensorModel) error {
+func sensormodel(w io.Writer, dl map[string]config.SensorModel) error {
 
 	t, err := template.New("sensormodels").Funcs(
 		template.FuncMap{
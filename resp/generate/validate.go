@@ -0,0 +1,573 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/GeoNet/delta/resp/config"
+	"gopkg.in/yaml.v2"
+)
+
+// Diagnostic describes a problem found in a response configuration file.
+type Diagnostic struct {
+	File    string
+	Line    int
+	Message string
+	Warning bool
+}
+
+func (d Diagnostic) String() string {
+	if d.Warning {
+		return fmt.Sprintf("%s:%d: warning: %s", d.File, d.Line, d.Message)
+	}
+	return fmt.Sprintf("%s:%d: %s", d.File, d.Line, d.Message)
+}
+
+// source holds the lines of a configuration file so that problems can be traced back to where they were found.
+type source struct {
+	file  string
+	lines []string
+}
+
+// leading returns the number of spaces at the start of a line.
+func leading(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+// item returns whether the line starts a list entry.
+func item(line string) bool {
+	return strings.HasPrefix(strings.TrimSpace(line), "-")
+}
+
+// unquote removes any surrounding quotes from a yaml scalar.
+func unquote(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) > 1 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+// key returns the mapping key given on a line, or the value if it is a simple list entry.
+func key(line string) string {
+	s := strings.TrimSpace(line)
+	if strings.HasPrefix(s, "-") {
+		s = strings.TrimSpace(strings.TrimPrefix(s, "-"))
+	}
+	if i := strings.Index(s, ":"); i > 0 && (i == len(s)-1 || s[i+1] == ' ') {
+		return unquote(s[:i])
+	}
+	return unquote(s)
+}
+
+// blank returns whether the line holds no yaml content.
+func blank(line string) bool {
+	s := strings.TrimSpace(line)
+	return s == "" || strings.HasPrefix(s, "#") || s == "---"
+}
+
+// inline returns the column of a key, or of a list entry without a key, given on a line and any
+// value that follows it on the same line.
+func inline(line string) (int, string) {
+	col, s := leading(line), strings.TrimSpace(line)
+	if strings.HasPrefix(s, "-") {
+		rest := strings.TrimLeft(strings.TrimPrefix(s, "-"), " ")
+		if k := strings.Index(rest, ":"); strings.HasPrefix(rest, "[") || strings.HasPrefix(rest, "{") || !(k > 0 && (k == len(rest)-1 || rest[k+1] == ' ')) {
+			return col, rest
+		}
+		col, s = col+len(s)-len(rest), rest
+	}
+	if k := strings.Index(s, ":"); k > 0 && (k == len(s)-1 || s[k+1] == ' ') {
+		s = strings.TrimSpace(s[k+1:])
+	}
+	if strings.HasPrefix(s, "#") {
+		return col, ""
+	}
+	return col, s
+}
+
+// continued marks the lines that carry on a value started on an earlier line, such as multi-line
+// or block scalars and flow collections, these are more indented than the key holding the value.
+func (s source) continued() []bool {
+	cont := make([]bool, len(s.lines))
+	for i := 0; i < len(s.lines); i++ {
+		if blank(s.lines[i]) {
+			continue
+		}
+		col, value := inline(s.lines[i])
+		if value == "" {
+			continue
+		}
+		for i+1 < len(s.lines) && (blank(s.lines[i+1]) || leading(s.lines[i+1]) > col) {
+			cont[i+1] = true
+			i++
+		}
+	}
+	return cont
+}
+
+// locate finds the line number of an element in the file, the path is made up of mapping keys
+// and list indexes. The last line found is returned if the full path could not be followed.
+func (s source) locate(path ...interface{}) int {
+	var line int
+
+	cont := s.continued()
+	skip := func(i int) bool {
+		return cont[i] || blank(s.lines[i])
+	}
+
+	start, parent, inclusive := 0, -1, true
+	for n, p := range path {
+		found := -1
+		switch p := p.(type) {
+		case string:
+			for i := start; i < len(s.lines); i++ {
+				if (i == start && !inclusive) || skip(i) {
+					continue
+				}
+				if i > start && leading(s.lines[i]) <= parent {
+					break
+				}
+				if key(s.lines[i]) == p {
+					found = i
+					break
+				}
+			}
+			if found < 0 {
+				return line
+			}
+			start, parent, inclusive = found, leading(s.lines[found]), false
+			if item(s.lines[found]) {
+				parent += 2
+			}
+		case int:
+			var n, indent int
+			for i := start + 1; i < len(s.lines); i++ {
+				if skip(i) {
+					continue
+				}
+				l := leading(s.lines[i])
+				if l < parent || (l == parent && !item(s.lines[i])) {
+					break
+				}
+				if !item(s.lines[i]) || (n > 0 && l != indent) {
+					continue
+				}
+				if n == 0 {
+					indent = l
+				}
+				if n == p {
+					found = i
+					break
+				}
+				n++
+			}
+			if found < 0 {
+				return line
+			}
+			start, parent, inclusive = found, leading(s.lines[found]), true
+		}
+		line = found + 1
+
+		// the rest of the path is inside a flow style collection
+		_, value := inline(s.lines[found])
+		if _, ok := p.(int); ok {
+			value = strings.TrimLeft(strings.TrimPrefix(strings.TrimSpace(s.lines[found]), "-"), " ")
+		}
+		if strings.HasPrefix(value, "[") || strings.HasPrefix(value, "{") {
+			f := flow{lines: s.lines, line: found, col: strings.Index(s.lines[found], value)}
+			if l := f.locate(f.parse(), path[n+1:]); l > 0 {
+				return l
+			}
+			return line
+		}
+	}
+
+	return line
+}
+
+// flowNode is an element of a flow style collection, keys are given for mappings.
+type flowNode struct {
+	line   int
+	keys   []string
+	values []flowNode
+}
+
+// flow parses flow style collections, i.e. "[a, b]" or "{a: b}", which may span several lines.
+type flow struct {
+	lines     []string
+	line, col int
+}
+
+func (f *flow) peek() byte {
+	for f.line < len(f.lines) {
+		for f.col < len(f.lines[f.line]) {
+			switch c := f.lines[f.line][f.col]; {
+			case c == ' ' || c == '\t':
+				f.col++
+			case c == '#':
+				f.col = len(f.lines[f.line])
+			default:
+				return c
+			}
+		}
+		f.line, f.col = f.line+1, 0
+	}
+	return 0
+}
+
+// scalar reads a plain or quoted flow scalar.
+func (f *flow) scalar() string {
+	text := f.lines[f.line][f.col:]
+	if q := text[0]; q == '"' || q == '\'' {
+		if k := strings.IndexByte(text[1:], q); k >= 0 {
+			f.col += k + 2
+			return text[1 : k+1]
+		}
+	}
+	var k int
+	for k < len(text) && !strings.ContainsRune(",[]{}", rune(text[k])) && !(text[k] == ':' && (k+1 == len(text) || text[k+1] == ' ')) {
+		k++
+	}
+	f.col += k
+	return strings.TrimSpace(text[:k])
+}
+
+// parse reads the next flow element, the line recorded is zero based.
+func (f *flow) parse() flowNode {
+	c := f.peek()
+	node := flowNode{line: f.line}
+	if c != '[' && c != '{' {
+		if c != 0 {
+			f.scalar()
+		}
+		return node
+	}
+
+	f.col++
+	for {
+		switch f.peek() {
+		case 0:
+			return node
+		case ']', '}':
+			f.col++
+			return node
+		case ',':
+			f.col++
+			continue
+		}
+		if c == '[' {
+			node.values = append(node.values, f.parse())
+			continue
+		}
+		line, name := f.line, f.scalar()
+		if f.peek() == ':' {
+			f.col++
+		}
+		value := f.parse()
+		if value.values == nil && value.keys == nil {
+			value.line = line
+		}
+		node.keys, node.values = append(node.keys, name), append(node.values, value)
+	}
+}
+
+// locate follows a path through a parsed flow element, returning the line number found.
+func (f *flow) locate(node flowNode, path []interface{}) int {
+	for _, p := range path {
+		next := -1
+		switch p := p.(type) {
+		case string:
+			for i, k := range node.keys {
+				if k == p {
+					next = i
+				}
+			}
+		case int:
+			if node.keys == nil && p < len(node.values) {
+				next = p
+			}
+		}
+		if next < 0 {
+			break
+		}
+		node = node.values[next]
+	}
+	return node.line + 1
+}
+
+// location records where a named entry was defined.
+type location struct {
+	file string
+	line int
+}
+
+// document holds a single parsed configuration file.
+type document struct {
+	source
+	info  config.ResponseInfo
+	value interface{}
+}
+
+// Validator checks a set of response configuration files for problems that would otherwise pass unnoticed.
+type Validator struct {
+	configs []document
+}
+
+// AddDir parses all the configuration files found in a directory, and any sub-directories, for later validation.
+func (v *Validator) AddDir(dir string) error {
+	return filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || filepath.Ext(path) != ".yaml" {
+			return nil
+		}
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return v.Add(path, b)
+	})
+}
+
+// Add parses a configuration file for later validation.
+func (v *Validator) Add(file string, data []byte) error {
+	info, err := config.Decode(data)
+	if err != nil {
+		return fmt.Errorf("%s: %v", file, err)
+	}
+	var value interface{}
+	if err := yaml.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("%s: %v", file, err)
+	}
+	v.configs = append(v.configs, document{
+		source: source{file: file, lines: strings.Split(string(data), "\n")},
+		info:   info,
+		value:  value,
+	})
+	return nil
+}
+
+// fields returns the yaml keys expected for a struct type.
+func fields(t reflect.Type) map[string]reflect.Type {
+	keys := make(map[string]reflect.Type)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+		name := strings.Split(f.Tag.Get("yaml"), ",")[0]
+		if name == "" {
+			name = strings.ToLower(f.Name)
+		}
+		keys[name] = f.Type
+	}
+	return keys
+}
+
+// unknown walks a decoded yaml value against the type it will be unmarshalled into, reporting any keys not expected.
+func unknown(value interface{}, t reflect.Type, path []interface{}, report func([]interface{}, string)) {
+	switch t.Kind() {
+	case reflect.Struct:
+		m, ok := value.(map[interface{}]interface{})
+		if !ok {
+			return
+		}
+		expected := fields(t)
+		for k, v := range m {
+			name := fmt.Sprint(k)
+			ft, ok := expected[name]
+			if !ok {
+				report(path, name)
+				continue
+			}
+			unknown(v, ft, append(append([]interface{}{}, path...), name), report)
+		}
+	case reflect.Map:
+		m, ok := value.(map[interface{}]interface{})
+		if !ok {
+			return
+		}
+		for k, v := range m {
+			unknown(v, t.Elem(), append(append([]interface{}{}, path...), fmt.Sprint(k)), report)
+		}
+	case reflect.Slice:
+		l, ok := value.([]interface{})
+		if !ok {
+			return
+		}
+		for i, v := range l {
+			unknown(v, t.Elem(), append(append([]interface{}{}, path...), i), report)
+		}
+	}
+}
+
+// Validate returns any problems found in the configuration files, unused models are given as warnings.
+func (v *Validator) Validate() []Diagnostic {
+	var diags []Diagnostic
+
+	errorf := func(c document, line int, format string, args ...interface{}) {
+		diags = append(diags, Diagnostic{File: c.file, Line: line, Message: fmt.Sprintf(format, args...)})
+	}
+	warnf := func(c document, line int, format string, args ...interface{}) {
+		diags = append(diags, Diagnostic{File: c.file, Line: line, Message: fmt.Sprintf(format, args...), Warning: true})
+	}
+
+	// unknown keys
+	for _, c := range v.configs {
+		unknown(c.value, reflect.TypeOf(config.ResponseInfo{}), nil, func(path []interface{}, name string) {
+			line := c.locate(append(append([]interface{}{}, path...), name)...)
+			if len(path) > 0 {
+				errorf(c, line, "unknown key %q in %s", name, path[0])
+			} else {
+				errorf(c, line, "unknown key %q", name)
+			}
+		})
+	}
+
+	// the names defined in each section, and where
+	defined := make(map[string]map[string]location)
+	sections := func(info config.ResponseInfo) map[string][]string {
+		names := func(m interface{}) []string {
+			var keys []string
+			for _, k := range reflect.ValueOf(m).MapKeys() {
+				keys = append(keys, k.String())
+			}
+			sort.Strings(keys)
+			return keys
+		}
+		return map[string][]string{
+			"paz":              names(info.PAZ),
+			"polynomial":       names(info.Polynomial),
+			"fir":              names(info.FIR),
+			"datalogger-model": names(info.DataloggerModel),
+			"sensor-model":     names(info.SensorModel),
+			"filter":           names(info.Filter),
+			"sensor-group":     names(info.SensorGroup),
+			"profile":          names(info.Profile),
+			"response":         names(info.Response),
+		}
+	}
+	for _, c := range v.configs {
+		for section, names := range sections(c.info) {
+			if _, ok := defined[section]; !ok {
+				defined[section] = make(map[string]location)
+			}
+			for _, name := range names {
+				line := c.locate(section, name)
+				if l, ok := defined[section][name]; ok {
+					errorf(c, line, "duplicate %s definition %q, previously defined at %s:%d", section, name, l.file, l.line)
+					continue
+				}
+				defined[section][name] = location{file: c.file, line: line}
+			}
+		}
+	}
+
+	exists := func(section, name string) bool {
+		_, ok := defined[section][name]
+		return ok
+	}
+
+	// dangling references
+	used := make(map[string]map[string]bool)
+	use := func(section, name string) {
+		if _, ok := used[section]; !ok {
+			used[section] = make(map[string]bool)
+		}
+		used[section][name] = true
+	}
+
+	for _, c := range v.configs {
+		check := func(line int, section, name, kind string) {
+			if !exists(section, name) {
+				errorf(c, line, "unknown %s %q", kind, name)
+			}
+		}
+		sensors := func(path []interface{}, list []config.Sensor) {
+			for i, s := range list {
+				p := append(append([]interface{}{}, path...), i)
+				if s.Group != "" {
+					check(c.locate(append(p, "group")...), "sensor-group", s.Group, "sensor group")
+				}
+				for j, name := range s.Sensors {
+					check(c.locate(append(p, "sensors", j)...), "sensor-model", name, "sensor model")
+					use("sensor-model", name)
+				}
+				for j, name := range s.Filters {
+					check(c.locate(append(p, "filters", j)...), "filter", name, "filter")
+				}
+			}
+		}
+		dataloggers := func(path []interface{}, list []config.Datalogger) {
+			for i, d := range list {
+				p := append(append([]interface{}{}, path...), i)
+				if d.Profile != "" {
+					check(c.locate(append(p, "profile")...), "profile", d.Profile, "datalogger profile")
+				}
+				for j, name := range d.Dataloggers {
+					check(c.locate(append(p, "dataloggers", j)...), "datalogger-model", name, "datalogger model")
+					use("datalogger-model", name)
+				}
+				for j, name := range d.Filters {
+					check(c.locate(append(p, "filters", j)...), "filter", name, "filter")
+				}
+			}
+		}
+
+		for _, k := range sections(c.info)["filter"] {
+			for i, stage := range c.info.Filter[k] {
+				line := c.locate("filter", k, i, "lookup")
+				switch stage.Type {
+				case "paz", "a2d":
+					if stage.Lookup != "" {
+						check(line, "paz", stage.Lookup, "paz")
+					}
+				case "fir":
+					check(line, "fir", stage.Lookup, "fir")
+				case "poly":
+					check(line, "polynomial", stage.Lookup, "polynomial")
+				default:
+					errorf(c, c.locate("filter", k, i, "type"), "unknown stage type %q in filter %q", stage.Type, k)
+				}
+			}
+		}
+		for _, k := range sections(c.info)["sensor-group"] {
+			sensors([]interface{}{"sensor-group", k}, c.info.SensorGroup[k])
+		}
+		for _, k := range sections(c.info)["profile"] {
+			dataloggers([]interface{}{"profile", k}, c.info.Profile[k])
+		}
+		for _, k := range sections(c.info)["response"] {
+			sensors([]interface{}{"response", k, "sensors"}, c.info.Response[k].Sensors)
+			dataloggers([]interface{}{"response", k, "dataloggers"}, c.info.Response[k].Dataloggers)
+		}
+	}
+
+	// unused models
+	for _, c := range v.configs {
+		for _, section := range []string{"sensor-model", "datalogger-model"} {
+			for _, name := range sections(c.info)[section] {
+				if l := defined[section][name]; l.file != c.file || used[section][name] {
+					continue
+				}
+				warnf(c, c.locate(section, name), "%s %q is not used by any response", section, name)
+			}
+		}
+	}
+
+	sort.SliceStable(diags, func(i, j int) bool {
+		if diags[i].File != diags[j].File {
+			return diags[i].File < diags[j].File
+		}
+		return diags[i].Line < diags[j].Line
+	})
+
+	return diags
+}
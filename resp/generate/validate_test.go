@@ -0,0 +1,186 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidator(t *testing.T) {
+
+	files := []struct {
+		name string
+		data string
+	}{
+		{"responses/a.yaml", `---
+paz:
+  TEST PAZ:
+    code: A
+    poles: []
+    zeros: []
+fir:
+  TEST FIR:
+    decimation: 2
+    factors:
+    - 1.0
+filter:
+  SENSOR:
+  - type: paz
+    lookup: TEST PAZ
+    colour: red
+  DATALOGGER:
+  - type: fir
+    lookup: MISSING FIR
+  BROKEN:
+  - type: paz
+    lookup: MISSING PAZ
+sensor-model:
+  TEST SENSOR:
+    type: test
+  UNUSED SENSOR:
+    type: test
+datalogger-model:
+  TEST DATALOGGER:
+    type: test
+response:
+  TEST RESPONSE:
+    sensors:
+    - sensors:
+      - TEST SENSOR
+      filters:
+      - SENSOR
+      - MISSING FILTER
+    dataloggers:
+    - profile: MISSING PROFILE
+      dataloggers:
+      - TEST DATALOGGER
+`},
+		{"responses/b.yaml", `---
+paz:
+  TEST PAZ:
+    code: B
+    poles: []
+    zeros: []
+polynomials:
+  TEST POLY:
+    gain: 1
+filter:
+  POLY:
+  - type: poly
+    lookup: TEST POLY
+`},
+	}
+
+	var validator Validator
+	for _, f := range files {
+		if err := validator.Add(f.name, []byte(f.data)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expected := []Diagnostic{
+		{File: "responses/a.yaml", Line: 16, Message: "unknown key \"colour\" in filter"},
+		{File: "responses/a.yaml", Line: 19, Message: "unknown fir \"MISSING FIR\""},
+		{File: "responses/a.yaml", Line: 22, Message: "unknown paz \"MISSING PAZ\""},
+		{File: "responses/a.yaml", Line: 26, Message: "sensor-model \"UNUSED SENSOR\" is not used by any response", Warning: true},
+		{File: "responses/a.yaml", Line: 38, Message: "unknown filter \"MISSING FILTER\""},
+		{File: "responses/a.yaml", Line: 40, Message: "unknown datalogger profile \"MISSING PROFILE\""},
+		{File: "responses/b.yaml", Line: 3, Message: "duplicate paz definition \"TEST PAZ\", previously defined at responses/a.yaml:3"},
+		{File: "responses/b.yaml", Line: 7, Message: "unknown key \"polynomials\""},
+		{File: "responses/b.yaml", Line: 13, Message: "unknown polynomial \"TEST POLY\""},
+	}
+
+	diags := validator.Validate()
+	if !reflect.DeepEqual(diags, expected) {
+		t.Errorf("invalid diagnostics")
+		for _, d := range diags {
+			t.Log(d)
+		}
+	}
+}
+
+func TestValidatorLibrary(t *testing.T) {
+
+	var validator Validator
+	if err := validator.AddDir("../responses"); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, d := range validator.Validate() {
+		if !d.Warning {
+			t.Error(d)
+		}
+	}
+}
+
+func TestValidatorLocate(t *testing.T) {
+
+	s := source{file: "test.yaml", lines: []string{
+		"---",
+		"filter:",
+		"  FIRST:",
+		"  - type: paz",
+		"    lookup: A",
+		"  - type: fir",
+		"    lookup: B",
+		"  SECOND:",
+		"  # a comment",
+		"  - type: poly",
+		"    lookup: C",
+		"response:",
+		"  TEST:",
+		"    sensors:",
+		"    - sensors:",
+		"      - ONE",
+		"      - TWO",
+		"paz:",
+		"  FIRST:",
+		"    notes: a long note which carries on",
+		"      lookup: onto the next line",
+		"    poles: [(-1+0i),",
+		"      (-2+0i)]",
+		"    lookup: D",
+		"  SECOND:",
+		"    notes: |",
+		"      - not a list entry",
+		"      lookup: not a key",
+		"    zeros: []",
+		"    lookup: E",
+		"datalogger:",
+		"  TEST:",
+		"    dataloggers: [ONE,",
+		"      TWO, 'THREE']",
+		"    filters:",
+		"    - {type: fir,",
+		"       lookup: F}",
+		"    - [G, H]",
+	}}
+
+	tests := []struct {
+		path []interface{}
+		line int
+	}{
+		{[]interface{}{"filter"}, 2},
+		{[]interface{}{"filter", "FIRST"}, 3},
+		{[]interface{}{"filter", "FIRST", 1, "lookup"}, 7},
+		{[]interface{}{"filter", "SECOND", 0, "lookup"}, 11},
+		{[]interface{}{"response", "TEST", "sensors", 0, "sensors", 1}, 17},
+		{[]interface{}{"filter", "FIRST", 2}, 3},
+		{[]interface{}{"missing"}, 0},
+		{[]interface{}{"paz", "FIRST", "lookup"}, 24},
+		{[]interface{}{"paz", "FIRST", "poles", 1}, 23},
+		{[]interface{}{"paz", "SECOND", "notes"}, 26},
+		{[]interface{}{"paz", "SECOND", "lookup"}, 30},
+		{[]interface{}{"paz", "SECOND", "zeros", 0}, 29},
+		{[]interface{}{"datalogger", "TEST", "dataloggers", 0}, 33},
+		{[]interface{}{"datalogger", "TEST", "dataloggers", 2}, 34},
+		{[]interface{}{"datalogger", "TEST", "filters", 0, "lookup"}, 37},
+		{[]interface{}{"datalogger", "TEST", "filters", 1, 1}, 38},
+		{[]interface{}{"datalogger", "TEST", "filters", 2}, 35},
+	}
+
+	for _, x := range tests {
+		if line := s.locate(x.path...); line != x.line {
+			t.Errorf("invalid line for %v: got %d, expected %d", x.path, line, x.line)
+		}
+	}
+}
@@ -0,0 +1,252 @@
+package resp
+
+import (
+	"io/fs"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/GeoNet/delta/resp/config"
+)
+
+// loader builds a response Library from a decoded configuration, this is the run time
+// equivalent of the generate command.
+type loader struct {
+	*config.ResponseInfo
+}
+
+// notes mimics the white space handling of the generated notes.
+func notes(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func pzFunction(p config.PAZ) PzTransferFunction {
+	switch p.Code {
+	case "A":
+		return PZFunctionLaplaceRadiansPerSecond
+	case "B":
+		return PZFunctionLaplaceHertz
+	case "D":
+		return PZFunctionLaplaceZTransform
+	default:
+		return PZFunctionUnknown
+	}
+}
+
+func pazStage(name string, p config.PAZ) PAZ {
+	paz := PAZ{
+		Name:  name,
+		Code:  pzFunction(p),
+		Type:  p.Type,
+		Notes: notes(p.Notes),
+	}
+	for _, v := range p.Poles {
+		paz.Poles = append(paz.Poles, complex128(v))
+	}
+	for _, v := range p.Zeros {
+		paz.Zeros = append(paz.Zeros, complex128(v))
+	}
+	return paz
+}
+
+func firSymmetry(f config.FIR) Symmetry {
+	switch strings.ToUpper(f.Symmetry) {
+	case "EVEN":
+		return SymmetryEven
+	case "ODD":
+		return SymmetryOdd
+	default:
+		return SymmetryNone
+	}
+}
+
+func firStage(name string, f config.FIR) FIR {
+	fir := FIR{
+		Name:       name,
+		Causal:     f.Causal,
+		Symmetry:   firSymmetry(f),
+		Decimation: f.Decimation,
+		Gain:       f.Gain,
+		Factors:    append([]float64{}, f.Factors...),
+	}
+	if f.Notes != "" {
+		n := notes(f.Notes)
+		fir.Notes = &n
+	}
+	return fir
+}
+
+func polynomialStage(name string, p config.Polynomial) Polynomial {
+	poly := Polynomial{
+		Name:                    name,
+		Gain:                    p.Gain,
+		ApproximationType:       ApproximationTypeUnknown,
+		FrequencyLowerBound:     p.FrequencyLowerBound,
+		FrequencyUpperBound:     p.FrequencyUpperBound,
+		ApproximationLowerBound: p.ApproximationLowerBound,
+		ApproximationUpperBound: p.ApproximationUpperBound,
+		MaximumError:            p.MaximumError,
+		Coefficients:            []Coefficient{},
+	}
+	if p.ApproximationType == "MACLAURIN" {
+		poly.ApproximationType = ApproximationTypeMaclaurin
+	}
+	if p.Notes != "" {
+		n := notes(p.Notes)
+		poly.Notes = &n
+	}
+	for _, v := range p.Coefficients {
+		poly.Coefficients = append(poly.Coefficients, Coefficient{Value: v})
+	}
+	return poly
+}
+
+// stageSet builds the configured filter stage, missing lookups give an empty stage.
+func (r loader) stageSet(s config.ResponseStage) StageSet {
+	switch s.Type {
+	case "paz":
+		if p, ok := r.PAZ[s.Lookup]; ok {
+			return pazStage(s.Lookup, p)
+		}
+	case "a2d":
+		if p, ok := r.PAZ[s.Lookup]; ok {
+			return A2D{
+				Name:  s.Lookup,
+				Code:  pzFunction(p),
+				Type:  p.Type,
+				Notes: notes(p.Notes),
+			}
+		}
+	case "fir":
+		if f, ok := r.FIR[s.Lookup]; ok {
+			return firStage(s.Lookup, f)
+		}
+	case "poly":
+		if p, ok := r.Polynomial[s.Lookup]; ok {
+			return polynomialStage(s.Lookup, p)
+		}
+	}
+	return nil
+}
+
+// stages builds the library stages for a list of filters, the configured stages of each
+// filter are found via the given lookup function.
+func (r loader) stages(filters []string, lookup func(string) []config.ResponseStage) []ResponseStage {
+	stages := []ResponseStage{}
+	for _, filter := range filters {
+		for _, s := range lookup(filter) {
+			stages = append(stages, ResponseStage{
+				Type:        s.Type,
+				Lookup:      s.Lookup,
+				Filter:      filter,
+				StageSet:    r.stageSet(s),
+				Frequency:   s.Frequency,
+				SampleRate:  s.SampleRate,
+				Decimate:    s.Decimate,
+				Gain:        s.Gain,
+				Correction:  s.Correction,
+				Delay:       s.Delay,
+				InputUnits:  s.InputUnits,
+				OutputUnits: s.OutputUnits,
+			})
+		}
+	}
+	return stages
+}
+
+func (r loader) library(responses map[string]config.Response) *Library {
+	lib := Library{
+		SensorModels:     make(map[string]SensorModel),
+		DataloggerModels: make(map[string]DataloggerModel),
+	}
+
+	for k, v := range r.DataloggerModel {
+		lib.DataloggerModels[k] = DataloggerModel{
+			Name:         k,
+			Type:         v.Type,
+			Description:  v.Description,
+			Manufacturer: v.Manufacturer,
+			Vendor:       v.Vendor,
+		}
+	}
+
+	for k, v := range r.SensorModel {
+		model := SensorModel{
+			Name:         k,
+			Type:         v.Type,
+			Description:  v.Description,
+			Manufacturer: v.Manufacturer,
+			Vendor:       v.Vendor,
+			Components:   []SensorComponent{},
+		}
+		for _, c := range v.Components {
+			model.Components = append(model.Components, SensorComponent{Azimuth: c.Azimuth, Dip: c.Dip})
+		}
+		lib.SensorModels[k] = model
+	}
+
+	var keys []string
+	for k := range responses {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		response := Response{
+			Name:        k,
+			Sensors:     []Sensor{},
+			Dataloggers: []Datalogger{},
+		}
+		for _, s := range responses[k].Sensors {
+			response.Sensors = append(response.Sensors, Sensor{
+				SensorList: append([]string{}, s.Sensors...),
+				FilterList: append([]string{}, s.Filters...),
+				Stages:     r.stages(s.Filters, r.SensorStages),
+				Channels:   s.Channels,
+				Reversed:   s.Reversed,
+			})
+		}
+		for _, d := range responses[k].Dataloggers {
+			response.Dataloggers = append(response.Dataloggers, Datalogger{
+				DataloggerList: append([]string{}, d.Dataloggers...),
+				Type:           d.Type,
+				Label:          d.Label,
+				SampleRate:     d.SampleRate,
+				Frequency:      d.Frequency,
+				StorageFormat:  d.StorageFormat,
+				ClockDrift:     d.ClockDrift,
+				FilterList:     append([]string{}, d.Filters...),
+				Stages:         r.stages(d.Filters, r.DataloggerStages),
+				Reversed:       d.Reversed,
+			})
+		}
+		lib.Responses = append(lib.Responses, response)
+	}
+
+	return &lib
+}
+
+// LoadFS builds a response Library from all the YAML files found in the file system, the
+// files are merged in lexical order with later definitions replacing earlier ones.
+func LoadFS(fsys fs.FS) (*Library, error) {
+	info, err := config.LoadFS(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	responses, err := info.Responses()
+	if err != nil {
+		return nil, err
+	}
+
+	return loader{info}.library(responses), nil
+}
+
+// LoadDir builds a response Library from the YAML files found under the given directory, this
+// is the run time equivalent of the generated Responses, SensorModels and DataloggerModels.
+func LoadDir(dir string) (*Library, error) {
+	if _, err := os.Stat(dir); err != nil {
+		return nil, err
+	}
+	return LoadFS(os.DirFS(dir))
+}
@@ -1,6 +1,6 @@
 package resp
 
-//go:generate bash -c "go run generate/*.go | gofmt -s > auto.go; test -s auto.go || rm auto.go"
+//go:generate bash -c "go run ./generate | gofmt -s > auto.go; test -s auto.go || rm auto.go"
 
 import (
 	"math"
@@ -135,6 +135,107 @@ func (s Stream) Gain() float64 {
 	return gain
 }
 
+// Orientation returns the azimuth and dip of a stream component given the installed sensor azimuth,
+// horizontal components are rotated for any reversed polarity, whereas vertical components are flipped.
+func (s Stream) Orientation(component SensorComponent, azimuth float64, reversed bool) (float64, float64) {
+	dip := component.Dip
+	azimuth += component.Azimuth
+
+	// only rotate horizontal components
+	if dip == 0.0 {
+		if s.Sensor.Reversed {
+			azimuth += 180.0
+		}
+		if s.Datalogger.Reversed {
+			azimuth += 180.0
+		}
+		if reversed {
+			azimuth += 180.0
+		}
+		// avoid negative zero
+		dip = 0.0
+		// bring into positive range
+		for azimuth < 0.0 {
+			azimuth += 360.0
+		}
+		for azimuth >= 360.0 {
+			azimuth -= 360.0
+		}
+	} else {
+		if s.Sensor.Reversed {
+			dip *= -1.0
+		}
+		if s.Datalogger.Reversed {
+			dip *= -1.0
+		}
+		if reversed {
+			dip *= -1.0
+		}
+		// no azimuth on verticals
+		azimuth = 0.0
+	}
+
+	return azimuth, dip
+}
+
+// SelectFilter returns the streams with any alternatives removed that do not use the given datalogger filter, streams
+// are only treated as alternatives if they share the same label and sample rate, those without a choice are all kept.
+func SelectFilter(streams []Stream, filter string) []Stream {
+	type alternative struct {
+		label string
+		rate  float64
+	}
+
+	uses := func(s Stream) bool {
+		for _, f := range s.Datalogger.FilterList {
+			if f == filter {
+				return true
+			}
+		}
+		return false
+	}
+
+	found := make(map[alternative]bool)
+	for _, s := range streams {
+		if uses(s) {
+			found[alternative{label: s.Label, rate: s.SampleRate}] = true
+		}
+	}
+
+	var selected []Stream
+	for _, s := range streams {
+		if found[alternative{label: s.Label, rate: s.SampleRate}] && !uses(s) {
+			continue
+		}
+		selected = append(selected, s)
+	}
+
+	return selected
+}
+
+// Scale returns a copy of the stream with the datalogger A2D stage adjusted for the deployed settings, a bit
+// weight in volts per count, if greater than zero, replaces the model gain which is then scaled by any pre-amp gain.
+func (s Stream) Scale(gain, bitWeight float64) Stream {
+	stages := make([]ResponseStage, len(s.Datalogger.Stages))
+	copy(stages, s.Datalogger.Stages)
+
+	for i, stage := range stages {
+		if _, ok := stage.StageSet.(A2D); !ok {
+			continue
+		}
+		if bitWeight > 0.0 {
+			stages[i].Gain = 1.0 / bitWeight
+		}
+		stages[i].Gain *= gain
+
+		break
+	}
+
+	s.Datalogger.Stages = stages
+
+	return s
+}
+
 type StageSet interface {
 	GetType() string
 }
@@ -1,7 +1,12 @@
 package resp
 
 import (
+	"math"
+	"math/cmplx"
+	"reflect"
+	"strings"
 	"testing"
+	"testing/fstest"
 )
 
 func TestResp_StageSet(t *testing.T) {
@@ -22,3 +27,300 @@ func TestResp_StageSet(t *testing.T) {
 		}
 	}
 }
+
+func TestResp_FIRCoefficients(t *testing.T) {
+
+	var tests = []struct {
+		f FIR
+		c []float64
+	}{
+		{FIR{Symmetry: SymmetryNone, Factors: []float64{1, 2, 3}}, []float64{1, 2, 3}},
+		{FIR{Symmetry: SymmetryEven, Factors: []float64{1, 2, 3}}, []float64{1, 2, 3, 3, 2, 1}},
+		{FIR{Symmetry: SymmetryOdd, Factors: []float64{1, 2, 3}}, []float64{1, 2, 3, 2, 1}},
+	}
+
+	for _, test := range tests {
+		c := test.f.Coefficients()
+		if len(c) != len(test.c) {
+			t.Fatalf("invalid number of coefficients: got %d, expected %d", len(c), len(test.c))
+		}
+		for i := range c {
+			if c[i] != test.c[i] {
+				t.Errorf("invalid coefficient %d: got %g, expected %g", i, c[i], test.c[i])
+			}
+		}
+	}
+}
+
+func TestResp_StreamResponse(t *testing.T) {
+
+	streams := Streams("Q330/3", "CMG-3ESP")
+	if len(streams) == 0 {
+		t.Fatal("expected Q330/3 and CMG-3ESP streams")
+	}
+
+	for _, stream := range streams {
+		// avoid normalisation frequencies close to the nyquist where the anti-alias filters roll off
+		if !(stream.Datalogger.Frequency < 0.25*stream.Datalogger.SampleRate) {
+			continue
+		}
+		h := stream.Response([]float64{stream.Datalogger.Frequency})
+		if g := cmplx.Abs(h[0]); math.Abs(g-stream.Gain())/stream.Gain() > 0.01 {
+			t.Errorf("invalid %s response amplitude at %g Hz: got %g, expected %g", stream.Datalogger.Label, stream.Datalogger.Frequency, g, stream.Gain())
+		}
+	}
+
+	stage := ResponseStage{
+		StageSet: PAZ{Code: PZFunctionLaplaceRadiansPerSecond, Poles: []complex128{complex(-2.0*math.Pi, 0.0)}},
+		Gain:     10.0,
+	}
+	if g := cmplx.Abs(stage.Response(1.0)); math.Abs(g-10.0/math.Sqrt2) > 1.0e-9 {
+		t.Errorf("invalid single pole amplitude at the corner frequency: got %g, expected %g", g, 10.0/math.Sqrt2)
+	}
+	if p := cmplx.Phase(stage.Response(1.0)); math.Abs(p+math.Pi/4.0) > 1.0e-9 {
+		t.Errorf("invalid single pole phase at the corner frequency: got %g, expected %g", p, -math.Pi/4.0)
+	}
+}
+
+func TestResp_Check(t *testing.T) {
+
+	stream := Stream{
+		Sensor: Sensor{
+			SensorList: []string{"TEST"},
+			Stages: []ResponseStage{{
+				StageSet:    PAZ{Code: PZFunctionLaplaceRadiansPerSecond, Poles: []complex128{complex(-2.0*math.Pi, 0.0)}},
+				Frequency:   10.0,
+				Gain:        100.0,
+				InputUnits:  "m/s",
+				OutputUnits: "V",
+			}},
+		},
+		Datalogger: Datalogger{
+			DataloggerList: []string{"TEST"},
+			Frequency:      10.0,
+			Stages: []ResponseStage{{
+				StageSet:    A2D{},
+				Gain:        1000.0,
+				InputUnits:  "A",
+				OutputUnits: "COUNTS",
+			}, {
+				StageSet:    FIR{Factors: []float64{0.5, 0.5}, Symmetry: SymmetryEven},
+				InputUnits:  "count",
+				OutputUnits: "COUNTS",
+			}},
+		},
+	}
+
+	problems := CheckStream(stream, 0.01)
+	if len(problems) != 3 {
+		t.Fatalf("invalid number of problems: got %d, expected %d: %v", len(problems), 3, problems)
+	}
+	if problems[0].Stage != 2 || problems[1].Stage != 3 || problems[2].Stage != 0 {
+		t.Errorf("invalid problem stages: got %d, %d and %d, expected %d, %d and %d", problems[0].Stage, problems[1].Stage, problems[2].Stage, 2, 3, 0)
+	}
+
+	stream.Sensor.Stages[0].Frequency = 1.0
+	stream.Datalogger.Stages[0].InputUnits = "VOLTS"
+	stream.Datalogger.Stages[1].StageSet = FIR{Factors: []float64{0.5, 0.5}}
+
+	problems = CheckStream(stream, 0.01)
+	if len(problems) != 2 {
+		t.Fatalf("invalid number of problems: got %d, expected %d: %v", len(problems), 2, problems)
+	}
+	if problems[0].Stage != 1 || problems[1].Stage != 0 {
+		t.Errorf("invalid problem stages: got %d and %d, expected %d and %d", problems[0].Stage, problems[1].Stage, 1, 0)
+	}
+
+	// a zero at the origin cannot be normalised at zero frequency
+	stream.Sensor.Stages[0].StageSet = PAZ{Code: PZFunctionLaplaceRadiansPerSecond, Zeros: []complex128{0.0}}
+	stream.Sensor.Stages[0].Frequency = 0.0
+
+	problems = CheckStream(stream, 0.01)
+	if len(problems) != 2 {
+		t.Fatalf("invalid number of problems: got %d, expected %d: %v", len(problems), 2, problems)
+	}
+	if !strings.Contains(problems[0].Message, "cannot be normalised") || problems[1].Stage != 0 {
+		t.Errorf("invalid problems: %v", problems)
+	}
+}
+
+func TestResp_LoadDir(t *testing.T) {
+
+	lib, err := LoadDir("responses")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(lib.SensorModels, SensorModels) {
+		t.Error("loaded sensor models do not match the generated models")
+	}
+	if !reflect.DeepEqual(lib.DataloggerModels, DataloggerModels) {
+		t.Error("loaded datalogger models do not match the generated models")
+	}
+	if len(lib.Responses) != len(Responses) {
+		t.Fatalf("invalid number of responses: got %d, expected %d", len(lib.Responses), len(Responses))
+	}
+	for i := range Responses {
+		if !reflect.DeepEqual(lib.Responses[i], Responses[i]) {
+			t.Errorf("loaded response %q does not match the generated response", Responses[i].Name)
+		}
+	}
+
+	if _, err := LoadDir("testdata/missing"); err == nil {
+		t.Error("expected missing directory error")
+	}
+}
+
+func TestResp_StreamOrientation(t *testing.T) {
+
+	stream := Stream{Sensor: Sensor{Reversed: true}}
+
+	if az, dip := stream.Orientation(SensorComponent{Azimuth: 90.0}, 300.0, false); az != 210.0 || dip != 0.0 {
+		t.Errorf("invalid horizontal orientation: got %g/%g, expected %g/%g", az, dip, 210.0, 0.0)
+	}
+	if az, dip := stream.Orientation(SensorComponent{Dip: -90.0}, 300.0, true); az != 0.0 || dip != -90.0 {
+		t.Errorf("invalid vertical orientation: got %g/%g, expected %g/%g", az, dip, 0.0, -90.0)
+	}
+}
+
+func TestResp_LoadProfiles(t *testing.T) {
+
+	config := `---
+filter:
+  TEST:
+  - type: a2d
+    lookup: A2D
+    samplerate: 100
+    inputunits: V
+    outputunits: count
+profile:
+  TEST:
+  - dataloggers:
+    - TEST/1
+    label: HH
+    samplerate: 100
+    filters:
+    - TEST
+    reversed: true
+response:
+  Test Response:
+    dataloggers:
+    - profile: TEST
+      dataloggers:
+      - TEST/2
+      reversed: false
+`
+
+	lib, err := LoadFS(fstest.MapFS{"test.yaml": &fstest.MapFile{Data: []byte(config)}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lib.Responses) != 1 || len(lib.Responses[0].Dataloggers) != 1 {
+		t.Fatalf("invalid responses: %v", lib.Responses)
+	}
+	d := lib.Responses[0].Dataloggers[0]
+	if d.Label != "HH" || d.Reversed || !reflect.DeepEqual(d.DataloggerList, []string{"TEST/2"}) {
+		t.Errorf("invalid profile expansion: %s %v %v", d.Label, d.Reversed, d.DataloggerList)
+	}
+
+	missing := strings.Replace(config, "- profile: TEST", "- profile: MISSING", 1)
+	if _, err := LoadFS(fstest.MapFS{"test.yaml": &fstest.MapFile{Data: []byte(missing)}}); err == nil {
+		t.Error("expected unknown profile error")
+	}
+}
+
+func TestResp_StreamsIndex(t *testing.T) {
+
+	// the index should give the same streams, in the same order, as a full search
+	search := func(datalogger, sensor string) []Stream {
+		var streams []Stream
+		for _, response := range Responses {
+			for _, lo := range response.Dataloggers {
+				for _, dl := range lo.DataloggerList {
+					if dl != datalogger {
+						continue
+					}
+					for _, se := range response.Sensors {
+						for _, s := range se.SensorList {
+							if s != sensor {
+								continue
+							}
+							streams = append(streams, Stream{Datalogger: lo, Sensor: se, Components: SensorModels[sensor].Components})
+						}
+					}
+				}
+			}
+		}
+		return streams
+	}
+
+	for datalogger := range DataloggerModels {
+		for sensor := range SensorModels {
+			if !reflect.DeepEqual(Streams(datalogger, sensor), search(datalogger, sensor)) {
+				t.Errorf("stream mismatch for %s and %s", datalogger, sensor)
+			}
+		}
+	}
+
+	for _, s := range StreamsBySampleRate("Q330HR/6", "STS-2", 100) {
+		if s.SampleRate != 100 {
+			t.Errorf("invalid sample rate: %g", s.SampleRate)
+		}
+	}
+	for _, s := range StreamsByLabel("Q330HR/6", "STS-2", "HH") {
+		if s.Label != "HH" {
+			t.Errorf("invalid label: %s", s.Label)
+		}
+	}
+	if len(StreamsByLabel("Q330HR/6", "STS-2", "HH")) == 0 {
+		t.Error("missing HH streams")
+	}
+}
+
+func TestResp_StreamScale(t *testing.T) {
+
+	stream := Stream{
+		Datalogger: Datalogger{
+			Stages: []ResponseStage{{
+				StageSet: A2D{},
+				Gain:     400000.0,
+			}, {
+				StageSet: FIR{Gain: 1.0},
+			}},
+		},
+	}
+
+	if g := stream.Scale(20.0, 0.0).Gain(); math.Abs(g-8.0e6) > 1.0e-6 {
+		t.Errorf("invalid pre-amp gain: %g", g)
+	}
+	if g := stream.Scale(2.0, 1.0e-6).Gain(); math.Abs(g-2.0e6) > 1.0e-6 {
+		t.Errorf("invalid bit weight gain: %g", g)
+	}
+	if g := stream.Gain(); g != 400000.0 {
+		t.Errorf("original stream should be unchanged: %g", g)
+	}
+}
+
+func TestResp_SelectFilter(t *testing.T) {
+
+	streams := []Stream{
+		{Datalogger: Datalogger{Label: "EH", SampleRate: 100, FilterList: []string{"FIR-100"}}},
+		{Datalogger: Datalogger{Label: "EH", SampleRate: 100, FilterList: []string{"FIR-100-PREAMP"}}},
+		{Datalogger: Datalogger{Label: "LH", SampleRate: 1, FilterList: []string{"FIR-1"}}},
+	}
+
+	selected := SelectFilter(streams, "FIR-100-PREAMP")
+	if len(selected) != 2 {
+		t.Fatalf("invalid number of selected streams: got %d, expected %d", len(selected), 2)
+	}
+	if f := selected[0].Datalogger.FilterList; !reflect.DeepEqual(f, []string{"FIR-100-PREAMP"}) {
+		t.Errorf("invalid selected filter: %v", f)
+	}
+	if l := selected[1].Label; l != "LH" {
+		t.Errorf("streams without an alternative should be kept: %s", l)
+	}
+
+	if n := len(SelectFilter(streams, "MISSING")); n != len(streams) {
+		t.Errorf("unknown filters should keep all streams: got %d, expected %d", n, len(streams))
+	}
+}
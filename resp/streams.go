@@ -1,27 +1,77 @@
 package resp
 
-// Provide a stream list for a given datalogger and sensor pair
-func Streams(datalogger, sensor string) []Stream {
-	var streams []Stream
+import (
+	"sync"
+)
 
-	// make sure we know about the sensor model - for the components
-	model, ok := SensorModels[sensor]
-	if !ok {
-		return nil
+// streamKey is used to index the streams of a datalogger and sensor pair.
+type streamKey struct {
+	datalogger string
+	sensor     string
+}
+
+// Library holds a complete set of response configurations, either the compiled in
+// values or those loaded at run time via LoadDir.
+type Library struct {
+	Responses        []Response
+	SensorModels     map[string]SensorModel
+	DataloggerModels map[string]DataloggerModel
+
+	// streams are indexed on first use
+	once  sync.Once
+	index map[streamKey][]Stream
+}
+
+// library is the package level Library used by Streams and its related functions.
+var library struct {
+	sync.Mutex
+	lib *Library
+}
+
+// defaultLibrary returns the package level Library, building it from the compiled in values if needed.
+func defaultLibrary() *Library {
+	library.Lock()
+	defer library.Unlock()
+
+	if library.lib == nil {
+		library.lib = &Library{
+			Responses:        Responses,
+			SensorModels:     SensorModels,
+			DataloggerModels: DataloggerModels,
+		}
 	}
+	return library.lib
+}
+
+// SetLibrary replaces the package level Responses, SensorModels and DataloggerModels,
+// this allows tools to use a run time response configuration in place of the compiled one.
+func SetLibrary(lib *Library) {
+	library.Lock()
+	defer library.Unlock()
+
+	Responses = lib.Responses
+	SensorModels = lib.SensorModels
+	DataloggerModels = lib.DataloggerModels
+
+	library.lib = lib
+}
 
-	for _, response := range Responses {
+// build indexes every datalogger and sensor pair, the streams are kept in configuration order.
+func (l *Library) build() {
+	l.index = make(map[streamKey][]Stream)
+
+	for _, response := range l.Responses {
 		for _, lo := range response.Dataloggers {
 			for _, dataloggerModel := range lo.DataloggerList {
-				if datalogger != dataloggerModel {
-					continue
-				}
 				for _, se := range response.Sensors {
 					for _, sensorModel := range se.SensorList {
-						if sensor != sensorModel {
+						// make sure we know about the sensor model - for the components
+						model, ok := l.SensorModels[sensorModel]
+						if !ok {
 							continue
 						}
-						streams = append(streams, Stream{
+						key := streamKey{datalogger: dataloggerModel, sensor: sensorModel}
+						l.index[key] = append(l.index[key], Stream{
 							Datalogger: lo,
 							Sensor:     se,
 							Components: model.Components,
@@ -31,6 +81,55 @@ func Streams(datalogger, sensor string) []Stream {
 			}
 		}
 	}
+}
+
+// Provide a stream list for a given datalogger and sensor pair
+func (l *Library) Streams(datalogger, sensor string) []Stream {
+	l.once.Do(l.build)
 
+	streams := l.index[streamKey{datalogger: datalogger, sensor: sensor}]
+	if len(streams) == 0 {
+		return nil
+	}
+
+	return append([]Stream{}, streams...)
+}
+
+// StreamsByLabel provides the streams for a given datalogger and sensor pair that use the given channel label.
+func (l *Library) StreamsByLabel(datalogger, sensor, label string) []Stream {
+	var streams []Stream
+	for _, s := range l.Streams(datalogger, sensor) {
+		if s.Label != label {
+			continue
+		}
+		streams = append(streams, s)
+	}
+	return streams
+}
+
+// StreamsBySampleRate provides the streams for a given datalogger and sensor pair that record at the given sample rate.
+func (l *Library) StreamsBySampleRate(datalogger, sensor string, rate float64) []Stream {
+	var streams []Stream
+	for _, s := range l.Streams(datalogger, sensor) {
+		if s.SampleRate != rate {
+			continue
+		}
+		streams = append(streams, s)
+	}
 	return streams
 }
+
+// Provide a stream list for a given datalogger and sensor pair
+func Streams(datalogger, sensor string) []Stream {
+	return defaultLibrary().Streams(datalogger, sensor)
+}
+
+// StreamsByLabel provides the streams for a given datalogger and sensor pair that use the given channel label.
+func StreamsByLabel(datalogger, sensor, label string) []Stream {
+	return defaultLibrary().StreamsByLabel(datalogger, sensor, label)
+}
+
+// StreamsBySampleRate provides the streams for a given datalogger and sensor pair that record at the given sample rate.
+func StreamsBySampleRate(datalogger, sensor string, rate float64) []Stream {
+	return defaultLibrary().StreamsBySampleRate(datalogger, sensor, rate)
+}
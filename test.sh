@@ -10,6 +10,9 @@ error_handler () {
 trap error_handler ERR
 
 go test ./meta
+go test ./metadb
+go test ./resp
+go test ./resp/generate
 go test ./tides
 go test ./tests
 go test ./tools/stationxml
@@ -20,6 +23,12 @@ go test ./tools/spectra
 go test ./tools/chart
 go test ./tools/impact
 go test ./tools/rinexml
+go test ./tools/respimport
+go test ./tools/pod
+go test ./tools/sacpz
+go test ./tools/tide
+go test ./tools/tidefit
+go test ./tools/detide
 
 exit $errcount
 
@@ -33,6 +33,7 @@ func TestConsistency(t *testing.T) {
 		"recorders":    {f: "../install/recorders.csv", l: &meta.InstalledRecorderList{}},
 		"sensors":      {f: "../install/sensors.csv", l: &meta.InstalledSensorList{}},
 		"firmware":     {f: "../install/firmware.csv", l: &meta.FirmwareHistoryList{}},
+		"gains":        {f: "../install/gains.csv", l: &meta.DataloggerGainList{}},
 		"streams":      {f: "../install/streams.csv", l: &meta.StreamList{}},
 		"networks":     {f: "../network/networks.csv", l: &meta.NetworkList{}},
 		"stations":     {f: "../network/stations.csv", l: &meta.StationList{}},
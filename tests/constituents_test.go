@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/GeoNet/delta/meta"
+	"github.com/GeoNet/delta/tides"
 )
 
 func TestConstituents(t *testing.T) {
@@ -22,6 +23,14 @@ func TestConstituents(t *testing.T) {
 		}
 	})
 
+	t.Run("check for known constituents", func(t *testing.T) {
+		if errs, ok := tides.Validate(constituents).(tides.ConstituentErrors); ok {
+			for _, err := range errs {
+				t.Error(err.Error())
+			}
+		}
+	})
+
 	t.Run("check for missing constituent gauges", func(t *testing.T) {
 		var list meta.GaugeList
 		loadListFile(t, "../environment/gauges.csv", &list)
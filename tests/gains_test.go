@@ -0,0 +1,89 @@
+package delta_test
+
+import (
+	"testing"
+
+	"github.com/GeoNet/delta/meta"
+	"github.com/GeoNet/delta/resp"
+)
+
+func TestGains(t *testing.T) {
+
+	var gains meta.DataloggerGainList
+	loadListFile(t, "../install/gains.csv", &gains)
+
+	t.Run("check for datalogger gain overlaps", func(t *testing.T) {
+		for i := 0; i < len(gains); i++ {
+			for j := i + 1; j < len(gains); j++ {
+				if gains[i].Model != gains[j].Model || gains[i].Serial != gains[j].Serial {
+					continue
+				}
+				if !gains[i].Overlaps(gains[j].Span) {
+					continue
+				}
+				t.Errorf("datalogger gain %s / %s has overlap between %s and %s",
+					gains[i].Model, gains[i].Serial, gains[j].Start.Format(meta.DateTimeFormat), gains[i].End.Format(meta.DateTimeFormat))
+			}
+		}
+	})
+
+	t.Run("check for valid datalogger gains", func(t *testing.T) {
+		for _, g := range gains {
+			if !(g.Gain > 0.0) {
+				t.Errorf("datalogger gain %s / %s has an invalid gain: %g", g.Model, g.Serial, g.Gain)
+			}
+			if g.HasBitWeight() && !(g.BitWeight > 0.0) {
+				t.Errorf("datalogger gain %s / %s has an invalid bit weight: %g", g.Model, g.Serial, g.BitWeight)
+			}
+		}
+	})
+
+	t.Run("check for known datalogger filters", func(t *testing.T) {
+		for _, g := range gains {
+			if g.Filter == "" {
+				continue
+			}
+			var found bool
+			for _, r := range resp.Responses {
+				for _, d := range r.Dataloggers {
+					for _, m := range d.DataloggerList {
+						if m != g.Model {
+							continue
+						}
+						for _, f := range d.FilterList {
+							if f == g.Filter {
+								found = true
+							}
+						}
+					}
+				}
+			}
+			if found {
+				continue
+			}
+			t.Errorf("datalogger gain %s / %s has an unknown filter: %s", g.Model, g.Serial, g.Filter)
+		}
+	})
+
+	t.Run("check for deployed datalogger gains", func(t *testing.T) {
+		var dataloggers meta.DeployedDataloggerList
+		loadListFile(t, "../install/dataloggers.csv", &dataloggers)
+
+		for _, g := range gains {
+			var found bool
+			for _, d := range dataloggers {
+				if d.Model != g.Model || d.Serial != g.Serial {
+					continue
+				}
+				if !d.Overlaps(g.Span) {
+					continue
+				}
+				found = true
+			}
+			if found {
+				continue
+			}
+			t.Errorf("unable to find deployed datalogger for gain: %s [%s]", g.Model, g.Serial)
+		}
+	})
+}
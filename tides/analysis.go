@@ -0,0 +1,203 @@
+package tides
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Standard is the default set of constituents used for harmonic analysis, given in order of
+// importance. When two constituents cannot be separated over the length of a record the
+// later one in the list is dropped.
+var Standard = []string{
+	"Z0", "M2", "S2", "N2", "K1", "O1", "K2", "P1", "Q1", "SA", "M4", "MS4", "MN4", "NU2", "MU2",
+	"2N2", "L2", "T2", "J1", "M3", "MK3", "SK3", "M6", "2MS6", "2MN6", "MO3", "S4", "LDA2", "EPS2",
+	"H1", "H2", "S1", "2Q1", "NO1", "OO1", "MF", "MM", "MSF", "MSM", "SSA", "SO3", "2MK5", "2SM6",
+	"MSN2", "M8",
+}
+
+// Observation holds an observed sea level at a given time.
+type Observation struct {
+	Time  time.Time
+	Value float64
+}
+
+// Valid returns the observations that have finite values, gaps in a record are usually
+// represented by NaN values.
+func Valid(observations []Observation) []Observation {
+	var valid []Observation
+	for _, o := range observations {
+		if math.IsNaN(o.Value) || math.IsInf(o.Value, 0) {
+			continue
+		}
+		valid = append(valid, o)
+	}
+	return valid
+}
+
+// Select returns the constituents, in the given order of importance, that can be resolved from a
+// record of the given length using the Rayleigh criterion. A constituent is kept only if its speed
+// differs from each of those already kept by at least the factor times one cycle over the record.
+// Unknown constituents will return an error.
+func Select(names []string, length time.Duration, factor float64) ([]string, error) {
+	hours := length.Hours()
+	if !(hours > 0.0) {
+		return nil, fmt.Errorf("invalid record length: %v", length)
+	}
+
+	// the smallest resolvable difference in speed, in degrees per hour
+	limit := factor * 360.0 / hours
+
+	var selected []string
+	for _, n := range names {
+		h, ok := lookup(n)
+		if !ok {
+			return nil, fmt.Errorf("unknown tidal constituent: %s", n)
+		}
+		resolved := true
+		for _, s := range selected {
+			if v, _ := lookup(s); math.Abs(h.speed()-v.speed()) < limit {
+				resolved = false
+				break
+			}
+		}
+		if resolved {
+			selected = append(selected, n)
+		}
+	}
+
+	return selected, nil
+}
+
+// Analyse uses a least squares fit to find the amplitude and lag of each of the given constituents from a
+// set of observations. The lags are referenced to the analysis time zone, given in the same way as the Tide
+// TimeZone parameter, and nodal corrections are applied at each observation time. A Z0 constituent is always
+// included to represent the mean level, the constituents are returned in order of their speeds. Missing
+// observations, those with NaN or infinite values, are ignored.
+func Analyse(observations []Observation, names []string, timeZone float64) ([]Constituent, error) {
+	observations = Valid(observations)
+
+	var list []harmonic
+	found := make(map[string]string)
+	for _, n := range names {
+		h, ok := lookup(n)
+		if !ok {
+			return nil, fmt.Errorf("unknown tidal constituent: %s", n)
+		}
+		// aliases refer to the same constituent and would give a singular fit
+		if v, ok := found[h.name]; ok {
+			return nil, fmt.Errorf("duplicate tidal constituent: %s duplicates %s as %s", n, v, h.name)
+		}
+		found[h.name] = n
+
+		if h.name == "Z0" {
+			continue
+		}
+		list = append(list, h)
+	}
+
+	size := 2*len(list) + 1
+	if len(observations) < size {
+		return nil, fmt.Errorf("not enough observations to fit %d constituents: %d", len(list)+1, len(observations))
+	}
+
+	zone := time.Duration(Tide{TimeZone: timeZone}.Zone() * float64(time.Hour))
+
+	// build the normal equations, the first unknown is the mean level followed by the
+	// cosine and sine terms of each constituent
+	ata := make([][]float64, size)
+	for i := range ata {
+		ata[i] = make([]float64, size)
+	}
+	atb := make([]float64, size)
+
+	row := make([]float64, size)
+	for _, o := range observations {
+		local := newAstro(o.Time.Add(zone))
+
+		row[0] = 1.0
+		for j, h := range list {
+			f, u := h.correction(local)
+			v := radians(h.argument(local) + u)
+			row[2*j+1], row[2*j+2] = f*math.Cos(v), f*math.Sin(v)
+		}
+
+		for i := 0; i < size; i++ {
+			for k := i; k < size; k++ {
+				ata[i][k] += row[i] * row[k]
+			}
+			atb[i] += row[i] * o.Value
+		}
+	}
+	for i := 0; i < size; i++ {
+		for k := 0; k < i; k++ {
+			ata[i][k] = ata[k][i]
+		}
+	}
+
+	x, err := solve(ata, atb)
+	if err != nil {
+		return nil, err
+	}
+
+	constituents := []Constituent{{Name: "Z0", Amplitude: x[0]}}
+	for j, h := range list {
+		a, b := x[2*j+1], x[2*j+2]
+		constituents = append(constituents, Constituent{
+			Name:      h.name,
+			Amplitude: math.Hypot(a, b),
+			Lag:       angle(math.Atan2(b, a) * 180.0 / math.Pi),
+		})
+	}
+
+	SortConstituents(constituents)
+
+	return constituents, nil
+}
+
+// solve uses a Cholesky decomposition to solve the symmetric positive definite system a x = b.
+func solve(a [][]float64, b []float64) ([]float64, error) {
+	n := len(b)
+
+	l := make([][]float64, n)
+	for i := range l {
+		l[i] = make([]float64, n)
+	}
+
+	for i := 0; i < n; i++ {
+		for j := 0; j <= i; j++ {
+			sum := a[i][j]
+			for k := 0; k < j; k++ {
+				sum -= l[i][k] * l[j][k]
+			}
+			switch {
+			case i != j:
+				l[i][j] = sum / l[j][j]
+			case sum > 0.0:
+				l[i][i] = math.Sqrt(sum)
+			default:
+				return nil, fmt.Errorf("unable to separate the constituents, the observations may be too short or sparse")
+			}
+		}
+	}
+
+	y := make([]float64, n)
+	for i := 0; i < n; i++ {
+		sum := b[i]
+		for k := 0; k < i; k++ {
+			sum -= l[i][k] * y[k]
+		}
+		y[i] = sum / l[i][i]
+	}
+
+	x := make([]float64, n)
+	for i := n - 1; i >= 0; i-- {
+		sum := y[i]
+		for k := i + 1; k < n; k++ {
+			sum -= l[k][i] * x[k]
+		}
+		x[i] = sum / l[i][i]
+	}
+
+	return x, nil
+}
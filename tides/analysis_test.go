@@ -0,0 +1,107 @@
+package tides
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSelect(t *testing.T) {
+
+	// a month of data can resolve S2 and M2 but not S2 and K2
+	names, err := Select([]string{"Z0", "M2", "S2", "K2", "K1", "P1"}, 30*24*time.Hour, 1.0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s, e := strings.Join(names, ","), "Z0,M2,S2,K1"; s != e {
+		t.Errorf("invalid selection: got %s, expected %s", s, e)
+	}
+
+	// but a year can
+	names, err = Select([]string{"Z0", "M2", "S2", "K2", "K1", "P1"}, 365*24*time.Hour, 1.0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s, e := strings.Join(names, ","), "Z0,M2,S2,K2,K1,P1"; s != e {
+		t.Errorf("invalid selection: got %s, expected %s", s, e)
+	}
+
+	if _, err := Select([]string{"XX"}, time.Hour, 1.0); err == nil {
+		t.Error("expected an error for an unknown constituent")
+	}
+}
+
+func TestAnalyse(t *testing.T) {
+
+	tide := Tide{
+		Code:     "TEST",
+		TimeZone: 180.0,
+		Constituents: []Constituent{
+			{Name: "Z0", Amplitude: 150.0},
+			{Name: "O1", Amplitude: 2.5, Lag: 120.0},
+			{Name: "K1", Amplitude: 7.0, Lag: 175.0},
+			{Name: "N2", Amplitude: 23.0, Lag: 172.0},
+			{Name: "M2", Amplitude: 114.0, Lag: 204.0},
+			{Name: "S2", Amplitude: 17.0, Lag: 280.0},
+			{Name: "M4", Amplitude: 1.5, Lag: 30.0},
+		},
+	}
+
+	start := time.Date(2021, time.June, 1, 0, 0, 0, 0, time.UTC)
+	predictions, err := tide.PredictRange(start, start.Add(60*24*time.Hour), 30*time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// include missing values and a gap of several days
+	var observations []Observation
+	for i, p := range predictions {
+		switch {
+		case i%97 == 0:
+			observations = append(observations, Observation{Time: p.Time, Value: math.NaN()})
+		case i > 1000 && i < 1300:
+		default:
+			observations = append(observations, Observation{Time: p.Time, Value: p.Height})
+		}
+	}
+
+	constituents, err := Analyse(observations, []string{"M2", "S2", "N2", "K1", "O1", "M4"}, tide.TimeZone)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(constituents) != len(tide.Constituents) {
+		t.Fatalf("invalid number of constituents: got %d, expected %d", len(constituents), len(tide.Constituents))
+	}
+
+	for i, c := range tide.Constituents {
+		r := constituents[i]
+		if r.Name != c.Name {
+			t.Errorf("invalid constituent %d: got %s, expected %s", i, r.Name, c.Name)
+			continue
+		}
+		if math.Abs(r.Amplitude-c.Amplitude) > 1.0e-6 {
+			t.Errorf("invalid %s amplitude: got %g, expected %g", c.Name, r.Amplitude, c.Amplitude)
+		}
+		if d := math.Abs(angle(r.Lag-c.Lag+180.0) - 180.0); d > 1.0e-4 {
+			t.Errorf("invalid %s lag: got %g, expected %g", c.Name, r.Lag, c.Lag)
+		}
+	}
+
+	if _, err := Analyse(observations[:4], []string{"M2", "S2"}, tide.TimeZone); err == nil {
+		t.Error("expected an error for too few observations")
+	}
+
+	// missing values do not count towards the number of observations
+	missing := []Observation{observations[1], observations[2], {Time: observations[3].Time, Value: math.NaN()}, {Time: observations[4].Time, Value: math.Inf(1)}, observations[5]}
+	if _, err := Analyse(missing, []string{"M2", "S2"}, tide.TimeZone); err == nil {
+		t.Error("expected an error for too few valid observations")
+	}
+
+	// constituents given more than once, either directly or via an alias, cannot be fitted
+	for _, names := range [][]string{{"M2", "S2", "M2"}, {"N2", "LDA2", "LAMBDA2"}, {"Z0", "M2", "A0"}} {
+		if _, err := Analyse(observations, names, tide.TimeZone); err == nil {
+			t.Errorf("expected an error for duplicate constituents: %v", names)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package tides
+
+import (
+	"math"
+	"time"
+)
+
+// j2000 is the reference epoch used for the astronomical arguments.
+var j2000 = time.Date(2000, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+// astro holds the mean astronomical arguments, in degrees, used to build the equilibrium
+// tidal arguments from the Doodson numbers of each constituent.
+type astro struct {
+	tau float64 // mean lunar time
+	s   float64 // mean longitude of the moon
+	h   float64 // mean longitude of the sun
+	p   float64 // longitude of the lunar perigee
+	n   float64 // negative longitude of the moon's ascending node
+	p1  float64 // longitude of the solar perigee
+}
+
+// angle returns a value reduced to the range [0, 360) degrees.
+func angle(v float64) float64 {
+	if v = math.Mod(v, 360.0); v < 0.0 {
+		return v + 360.0
+	}
+	return v
+}
+
+// radians converts an angle from degrees.
+func radians(v float64) float64 {
+	return v * math.Pi / 180.0
+}
+
+// newAstro returns the astronomical arguments at a given time, the polynomial terms are those
+// given by Meeus with the time measured in Julian centuries from J2000.
+func newAstro(at time.Time) astro {
+	t := at.Sub(j2000).Hours() / (24.0 * 36525.0)
+
+	s := 218.3164477 + 481267.88123421*t - 0.0015786*t*t
+	h := 280.46646 + 36000.76983*t + 0.0003032*t*t
+	p := 83.3532465 + 4069.0137287*t - 0.01032*t*t
+	n := 125.04452 - 1934.136261*t + 0.0020708*t*t
+	p1 := 282.93735 + 1.71946*t + 0.00046*t*t
+
+	// the mean solar time is measured from lower transit
+	ut := at.UTC()
+	hours := float64(ut.Hour()) + float64(ut.Minute())/60.0 + (float64(ut.Second())+float64(ut.Nanosecond())/1.0e9)/3600.0
+
+	return astro{
+		tau: angle(15.0*hours + h - s),
+		s:   angle(s),
+		h:   angle(h),
+		p:   angle(p),
+		n:   angle(-n),
+		p1:  angle(p1),
+	}
+}
+
+// node returns the longitude of the moon's ascending node.
+func (a astro) node() float64 {
+	return angle(-a.n)
+}
+
+// nodal describes the basic lunar node corrections from which those of the other constituents are built.
+type nodal int
+
+const (
+	nodalMm nodal = iota
+	nodalMf
+	nodalO1
+	nodalK1
+	nodalJ1
+	nodalOO1
+	nodalM2
+	nodalK2
+)
+
+// correction returns the nodal amplitude factor and phase correction, in degrees, for a basic constituent
+// using the series approximations in the longitude of the moon's node given by Schureman.
+func (a astro) correction(c nodal) (float64, float64) {
+	n := radians(a.node())
+
+	switch c {
+	case nodalMm:
+		return 1.0 - 0.130*math.Cos(n), 0.0
+	case nodalMf:
+		return 1.043 + 0.414*math.Cos(n),
+			-23.7*math.Sin(n) + 2.7*math.Sin(2.0*n) - 0.4*math.Sin(3.0*n)
+	case nodalO1:
+		return 1.009 + 0.187*math.Cos(n) - 0.015*math.Cos(2.0*n),
+			10.8*math.Sin(n) - 1.3*math.Sin(2.0*n) + 0.2*math.Sin(3.0*n)
+	case nodalK1:
+		return 1.006 + 0.115*math.Cos(n) - 0.009*math.Cos(2.0*n),
+			-8.9*math.Sin(n) + 0.7*math.Sin(2.0*n)
+	case nodalJ1:
+		return 1.013 + 0.168*math.Cos(n) - 0.017*math.Cos(2.0*n),
+			-12.9*math.Sin(n) + 1.3*math.Sin(2.0*n)
+	case nodalOO1:
+		return 1.138 + 0.658*math.Cos(n) + 0.067*math.Cos(2.0*n),
+			-36.7*math.Sin(n) + 4.0*math.Sin(2.0*n) - 0.3*math.Sin(3.0*n)
+	case nodalM2:
+		return 1.0004 - 0.0373*math.Cos(n) + 0.0002*math.Cos(2.0*n),
+			-2.14 * math.Sin(n)
+	case nodalK2:
+		return 1.0241 + 0.2863*math.Cos(n) + 0.0083*math.Cos(2.0*n) - 0.0015*math.Cos(3.0*n),
+			-17.74*math.Sin(n) + 0.68*math.Sin(2.0*n) - 0.04*math.Sin(3.0*n)
+	default:
+		return 1.0, 0.0
+	}
+}
@@ -0,0 +1,147 @@
+package tides
+
+import (
+	"math"
+	"strings"
+)
+
+// the rate of change of the astronomical arguments, in degrees per hour
+const (
+	tauSpeed = 14.4920521
+	sSpeed   = 0.5490165
+	hSpeed   = 0.0410686
+	pSpeed   = 0.0046418
+	nSpeed   = 0.0022064
+	p1Speed  = 0.0000019
+)
+
+// doodson holds the multiples of the astronomical arguments that make up a constituent.
+type doodson [6]int
+
+// factor is a basic nodal correction raised to a power, negative powers are used for constituents
+// that are formed by subtracting another constituent and so subtract the phase correction.
+type factor struct {
+	nodal nodal
+	power float64
+}
+
+// harmonic describes how the equilibrium argument and nodal corrections of a tidal constituent
+// are built. The offset is the phase, in degrees, added to the equilibrium argument.
+type harmonic struct {
+	name    string
+	aliases []string
+	doodson doodson
+	offset  float64
+	factors []factor
+}
+
+// speed returns the angular speed of the constituent in degrees per hour.
+func (h harmonic) speed() float64 {
+	return float64(h.doodson[0])*tauSpeed +
+		float64(h.doodson[1])*sSpeed +
+		float64(h.doodson[2])*hSpeed +
+		float64(h.doodson[3])*pSpeed +
+		float64(h.doodson[4])*nSpeed +
+		float64(h.doodson[5])*p1Speed
+}
+
+// argument returns the equilibrium argument of the constituent, in degrees.
+func (h harmonic) argument(a astro) float64 {
+	return angle(float64(h.doodson[0])*a.tau +
+		float64(h.doodson[1])*a.s +
+		float64(h.doodson[2])*a.h +
+		float64(h.doodson[3])*a.p +
+		float64(h.doodson[4])*a.n +
+		float64(h.doodson[5])*a.p1 +
+		h.offset)
+}
+
+// correction returns the nodal amplitude factor and phase correction, in degrees, of the constituent.
+func (h harmonic) correction(a astro) (float64, float64) {
+	f, u := 1.0, 0.0
+	for _, v := range h.factors {
+		kf, ku := a.correction(v.nodal)
+		f *= math.Pow(kf, math.Abs(v.power))
+		u += v.power * ku
+	}
+	return f, u
+}
+
+// harmonics holds the constituents known to the prediction code, indexed by name and any aliases.
+var harmonics = func() map[string]harmonic {
+	list := []harmonic{
+		// mean sea level
+		{name: "Z0", aliases: []string{"A0"}},
+
+		// long period
+		{name: "SA", doodson: doodson{0, 0, 1, 0, 0, 0}},
+		{name: "SSA", doodson: doodson{0, 0, 2, 0, 0, 0}},
+		{name: "MSM", doodson: doodson{0, 1, -2, 1, 0, 0}, factors: []factor{{nodalMm, 1}}},
+		{name: "MM", doodson: doodson{0, 1, 0, -1, 0, 0}, factors: []factor{{nodalMm, 1}}},
+		{name: "MSF", doodson: doodson{0, 2, -2, 0, 0, 0}, factors: []factor{{nodalM2, -1}}},
+		{name: "MF", doodson: doodson{0, 2, 0, 0, 0, 0}, factors: []factor{{nodalMf, 1}}},
+
+		// diurnal
+		{name: "2Q1", doodson: doodson{1, -3, 0, 2, 0, 0}, offset: -90, factors: []factor{{nodalO1, 1}}},
+		{name: "Q1", doodson: doodson{1, -2, 0, 1, 0, 0}, offset: -90, factors: []factor{{nodalO1, 1}}},
+		{name: "O1", doodson: doodson{1, -1, 0, 0, 0, 0}, offset: -90, factors: []factor{{nodalO1, 1}}},
+		{name: "NO1", doodson: doodson{1, 0, 0, 1, 0, 0}, offset: 90, factors: []factor{{nodalM2, 1}, {nodalO1, -1}}},
+		{name: "P1", doodson: doodson{1, 1, -2, 0, 0, 0}, offset: -90},
+		{name: "S1", doodson: doodson{1, 1, -1, 0, 0, 0}, offset: 180},
+		{name: "K1", doodson: doodson{1, 1, 0, 0, 0, 0}, offset: 90, factors: []factor{{nodalK1, 1}}},
+		{name: "J1", doodson: doodson{1, 2, 0, -1, 0, 0}, offset: 90, factors: []factor{{nodalJ1, 1}}},
+		{name: "OO1", doodson: doodson{1, 3, 0, 0, 0, 0}, offset: 90, factors: []factor{{nodalOO1, 1}}},
+
+		// semi-diurnal
+		{name: "EPS2", aliases: []string{"EPSILON2"}, doodson: doodson{2, -3, 2, 1, 0, 0}, factors: []factor{{nodalM2, 1}}},
+		{name: "2N2", doodson: doodson{2, -2, 0, 2, 0, 0}, factors: []factor{{nodalM2, 1}}},
+		{name: "MU2", doodson: doodson{2, -2, 2, 0, 0, 0}, factors: []factor{{nodalM2, 1}}},
+		{name: "N2", doodson: doodson{2, -1, 0, 1, 0, 0}, factors: []factor{{nodalM2, 1}}},
+		{name: "NU2", doodson: doodson{2, -1, 2, -1, 0, 0}, factors: []factor{{nodalM2, 1}}},
+		{name: "H1", doodson: doodson{2, 0, -1, 0, 0, 1}, offset: 180, factors: []factor{{nodalM2, 1}}},
+		{name: "M2", doodson: doodson{2, 0, 0, 0, 0, 0}, factors: []factor{{nodalM2, 1}}},
+		{name: "H2", doodson: doodson{2, 0, 1, 0, 0, -1}, factors: []factor{{nodalM2, 1}}},
+		{name: "MKS2", doodson: doodson{2, 0, 2, 0, 0, 0}, factors: []factor{{nodalM2, 1}, {nodalK2, 1}}},
+		{name: "LDA2", aliases: []string{"LAMBDA2", "LAM2"}, doodson: doodson{2, 1, -2, 1, 0, 0}, offset: 180, factors: []factor{{nodalM2, 1}}},
+		{name: "L2", doodson: doodson{2, 1, 0, -1, 0, 0}, offset: 180, factors: []factor{{nodalM2, 1}}},
+		{name: "T2", doodson: doodson{2, 2, -3, 0, 0, 1}},
+		{name: "S2", doodson: doodson{2, 2, -2, 0, 0, 0}},
+		{name: "R2", doodson: doodson{2, 2, -1, 0, 0, -1}, offset: 180},
+		{name: "K2", doodson: doodson{2, 2, 0, 0, 0, 0}, factors: []factor{{nodalK2, 1}}},
+		{name: "MSN2", doodson: doodson{2, 3, -2, -1, 0, 0}, factors: []factor{{nodalM2, 1}, {nodalM2, -1}}},
+
+		// ter-diurnal
+		{name: "MO3", doodson: doodson{3, -1, 0, 0, 0, 0}, offset: -90, factors: []factor{{nodalM2, 1}, {nodalO1, 1}}},
+		{name: "M3", doodson: doodson{3, 0, 0, 0, 0, 0}, offset: 180, factors: []factor{{nodalM2, 1.5}}},
+		{name: "SO3", doodson: doodson{3, 1, -2, 0, 0, 0}, offset: -90, factors: []factor{{nodalO1, 1}}},
+		{name: "MK3", doodson: doodson{3, 1, 0, 0, 0, 0}, offset: 90, factors: []factor{{nodalM2, 1}, {nodalK1, 1}}},
+		{name: "SK3", doodson: doodson{3, 3, -2, 0, 0, 0}, offset: 90, factors: []factor{{nodalK1, 1}}},
+
+		// shallow water
+		{name: "MN4", doodson: doodson{4, -1, 0, 1, 0, 0}, factors: []factor{{nodalM2, 2}}},
+		{name: "M4", doodson: doodson{4, 0, 0, 0, 0, 0}, factors: []factor{{nodalM2, 2}}},
+		{name: "MS4", doodson: doodson{4, 2, -2, 0, 0, 0}, factors: []factor{{nodalM2, 1}}},
+		{name: "S4", doodson: doodson{4, 4, -4, 0, 0, 0}},
+		{name: "2MK5", doodson: doodson{5, 1, 0, 0, 0, 0}, offset: 90, factors: []factor{{nodalM2, 2}, {nodalK1, 1}}},
+		{name: "2MN6", doodson: doodson{6, -1, 0, 1, 0, 0}, factors: []factor{{nodalM2, 3}}},
+		{name: "M6", doodson: doodson{6, 0, 0, 0, 0, 0}, factors: []factor{{nodalM2, 3}}},
+		{name: "2MS6", doodson: doodson{6, 2, -2, 0, 0, 0}, factors: []factor{{nodalM2, 2}}},
+		{name: "2SM6", doodson: doodson{6, 4, -4, 0, 0, 0}, factors: []factor{{nodalM2, 1}}},
+		{name: "M8", doodson: doodson{8, 0, 0, 0, 0, 0}, factors: []factor{{nodalM2, 4}}},
+	}
+
+	harmonics := make(map[string]harmonic)
+	for _, h := range list {
+		harmonics[h.name] = h
+		for _, a := range h.aliases {
+			harmonics[a] = h
+		}
+	}
+	return harmonics
+}()
+
+// lookup returns the details of a constituent given either its name or an alias.
+func lookup(name string) (harmonic, bool) {
+	h, ok := harmonics[strings.ToUpper(strings.TrimSpace(name))]
+	return h, ok
+}
@@ -0,0 +1,37 @@
+package tides
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestCorrection(t *testing.T) {
+
+	// constituents formed by subtracting another take the opposite phase correction
+	var tests = map[string]struct {
+		base  string
+		power float64
+	}{
+		"MSF": {"M2", -1.0},
+		"M4":  {"M2", 2.0},
+		"MN4": {"M2", 2.0},
+	}
+
+	for _, at := range []time.Time{
+		time.Date(2015, time.January, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2020, time.July, 1, 0, 0, 0, 0, time.UTC),
+	} {
+		a := newAstro(at)
+		for k, v := range tests {
+			f, u := harmonics[k].correction(a)
+			bf, bu := harmonics[v.base].correction(a)
+			if e := math.Pow(bf, math.Abs(v.power)); math.Abs(f-e) > 1.0e-9 {
+				t.Errorf("invalid %s nodal factor at %s: got %g, expected %g", k, at, f, e)
+			}
+			if e := v.power * bu; math.Abs(u-e) > 1.0e-9 {
+				t.Errorf("invalid %s phase correction at %s: got %g, expected %g", k, at, u, e)
+			}
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package tides
+
+import (
+	"fmt"
+	"time"
+)
+
+// Calibration describes how recorded values are converted into sea levels in the units of the constituent
+// amplitudes. The Factor and Bias match the installed sensor scale values and are applied in the recorded
+// units, the Units multiplier then converts the scaled values into the constituent units, e.g. 100 for
+// values recorded in metres as the delta constituents are given in centimetres. A zero Factor or Units
+// is taken as unity, while the Datum is the offset, in constituent units, between the recording datum
+// and the datum of the constituents.
+type Calibration struct {
+	Factor float64
+	Bias   float64
+	Units  float64
+	Datum  float64
+}
+
+// Level returns the sea level for the given recorded value.
+func (c Calibration) Level(value float64) float64 {
+	factor, units := c.Factor, c.Units
+	if factor == 0.0 {
+		factor = 1.0
+	}
+	if units == 0.0 {
+		units = 1.0
+	}
+	return units*(factor*value+c.Bias) + c.Datum
+}
+
+// Residual holds the difference between an observed and predicted sea level at a given time.
+type Residual struct {
+	Time      time.Time
+	Observed  float64
+	Predicted float64
+	Residual  float64
+}
+
+// Detide returns the residuals after removing the predicted tide from the observations, these are first
+// calibrated to give sea levels in the units of the constituent amplitudes. Missing observations, those
+// with NaN or infinite values, are skipped and so can be recognised as gaps in the residuals.
+func (t Tide) Detide(observations []Observation, calibration Calibration) ([]Residual, error) {
+	list, err := t.prepare()
+	if err != nil {
+		return nil, err
+	}
+
+	var residuals []Residual
+	for _, o := range Valid(observations) {
+		observed, predicted := calibration.Level(o.Value), t.height(list, o.Time)
+
+		residuals = append(residuals, Residual{
+			Time:      o.Time,
+			Observed:  observed,
+			Predicted: predicted,
+			Residual:  observed - predicted,
+		})
+	}
+
+	return residuals, nil
+}
+
+// Detide returns the residuals after removing the predicted tide from the observations for the given gauge code.
+func Detide(code string, observations []Observation, calibration Calibration) ([]Residual, error) {
+	tide := Lookup(code)
+	if tide == nil {
+		return nil, fmt.Errorf("unknown tide gauge: %s", code)
+	}
+	return tide.Detide(observations, calibration)
+}
+
+// Segments splits the residuals into continuous runs, a new run is started whenever the time between
+// consecutive residuals is greater than the gap.
+func Segments(residuals []Residual, gap time.Duration) [][]Residual {
+	var segments [][]Residual
+
+	var start int
+	for i := 1; i <= len(residuals); i++ {
+		if i < len(residuals) && residuals[i].Time.Sub(residuals[i-1].Time) <= gap {
+			continue
+		}
+		segments = append(segments, residuals[start:i])
+		start = i
+	}
+
+	return segments
+}
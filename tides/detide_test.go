@@ -0,0 +1,63 @@
+package tides
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestDetide(t *testing.T) {
+
+	tide := Tide{
+		Code:     "TEST",
+		TimeZone: 360.0,
+		Constituents: []Constituent{
+			{Name: "Z0", Amplitude: 100.0},
+			{Name: "S2", Amplitude: 10.0},
+		},
+	}
+
+	at := time.Date(2020, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	// recorded values need scaling and shifting to give the sea level
+	calibration := Calibration{Factor: 2.0, Bias: 1.0, Datum: 4.0}
+	observations := []Observation{
+		{Time: at, Value: 55.0},
+		{Time: at.Add(time.Hour), Value: math.NaN()},
+		{Time: at.Add(3 * time.Hour), Value: 50.0},
+		{Time: at.Add(4 * time.Hour), Value: 45.0},
+	}
+
+	residuals, err := tide.Detide(observations, calibration)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(residuals) != 3 {
+		t.Fatalf("invalid number of residuals: got %d, expected %d", len(residuals), 3)
+	}
+	for i, v := range []float64{5.0, 5.0, 0.0} {
+		if math.Abs(residuals[i].Residual-v) > 1.0e-6 {
+			t.Errorf("invalid residual %d: got %g, expected %g", i, residuals[i].Residual, v)
+		}
+		if d := residuals[i].Observed - residuals[i].Predicted - residuals[i].Residual; math.Abs(d) > 1.0e-9 {
+			t.Errorf("inconsistent residual %d: %g", i, d)
+		}
+	}
+
+	// the scale is applied before converting units
+	if v := (Calibration{Factor: 1.03, Bias: 0.1, Units: 100.0, Datum: -5.0}).Level(1.0); math.Abs(v-108.0) > 1.0e-9 {
+		t.Errorf("invalid calibrated level: got %g, expected %g", v, 108.0)
+	}
+
+	segments := Segments(residuals, time.Hour)
+	if len(segments) != 2 || len(segments[0]) != 1 || len(segments[1]) != 2 {
+		t.Errorf("invalid segments: %v", segments)
+	}
+	if s := Segments(nil, time.Hour); s != nil {
+		t.Errorf("invalid empty segments: %v", s)
+	}
+
+	if _, err := Detide("XXXX", observations, calibration); err == nil {
+		t.Error("expected an error for an unknown gauge")
+	}
+}
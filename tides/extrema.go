@@ -0,0 +1,101 @@
+package tides
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	// extremaStep is the sampling interval used to bracket each high and low water.
+	extremaStep = 10 * time.Minute
+	// extremaTolerance is the precision to which each high and low water time is found.
+	extremaTolerance = time.Second
+)
+
+// Extremum holds the time and height of a predicted high or low water.
+type Extremum struct {
+	Time   time.Time
+	Height float64
+	High   bool
+}
+
+// Label returns a description of the extremum type.
+func (e Extremum) Label() string {
+	if e.High {
+		return "High"
+	}
+	return "Low"
+}
+
+// refine uses a golden section search to find the time of the turning point within the bracket.
+func (t Tide) refine(list []harmonic, lower, upper time.Time, high bool) Extremum {
+	sign := -1.0
+	if high {
+		sign = 1.0
+	}
+
+	ratio := (math.Sqrt(5.0) - 1.0) / 2.0
+	at := func(v float64) time.Time {
+		return lower.Add(time.Duration(v * float64(time.Second)))
+	}
+	value := func(v float64) float64 {
+		return sign * t.height(list, at(v))
+	}
+
+	a, b := 0.0, upper.Sub(lower).Seconds()
+	c, d := b-ratio*(b-a), a+ratio*(b-a)
+	fc, fd := value(c), value(d)
+	for b-a > extremaTolerance.Seconds() {
+		if fc > fd {
+			b, d, fd = d, c, fc
+			c = b - ratio*(b-a)
+			fc = value(c)
+		} else {
+			a, c, fc = c, d, fd
+			d = a + ratio*(b-a)
+			fd = value(d)
+		}
+	}
+
+	v := at((a + b) / 2.0).Round(extremaTolerance)
+
+	return Extremum{
+		Time:   v,
+		Height: t.height(list, v),
+		High:   high,
+	}
+}
+
+// Extrema returns the predicted high and low waters between the start and end times. Turning points are
+// first bracketed by sampling the predictions and then refined to the nearest second.
+func (t Tide) Extrema(start, end time.Time) ([]Extremum, error) {
+	list, err := t.prepare()
+	if err != nil {
+		return nil, err
+	}
+
+	var extrema []Extremum
+
+	previous, current := start.Add(-extremaStep), start
+	h0, h1 := t.height(list, previous), t.height(list, current)
+	for current.Before(end.Add(extremaStep)) {
+		next := current.Add(extremaStep)
+		h2 := t.height(list, next)
+
+		switch {
+		case h1 > h0 && h1 >= h2:
+			if e := t.refine(list, previous, next, true); !e.Time.Before(start) && e.Time.Before(end) {
+				extrema = append(extrema, e)
+			}
+		case h1 < h0 && h1 <= h2:
+			if e := t.refine(list, previous, next, false); !e.Time.Before(start) && e.Time.Before(end) {
+				extrema = append(extrema, e)
+			}
+		}
+
+		previous, current = current, next
+		h0, h1 = h1, h2
+	}
+
+	return extrema, nil
+}
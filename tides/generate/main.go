@@ -7,7 +7,7 @@ import (
 	"os"
 	"text/template"
 
-	"github.com/GeoNet/delta/internal/metadb"
+	"github.com/GeoNet/delta/metadb"
 	"github.com/GeoNet/delta/meta"
 )
 
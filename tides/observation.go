@@ -0,0 +1,63 @@
+package tides
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ReadObservations reads sea level observations from csv formatted input. Each row is expected to hold
+// an RFC3339 time followed by the observed value, an optional header line is skipped. Empty values are
+// taken as missing and are given as NaN. The observations are returned in time order.
+func ReadObservations(rd io.Reader) ([]Observation, error) {
+	r := csv.NewReader(rd)
+	r.FieldsPerRecord = -1
+	r.Comment = '#'
+	r.TrimLeadingSpace = true
+
+	var observations []Observation
+	for first := true; ; first = false {
+		row, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := r.FieldPos(0)
+		if len(row) < 2 {
+			return nil, fmt.Errorf("line %d: expected a time and value, found %d fields", line, len(row))
+		}
+
+		at, err := time.Parse(time.RFC3339, strings.TrimSpace(row[0]))
+		if err != nil {
+			// allow for an initial header
+			if first {
+				continue
+			}
+			return nil, fmt.Errorf("line %d: invalid time %q: %v", line, row[0], err)
+		}
+		value := math.NaN()
+		if v := strings.TrimSpace(row[1]); v != "" {
+			if value, err = strconv.ParseFloat(v, 64); err != nil {
+				return nil, fmt.Errorf("line %d: invalid value %q: %v", line, row[1], err)
+			}
+		}
+
+		observations = append(observations, Observation{
+			Time:  at,
+			Value: value,
+		})
+	}
+
+	sort.SliceStable(observations, func(i, j int) bool {
+		return observations[i].Time.Before(observations[j].Time)
+	})
+
+	return observations, nil
+}
@@ -0,0 +1,26 @@
+package tides
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReadObservations(t *testing.T) {
+
+	input := "Time,Value\n2021-06-01T00:10:00Z,1.5\n# comment\n2021-06-01T00:00:00Z, 2.0\n"
+
+	observations, err := ReadObservations(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(observations) != 2 {
+		t.Fatalf("invalid number of observations: got %d, expected 2", len(observations))
+	}
+	if v := observations[0].Value; v != 2.0 {
+		t.Errorf("invalid first observation: got %g, expected 2", v)
+	}
+
+	if _, err := ReadObservations(strings.NewReader("Time,Value\n2021-06-01T00:00:00Z,bad\n")); err == nil {
+		t.Error("expected an error for an invalid value")
+	}
+}
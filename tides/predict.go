@@ -0,0 +1,84 @@
+package tides
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Prediction holds a predicted tide height at a given time.
+type Prediction struct {
+	Time   time.Time
+	Height float64
+}
+
+// prepare returns the prediction details for each of the tidal constituents, an error is returned
+// if any constituents are not known.
+func (t Tide) prepare() ([]harmonic, error) {
+	var list []harmonic
+	for _, c := range t.Constituents {
+		h, ok := lookup(c.Name)
+		if !ok {
+			return nil, fmt.Errorf("unknown tidal constituent for %s: %s", t.Code, c.Name)
+		}
+		list = append(list, h)
+	}
+	return list, nil
+}
+
+// height returns the predicted tide height at a given time using the prepared constituent details.
+func (t Tide) height(list []harmonic, at time.Time) float64 {
+	// the constituent lags are referenced to the analysis time zone
+	local := newAstro(at.Add(time.Duration(t.Zone() * float64(time.Hour))))
+
+	var height float64
+	for i, c := range t.Constituents {
+		if list[i].name == "Z0" {
+			height += c.Amplitude
+			continue
+		}
+		f, u := list[i].correction(local)
+		height += f * c.Amplitude * math.Cos(radians(list[i].argument(local)+u-c.Lag))
+	}
+
+	return height
+}
+
+// Predict returns the predicted tide heights at the given times, the heights are given in the units
+// of the constituent amplitudes and include the Z0 mean sea level.
+func (t Tide) Predict(times []time.Time) ([]float64, error) {
+	list, err := t.prepare()
+	if err != nil {
+		return nil, err
+	}
+
+	heights := make([]float64, len(times))
+	for i, at := range times {
+		heights[i] = t.height(list, at)
+	}
+
+	return heights, nil
+}
+
+// PredictRange returns the predicted tide heights from the start time, at the given step, up to
+// but not including the end time.
+func (t Tide) PredictRange(start, end time.Time, step time.Duration) ([]Prediction, error) {
+	if step <= 0 {
+		return nil, fmt.Errorf("invalid prediction step: %v", step)
+	}
+
+	list, err := t.prepare()
+	if err != nil {
+		return nil, err
+	}
+
+	var predictions []Prediction
+	for at := start; at.Before(end); at = at.Add(step) {
+		predictions = append(predictions, Prediction{
+			Time:   at,
+			Height: t.height(list, at),
+		})
+	}
+
+	return predictions, nil
+}
@@ -0,0 +1,281 @@
+package tides
+
+import (
+	"encoding/csv"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSpeed(t *testing.T) {
+
+	var speeds = map[string]float64{
+		"M2": 28.9841042,
+		"S2": 30.0000000,
+		"N2": 28.4397295,
+		"K1": 15.0410686,
+		"O1": 13.9430356,
+		"K2": 30.0821373,
+		"M4": 57.9682084,
+		"MF": 1.0980331,
+	}
+	for k, v := range speeds {
+		if s := harmonics[k].speed(); math.Abs(s-v) > 1.0e-6 {
+			t.Errorf("invalid %s speed: got %.7f, expected %.7f", k, s, v)
+		}
+	}
+
+	// the equilibrium arguments should advance at the constituent speeds
+	at := time.Date(2020, time.March, 1, 0, 0, 0, 0, time.UTC)
+	for k, h := range harmonics {
+		d := angle(h.argument(newAstro(at.Add(time.Hour))) - h.argument(newAstro(at)))
+		if math.Abs(d-angle(h.speed())) > 1.0e-4 {
+			t.Errorf("invalid %s argument rate: got %g, expected %g", k, d, angle(h.speed()))
+		}
+	}
+}
+
+func TestPredict(t *testing.T) {
+
+	tide := Tide{
+		Code:     "TEST",
+		TimeZone: 360.0,
+		Constituents: []Constituent{
+			{Name: "Z0", Amplitude: 100.0},
+			{Name: "S2", Amplitude: 10.0},
+		},
+	}
+
+	at := time.Date(2020, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	heights, err := tide.Predict([]time.Time{at, at.Add(3 * time.Hour), at.Add(6 * time.Hour)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, v := range []float64{110.0, 100.0, 90.0} {
+		if math.Abs(heights[i]-v) > 1.0e-6 {
+			t.Errorf("invalid prediction %d: got %g, expected %g", i, heights[i], v)
+		}
+	}
+
+	// a later time zone shifts the reference time
+	tide.TimeZone = 315.0
+	heights, err = tide.Predict([]time.Time{at})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(heights[0]-100.0) > 1.0e-6 {
+		t.Errorf("invalid time zone prediction: got %g, expected %g", heights[0], 100.0)
+	}
+
+	predictions, err := tide.PredictRange(at, at.Add(24*time.Hour), 10*time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(predictions) != 144 {
+		t.Errorf("invalid number of predictions: got %d, expected %d", len(predictions), 144)
+	}
+
+	tide.Constituents = append(tide.Constituents, Constituent{Name: "XX"})
+	if _, err := tide.Predict([]time.Time{at}); err == nil {
+		t.Error("expected unknown constituent error")
+	}
+
+	for _, g := range _tides {
+		t.Run("predict gauge: "+g.Code, func(t *testing.T) {
+			// the nodal corrections can increase amplitudes by up to a half
+			var mean, limit float64
+			for _, c := range g.Constituents {
+				switch c.Name {
+				case "Z0":
+					mean += c.Amplitude
+				default:
+					limit += 1.5 * c.Amplitude
+				}
+			}
+			predictions, err := g.PredictRange(at, at.Add(48*time.Hour), time.Hour)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, p := range predictions {
+				if math.Abs(p.Height-mean) > limit {
+					t.Errorf("invalid prediction at %s: %g", p.Time, p.Height)
+				}
+			}
+		})
+	}
+}
+
+func TestExtrema(t *testing.T) {
+
+	tide := Tide{
+		Code:     "TEST",
+		TimeZone: 360.0,
+		Constituents: []Constituent{
+			{Name: "Z0", Amplitude: 100.0},
+			{Name: "S2", Amplitude: 10.0, Lag: 45.0},
+		},
+	}
+
+	start := time.Date(2020, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	extrema, err := tide.Extrema(start, start.Add(24*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(extrema) != 4 {
+		t.Fatalf("invalid number of extrema: got %d, expected %d", len(extrema), 4)
+	}
+
+	// the S2 lag delays high water by an hour and a half
+	for i, e := range extrema {
+		expected := start.Add(90*time.Minute + time.Duration(i)*6*time.Hour)
+		if d := e.Time.Sub(expected); d < -time.Second || d > time.Second {
+			t.Errorf("invalid extremum %d time: got %s, expected %s", i, e.Time, expected)
+		}
+		if e.High != (i%2 == 0) {
+			t.Errorf("invalid extremum %d type: %s", i, e.Label())
+		}
+		if v := 100.0 + map[bool]float64{true: 10.0, false: -10.0}[e.High]; math.Abs(e.Height-v) > 1.0e-6 {
+			t.Errorf("invalid extremum %d height: got %g, expected %g", i, e.Height, v)
+		}
+	}
+
+	if l := (Tide{TimeZone: 180.0}).Location(); l.String() != "UTC+12" {
+		t.Errorf("invalid location: %s", l)
+	}
+}
+
+func TestReference(t *testing.T) {
+
+	files, err := filepath.Glob(filepath.Join("testdata", "linz", "*.csv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Skip("no published LINZ reference predictions found")
+	}
+
+	for _, file := range files {
+		code := strings.TrimSuffix(filepath.Base(file), ".csv")
+		t.Run("reference gauge: "+code, func(t *testing.T) {
+			tide := Lookup(code)
+			if tide == nil {
+				t.Fatalf("unknown gauge: %s", code)
+			}
+
+			f, err := os.Open(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			rows, err := csv.NewReader(f).ReadAll()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, row := range rows[1:] {
+				at, err := time.Parse(time.RFC3339, row[0])
+				if err != nil {
+					t.Fatal(err)
+				}
+				height, err := strconv.ParseFloat(row[1], 64)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				extrema, err := tide.Extrema(at.Add(-2*time.Hour), at.Add(2*time.Hour))
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				var found bool
+				for _, e := range extrema {
+					if e.Label() != row[2] {
+						continue
+					}
+					found = true
+
+					// the published times are given to the minute, and the heights to a centimetre in metres
+					if d := e.Time.Sub(at); d < -15*time.Minute || d > 15*time.Minute {
+						t.Errorf("invalid %s water time: got %s, expected %s", row[2], e.Time.In(at.Location()), at)
+					}
+					if d := e.Height/100.0 - height; math.Abs(d) > 0.1 {
+						t.Errorf("invalid %s water height at %s: got %.2f, expected %.2f", row[2], at, e.Height/100.0, height)
+					}
+				}
+				if !found {
+					t.Errorf("no %s water found near %s", row[2], at)
+				}
+			}
+		})
+	}
+}
+
+func TestEquilibrium(t *testing.T) {
+
+	// the hour angle of the mean sun and the mean longitudes of the moon and sun, in degrees,
+	// calculated independently of the astro code
+	longitudes := func(at time.Time) (float64, float64, float64) {
+		c := (float64(at.Unix())/86400.0 + 2440587.5 - 2451545.0) / 36525.0
+		hour := float64(at.Sub(at.Truncate(24*time.Hour))) / float64(time.Hour)
+		return 180.0 + 15.0*hour, 218.3164477 + 481267.88123421*c, 280.46646 + 36000.76983*c
+	}
+
+	// a constituent with no lag has its high waters when the equilibrium argument, as given by Schureman
+	// and referred to the time zone meridian, is zero; the tolerances allow for the nodal phase corrections
+	var tests = []struct {
+		name      string
+		zone      float64
+		tolerance float64
+		argument  func(T, s, h float64) float64
+	}{
+		{"M2", 180.0, 3.0, func(T, s, h float64) float64 { return 2.0*T - 2.0*s + 2.0*h }},
+		{"M2", 315.0, 3.0, func(T, s, h float64) float64 { return 2.0*T - 2.0*s + 2.0*h }},
+		{"K1", 315.0, 12.0, func(T, s, h float64) float64 { return T + h - 90.0 }},
+		{"O1", 315.0, 12.0, func(T, s, h float64) float64 { return T - 2.0*s + h + 90.0 }},
+	}
+
+	start := time.Date(2021, time.June, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, x := range tests {
+		t.Run(fmt.Sprintf("%s zone %g", x.name, x.zone), func(t *testing.T) {
+			tide := Tide{
+				TimeZone: x.zone,
+				Constituents: []Constituent{
+					{Name: "Z0", Amplitude: 100.0},
+					{Name: x.name, Amplitude: 10.0},
+				},
+			}
+
+			h, ok := lookup(x.name)
+			if !ok {
+				t.Fatalf("unknown constituent: %s", x.name)
+			}
+
+			extrema, err := tide.Extrema(start, start.Add(30*24*time.Hour))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(extrema) == 0 {
+				t.Fatal("no extrema found")
+			}
+
+			for _, e := range extrema {
+				if !e.High {
+					continue
+				}
+				T, s, l := longitudes(e.Time)
+				if d := math.Abs(angle(x.argument(T, s, l)+h.speed()*tide.Zone()+180.0) - 180.0); d > x.tolerance {
+					t.Errorf("invalid %s high water at %s: equilibrium phase differs by %.1f degrees", x.name, e.Time, d)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package tides
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/GeoNet/delta/meta"
+)
+
+// Harmonic describes a known tidal constituent. The Doodson numbers are the multiples of the astronomical
+// arguments, in the order of lunar time, the moon's and the sun's mean longitudes, the longitude of the
+// lunar perigee, the negative of the longitude of the lunar node, and the longitude of the solar perigee.
+// The Speed is given in degrees per hour.
+type Harmonic struct {
+	Name    string
+	Aliases []string
+	Doodson [6]int
+	Speed   float64
+}
+
+// newHarmonic converts the internal constituent details into its public form.
+func newHarmonic(h harmonic) Harmonic {
+	return Harmonic{
+		Name:    h.name,
+		Aliases: append([]string{}, h.aliases...),
+		Doodson: [6]int(h.doodson),
+		Speed:   h.speed(),
+	}
+}
+
+// less orders constituents by speed, with equal speeds ordered by name.
+func (h harmonic) less(v harmonic) bool {
+	switch {
+	case h.speed() < v.speed():
+		return true
+	case h.speed() > v.speed():
+		return false
+	default:
+		return h.name < v.name
+	}
+}
+
+// Harmonics returns the known tidal constituents in order of their speeds.
+func Harmonics() []Harmonic {
+	var list []harmonic
+	for k, h := range harmonics {
+		if k == h.name {
+			list = append(list, h)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].less(list[j]) })
+
+	var res []Harmonic
+	for _, h := range list {
+		res = append(res, newHarmonic(h))
+	}
+	return res
+}
+
+// LookupHarmonic returns the details of a tidal constituent given either its name or one of its aliases,
+// the lookup is not case sensitive.
+func LookupHarmonic(name string) (Harmonic, bool) {
+	h, ok := lookup(name)
+	if !ok {
+		return Harmonic{}, false
+	}
+	return newHarmonic(h), true
+}
+
+// SortConstituents orders the constituents by their speeds, any unknown constituents are placed last.
+func SortConstituents(constituents []Constituent) {
+	sort.SliceStable(constituents, func(i, j int) bool {
+		a, aok := lookup(constituents[i].Name)
+		b, bok := lookup(constituents[j].Name)
+		switch {
+		case aok && bok:
+			return a.less(b)
+		default:
+			return aok && !bok
+		}
+	})
+}
+
+// ConstituentError describes a problem with a gauge constituent.
+type ConstituentError struct {
+	Gauge   string
+	Number  int
+	Name    string
+	Message string
+}
+
+func (e ConstituentError) Error() string {
+	return fmt.Sprintf("gauge %s constituent %d (%s): %s", e.Gauge, e.Number, e.Name, e.Message)
+}
+
+// ConstituentErrors holds all the problems found when validating gauge constituents.
+type ConstituentErrors []ConstituentError
+
+func (e ConstituentErrors) Error() string {
+	var lines []string
+	for _, err := range e {
+		lines = append(lines, err.Error())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Validate checks the constituent rows against the known tidal constituents. Unknown constituents, those
+// given more than once for a gauge either by name or alias, or those not numbered in order of increasing
+// frequency are returned as ConstituentErrors.
+func Validate(constituents []meta.Constituent) error {
+	var errs ConstituentErrors
+
+	list := append([]meta.Constituent{}, constituents...)
+	sort.Sort(meta.ConstituentList(list))
+
+	found := make(map[string]map[string]int)
+	previous := make(map[string]harmonic)
+	for _, c := range list {
+		h, ok := lookup(c.Name)
+		if !ok {
+			errs = append(errs, ConstituentError{
+				Gauge:   c.Gauge,
+				Number:  c.Number,
+				Name:    c.Name,
+				Message: "unknown tidal constituent",
+			})
+			continue
+		}
+		if _, ok := found[c.Gauge]; !ok {
+			found[c.Gauge] = make(map[string]int)
+		}
+		if n, ok := found[c.Gauge][h.name]; ok {
+			errs = append(errs, ConstituentError{
+				Gauge:   c.Gauge,
+				Number:  c.Number,
+				Name:    c.Name,
+				Message: fmt.Sprintf("duplicates constituent %d as %s", n, h.name),
+			})
+			continue
+		}
+		found[c.Gauge][h.name] = c.Number
+
+		if p, ok := previous[c.Gauge]; ok && !p.less(h) {
+			errs = append(errs, ConstituentError{
+				Gauge:   c.Gauge,
+				Number:  c.Number,
+				Name:    c.Name,
+				Message: fmt.Sprintf("not in order of frequency, follows %s", p.name),
+			})
+		}
+		previous[c.Gauge] = h
+	}
+
+	if errs != nil {
+		return errs
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package tides
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/GeoNet/delta/meta"
+)
+
+func TestRegistry(t *testing.T) {
+
+	list := Harmonics()
+	if len(list) == 0 {
+		t.Fatal("no known constituents")
+	}
+	for i := 1; i < len(list); i++ {
+		if list[i].Speed < list[i-1].Speed {
+			t.Errorf("constituents not in order of speed: %s %s", list[i-1].Name, list[i].Name)
+		}
+	}
+
+	h, ok := LookupHarmonic("lambda2")
+	if !ok {
+		t.Fatal("unable to find constituent alias")
+	}
+	if h.Name != "LDA2" || h.Doodson != [6]int{2, 1, -2, 1, 0, 0} || math.Abs(h.Speed-29.4556253) > 1.0e-6 {
+		t.Errorf("invalid constituent details: %v", h)
+	}
+	if _, ok := LookupHarmonic("XX"); ok {
+		t.Error("expected unknown constituent")
+	}
+
+	constituents := []Constituent{{Name: "M2"}, {Name: "XX"}, {Name: "K1"}, {Name: "Z0"}, {Name: "SA"}}
+	SortConstituents(constituents)
+	var names []string
+	for _, c := range constituents {
+		names = append(names, c.Name)
+	}
+	if s, e := strings.Join(names, ","), "Z0,SA,K1,M2,XX"; s != e {
+		t.Errorf("invalid constituent order: got %s, expected %s", s, e)
+	}
+
+	var tests = map[string]struct {
+		constituents []meta.Constituent
+		errors       int
+	}{
+		"valid": {[]meta.Constituent{
+			{Gauge: "AAAA", Number: 1, Name: "Z0"},
+			{Gauge: "AAAA", Number: 2, Name: "K1"},
+			{Gauge: "AAAA", Number: 3, Name: "M2"},
+			{Gauge: "BBBB", Number: 1, Name: "M2"},
+		}, 0},
+		"unknown": {[]meta.Constituent{
+			{Gauge: "AAAA", Number: 1, Name: "Z0"},
+			{Gauge: "AAAA", Number: 2, Name: "XX"},
+		}, 1},
+		"duplicate": {[]meta.Constituent{
+			{Gauge: "AAAA", Number: 1, Name: "Z0"},
+			{Gauge: "AAAA", Number: 2, Name: "LDA2"},
+			{Gauge: "AAAA", Number: 3, Name: "LAMBDA2"},
+		}, 1},
+		"order": {[]meta.Constituent{
+			{Gauge: "AAAA", Number: 1, Name: "M2"},
+			{Gauge: "AAAA", Number: 2, Name: "K1"},
+		}, 1},
+	}
+
+	for k, v := range tests {
+		t.Run("validate "+k, func(t *testing.T) {
+			err := Validate(v.constituents)
+			switch errs, ok := err.(ConstituentErrors); {
+			case v.errors == 0 && err != nil:
+				t.Errorf("unexpected error: %v", err)
+			case v.errors > 0 && !ok:
+				t.Errorf("expected constituent errors, got: %v", err)
+			case len(errs) != v.errors:
+				t.Errorf("invalid number of errors: got %d, expected %d", len(errs), v.errors)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ package tides
 import (
 	"fmt"
 	"strings"
+	"time"
 )
 
 //go:generate bash -c "go run generate/*.go | gofmt -s > auto.go; test -s auto.go || rm auto.go"
@@ -44,6 +45,12 @@ func (t Tide) Zone() float64 {
 	return (360.0 - t.TimeZone) / 15.0
 }
 
+// Location returns the fixed time zone used for the tidal analysis, this can be used to
+// present predictions in the same local time as the constituents.
+func (t Tide) Location() *time.Location {
+	return time.FixedZone(fmt.Sprintf("UTC%+g", t.Zone()), int(t.Zone()*3600.0))
+}
+
 // Lookup will return a Tide pointer for the requested site code.
 // A nil pointer will be returned if a code cannot be found.
 func Lookup(code string) *Tide {
@@ -7,7 +7,7 @@ import (
 	"strings"
 	"sync"
 
-	"github.com/GeoNet/delta/internal/metadb"
+	"github.com/GeoNet/delta/metadb"
 	"github.com/GeoNet/delta/resp"
 )
 
@@ -38,7 +38,7 @@ type Altus struct {
 
 type Site struct {
 	SiteId    string  `xml:"site_id,attr"`
-	Height    float64 `xml:"height,attr"`
+	Height    string  `xml:"height,attr,omitempty"`
 	Latitude  float64 `xml:"latitude,attr"`
 	Longitude float64 `xml:"longitude,attr"`
 	Name      string  `xml:"name,attr"`
@@ -112,7 +112,7 @@ func buildSites(base string) ([]Site, error) {
 			case "FBA-ES-T-OBSIDIAN", "FBA-ES-T-BASALT", "FBA-ES-T-DECK", "FBA-23-DECK", "FBA-ES-T", "FBA-ES-T-ISO", "Kinemetrics SBEPI", "SDP":
 				switch installation.Datalogger.Model {
 				case "OBSIDIAN", "K2", "ETNA", "BASALT", "BASALT 8X DATALOGGER":
-					for _, response := range resp.Streams(installation.Datalogger.Model, installation.Sensor.Model) {
+					for _, response := range resp.StreamsBySampleRate(installation.Datalogger.Model, installation.Sensor.Model, 200) {
 						stream, err := db.StationLocationSamplingRateStartStream(
 							station.Code,
 							installation.Location,
@@ -125,10 +125,6 @@ func buildSites(base string) ([]Site, error) {
 							continue
 						}
 
-						if response.Datalogger.SampleRate != 200 {
-							continue
-						}
-
 						altuses = append(altuses, Altus{
 							Installed: installation.Start.Format("2006/01/02,15:04:05"),
 							Removed:   installation.End.Format("2006/01/02,15:04:05"),
@@ -335,7 +331,13 @@ func buildSites(base string) ([]Site, error) {
 			Name:      station.Name,
 			Latitude:  station.Latitude,
 			Longitude: station.Longitude,
-			Height:    station.Elevation,
+			Height: func() string {
+				// an unknown station elevation is left out rather than given as zero
+				if !station.HasElevation() {
+					return ""
+				}
+				return strconv.FormatFloat(station.Elevation, 'g', -1, 64)
+			}(),
 			Altuses:   altuses,
 		})
 
@@ -9,7 +9,7 @@ import (
 	"strings"
 	"time"
 
-	"github.com/GeoNet/delta/internal/metadb"
+	"github.com/GeoNet/delta/metadb"
 	"github.com/GeoNet/delta/resp"
 )
 
@@ -158,7 +158,7 @@ func buildAmplitudes(cfgs map[string]Config, base, dir string) ([]Amplitude, err
 					continue
 				}
 
-				for _, response := range resp.Streams(installation.Datalogger.Model, installation.Sensor.Model) {
+				for _, response := range installation.Streams() {
 					stream, err := db.StationLocationSamplingRateStartStream(
 						station.Code,
 						installation.Location,
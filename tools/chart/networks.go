@@ -4,7 +4,7 @@ import (
 	"strconv"
 	"time"
 
-	"github.com/GeoNet/delta/internal/metadb"
+	"github.com/GeoNet/delta/metadb"
 	"github.com/GeoNet/delta/meta"
 )
 
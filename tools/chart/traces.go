@@ -5,7 +5,7 @@ import (
 	"strconv"
 	"time"
 
-	"github.com/GeoNet/delta/internal/metadb"
+	"github.com/GeoNet/delta/metadb"
 	"github.com/GeoNet/delta/meta"
 )
 
@@ -5,7 +5,7 @@ import (
 	"strings"
 	"time"
 
-	"github.com/GeoNet/delta/internal/metadb"
+	"github.com/GeoNet/delta/metadb"
 )
 
 type Channels []metadb.Channel
@@ -6,7 +6,7 @@ import (
 	"strconv"
 	"strings"
 
-	"github.com/GeoNet/delta/internal/metadb"
+	"github.com/GeoNet/delta/metadb"
 	"github.com/GeoNet/delta/resp"
 )
 
@@ -100,7 +100,7 @@ func buildSites(base string) ([]Site, error) {
 			case "CUSP3C3", "CUSP3D", "CUSP3C", "CUSP3B", "CUSP3A":
 				switch installation.Datalogger.Model {
 				case "CUSP3C3", "CUSP3D", "CUSP3C", "CUSP3B", "CUSP3A":
-					for _, response := range resp.Streams(installation.Datalogger.Model, installation.Sensor.Model) {
+					for _, response := range resp.StreamsBySampleRate(installation.Datalogger.Model, installation.Sensor.Model, 200) {
 						stream, err := db.StationLocationSamplingRateStartStream(
 							station.Code,
 							installation.Location,
@@ -113,10 +113,6 @@ func buildSites(base string) ([]Site, error) {
 							continue
 						}
 
-						if response.Datalogger.SampleRate != 200 {
-							continue
-						}
-
 						cusps = append(cusps, Cusp{
 							Installed: installation.Start.Format("2006-01-02 15:04:05"),
 							Removed:   installation.End.Format("2006-01-02 15:04:05"),
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/GeoNet/delta/meta"
+	"github.com/GeoNet/delta/tides"
+)
+
+// calibration returns the sea level calibration for an observation time, the scale of any installed sensor
+// covering the time is used in place of the default factor and bias, the units and datum are always kept.
+func calibration(sensors []meta.InstalledSensor, at time.Time, fallback tides.Calibration) tides.Calibration {
+	for _, s := range sensors {
+		if !s.Span.Contains(at) {
+			continue
+		}
+		c := tides.Calibration{Factor: 1.0, Units: fallback.Units, Datum: fallback.Datum}
+		if s.HasFactor() {
+			c.Factor = s.Factor
+		}
+		if s.HasBias() {
+			c.Bias = s.Bias
+		}
+		return c
+	}
+	return fallback
+}
+
+// detide removes the predicted tide from the observations, runs of observations that share the same
+// calibration are processed together.
+func detide(tide tides.Tide, observations []tides.Observation, sensors []meta.InstalledSensor, fallback tides.Calibration) ([]tides.Residual, error) {
+	var residuals []tides.Residual
+
+	var start int
+	for i := 1; i <= len(observations); i++ {
+		current := calibration(sensors, observations[start].Time, fallback)
+		if i < len(observations) && calibration(sensors, observations[i].Time, fallback) == current {
+			continue
+		}
+		list, err := tide.Detide(observations[start:i], current)
+		if err != nil {
+			return nil, err
+		}
+		residuals = append(residuals, list...)
+		start = i
+	}
+
+	return residuals, nil
+}
+
+// writeResiduals outputs the residuals in csv format, each continuous segment is numbered.
+func writeResiduals(w io.Writer, residuals []tides.Residual, gap time.Duration) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"Segment", "Time", "Observed", "Predicted", "Residual"}); err != nil {
+		return err
+	}
+	for n, segment := range tides.Segments(residuals, gap) {
+		for _, r := range segment {
+			if err := cw.Write([]string{
+				strconv.Itoa(n + 1),
+				r.Time.Format(time.RFC3339Nano),
+				strconv.FormatFloat(r.Observed, 'f', 4, 64),
+				strconv.FormatFloat(r.Predicted, 'f', 4, 64),
+				strconv.FormatFloat(r.Residual, 'f', 4, 64),
+			}); err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
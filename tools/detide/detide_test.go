@@ -0,0 +1,117 @@
+package main
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GeoNet/delta/meta"
+	"github.com/GeoNet/delta/tides"
+)
+
+func TestReadObservations(t *testing.T) {
+
+	start := time.Date(2021, time.June, 1, 0, 0, 0, 0, time.UTC)
+
+	var tests = map[string]string{
+		"csv":    "Time,Value\n2021-06-01T00:00:00Z,1.5\n2021-06-01T00:01:00Z,2.5\n2021-06-01T00:02:00Z,\n",
+		"tspair": "TIMESERIES NZ_AUCT_40_BTT_D, 3 samples, 0.0166667 sps, 2021-06-01T00:00:00.000000, TSPAIR, FLOAT, Counts\n2021-06-01T00:00:00.000000  1.5\n2021-06-01T00:01:00.000000  2.5\n2021-06-01T00:02:00.000000  NaN\n",
+		"slist":  "TIMESERIES NZ_AUCT_40_BTT_D, 3 samples, 0.0166666666667 sps, 2021-06-01T00:00:00.000000, SLIST, FLOAT, Counts\n  1.5  2.5  NaN\n",
+	}
+
+	for k, v := range tests {
+		t.Run("read "+k, func(t *testing.T) {
+			observations, err := readObservations(strings.NewReader(v))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(observations) != 3 {
+				t.Fatalf("invalid number of observations: got %d, expected %d", len(observations), 3)
+			}
+			for i, o := range observations {
+				if d := o.Time.Sub(start.Add(time.Duration(i) * time.Minute)); d < -time.Millisecond || d > time.Millisecond {
+					t.Errorf("invalid observation %d time: %s", i, o.Time)
+				}
+			}
+			if observations[1].Value != 2.5 || !math.IsNaN(observations[2].Value) {
+				t.Errorf("invalid observation values: %v", observations)
+			}
+		})
+	}
+
+	// sample times are not accumulated, so the rounding of the sample interval does not drift
+	slist := "TIMESERIES NZ_AUCT_40_BTT_D, 3001 samples, 3 sps, 2021-06-01T00:00:00.000000, SLIST, FLOAT, Counts\n"
+	for i := 0; i <= 3000; i++ {
+		slist += " 1.0"
+		if i%6 == 5 {
+			slist += "\n"
+		}
+	}
+	observations, err := readObservations(strings.NewReader(slist))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(observations) != 3001 {
+		t.Fatalf("invalid number of observations: got %d, expected %d", len(observations), 3001)
+	}
+	if at := observations[3000].Time; !at.Equal(start.Add(1000 * time.Second)) {
+		t.Errorf("invalid last observation time: got %s, expected %s", at, start.Add(1000*time.Second))
+	}
+
+	if _, err := readObservations(strings.NewReader("TIMESERIES NZ_AUCT_40_BTT_D, 1 samples, 1 sps, 2021-06-01T00:00:00.000000, BINARY, FLOAT, Counts\n")); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
+
+func TestDetide(t *testing.T) {
+
+	tide := tides.Tide{
+		Code:     "TEST",
+		TimeZone: 360.0,
+		Constituents: []tides.Constituent{
+			{Name: "Z0", Amplitude: 100.0},
+			{Name: "S2", Amplitude: 10.0},
+		},
+	}
+
+	start := time.Date(2020, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	// the sensor is replaced after the first hour, the first sensor has a non-unit scale factor
+	var first, second meta.InstalledSensor
+	first.Span = meta.Span{Start: start.Add(-time.Hour), End: start.Add(time.Hour)}
+	first.SetFactor(func(v float64) *float64 { return &v }(1.03))
+	second.Span = meta.Span{Start: start.Add(time.Hour), End: meta.OpenEnd}
+	second.SetBias(func(v float64) *float64 { return &v }(0.1))
+
+	// observations are recorded in metres while the constituents are in centimetres
+	observations := []tides.Observation{
+		{Time: start.Add(-3 * time.Hour), Value: 1.01},
+		{Time: start, Value: 1.08 / 1.03},
+		{Time: start.Add(3 * time.Hour), Value: 0.91},
+		{Time: start.Add(6 * time.Hour), Value: 0.81},
+	}
+
+	residuals, err := detide(tide, observations, []meta.InstalledSensor{first, second}, tides.Calibration{Factor: 1.0, Units: 100.0, Datum: -1.0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(residuals) != 4 {
+		t.Fatalf("invalid number of residuals: got %d, expected %d", len(residuals), 4)
+	}
+	for i, v := range []float64{0.0, -3.0, 0.0, 0.0} {
+		if math.Abs(residuals[i].Residual-v) > 1.0e-6 {
+			t.Errorf("invalid residual %d: got %g, expected %g", i, residuals[i].Residual, v)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := writeResiduals(&buf, residuals, time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 5 || !strings.HasPrefix(lines[4], "4,") {
+		t.Errorf("invalid residual output:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/GeoNet/delta/tides"
+)
+
+// asciiTime is the sample time format used by miniSEED to text conversions.
+const asciiTime = "2006-01-02T15:04:05.999999"
+
+// readObservations reads sea level observations from either csv input, or from the text output of a
+// miniSEED conversion which consists of TIMESERIES headers followed by either time and value pairs or
+// lists of sample values.
+func readObservations(rd io.Reader) ([]tides.Observation, error) {
+	data, err := io.ReadAll(rd)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.HasPrefix(bytes.TrimSpace(data), []byte("TIMESERIES")) {
+		return tides.ReadObservations(bytes.NewReader(data))
+	}
+
+	var observations []tides.Observation
+
+	var list bool
+	var start time.Time
+	var interval float64
+	var samples int
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		switch {
+		case len(fields) == 0:
+			continue
+		case fields[0] == "TIMESERIES":
+			parts := strings.Split(scanner.Text(), ",")
+			if len(parts) < 5 {
+				return nil, fmt.Errorf("line %d: invalid timeseries header", line)
+			}
+			rate, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(parts[2]), " sps"), 64)
+			if err != nil || !(rate > 0.0) {
+				return nil, fmt.Errorf("line %d: invalid sample rate %q", line, strings.TrimSpace(parts[2]))
+			}
+			if start, err = time.Parse(asciiTime, strings.TrimSpace(parts[3])); err != nil {
+				return nil, fmt.Errorf("line %d: invalid start time %q: %v", line, strings.TrimSpace(parts[3]), err)
+			}
+			switch f := strings.TrimSpace(parts[4]); f {
+			case "SLIST":
+				list = true
+			case "TSPAIR":
+				list = false
+			default:
+				return nil, fmt.Errorf("line %d: unknown timeseries format: %s", line, f)
+			}
+			interval, samples = float64(time.Second)/rate, 0
+		case list:
+			for _, f := range fields {
+				value, err := strconv.ParseFloat(f, 64)
+				if err != nil {
+					return nil, fmt.Errorf("line %d: invalid value %q: %v", line, f, err)
+				}
+				// sample times are measured from the header to avoid accumulating any rounding of the interval
+				observations = append(observations, tides.Observation{
+					Time:  start.Add(time.Duration(math.Round(float64(samples) * interval))),
+					Value: value,
+				})
+				samples++
+			}
+		default:
+			if len(fields) != 2 {
+				return nil, fmt.Errorf("line %d: expected a time and value, found %d fields", line, len(fields))
+			}
+			at, err := time.Parse(asciiTime, fields[0])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid time %q: %v", line, fields[0], err)
+			}
+			value, err := strconv.ParseFloat(fields[1], 64)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid value %q: %v", line, fields[1], err)
+			}
+			observations = append(observations, tides.Observation{
+				Time:  at,
+				Value: value,
+			})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(observations, func(i, j int) bool {
+		return observations[i].Time.Before(observations[j].Time)
+	})
+
+	return observations, nil
+}
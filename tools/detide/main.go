@@ -0,0 +1,108 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/GeoNet/delta/meta"
+	"github.com/GeoNet/delta/metadb"
+	"github.com/GeoNet/delta/tides"
+)
+
+func main() {
+
+	var base string
+	flag.StringVar(&base, "base", "../..", "base of delta files on disk")
+
+	var gauge string
+	flag.StringVar(&gauge, "gauge", "", "tide gauge code used for the predictions")
+
+	var location string
+	flag.StringVar(&location, "location", "", "optional sensor location used to find the installed sensor scale factor and bias")
+
+	var input string
+	flag.StringVar(&input, "input", "", "csv or miniSEED derived text file of observations, defaults to standard input")
+
+	var factor float64
+	flag.Float64Var(&factor, "factor", 1.0, "scale factor applied to the recorded observations if no installed sensor is found")
+
+	var bias float64
+	flag.Float64Var(&bias, "bias", 0.0, "scale bias applied to the observations if no installed sensor is found")
+
+	var units float64
+	flag.Float64Var(&units, "units", 100.0, "multiplier converting the scaled observations into constituent units, the default converts metres to centimetres")
+
+	var datum float64
+	flag.Float64Var(&datum, "datum", 0.0, "offset, in constituent units, added to the converted observations to match the constituent datum")
+
+	var gap time.Duration
+	flag.DurationVar(&gap, "gap", 5*time.Minute, "largest time between observations before a new segment is started")
+
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "\n")
+		fmt.Fprintf(os.Stderr, "Remove the predicted tide from a time series of sea level observations\n")
+		fmt.Fprintf(os.Stderr, "\n")
+		fmt.Fprintf(os.Stderr, "The observations are expected in the recorded sensor units, usually metres, these are\n")
+		fmt.Fprintf(os.Stderr, "scaled by the sensor factor and bias and then converted into the centimetre units of the\n")
+		fmt.Fprintf(os.Stderr, "tidal constituents using the units multiplier.\n")
+		fmt.Fprintf(os.Stderr, "\n")
+		fmt.Fprintf(os.Stderr, "Usage:\n")
+		fmt.Fprintf(os.Stderr, "\n")
+		fmt.Fprintf(os.Stderr, "  %s [options]\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fmt.Fprintf(os.Stderr, "\n")
+		flag.PrintDefaults()
+		fmt.Fprintf(os.Stderr, "\n")
+	}
+
+	flag.Parse()
+
+	tide := tides.Lookup(gauge)
+	if tide == nil {
+		fmt.Fprintf(os.Stderr, "unknown tide gauge: %q\n", gauge)
+		os.Exit(1)
+	}
+
+	var sensors []meta.InstalledSensor
+	if location != "" {
+		list, err := metadb.NewMetaDB(base).StationLocationInstalledSensors(strings.ToUpper(gauge), location)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "problem loading sensors from db %s [%s]: %v\n", base, gauge, err)
+			os.Exit(1)
+		}
+		sensors = list
+	}
+
+	var rd io.Reader = os.Stdin
+	if input != "" {
+		file, err := os.Open(input)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "problem opening input file %s: %v\n", input, err)
+			os.Exit(1)
+		}
+		defer file.Close()
+		rd = file
+	}
+
+	observations, err := readObservations(rd)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "problem reading observations: %v\n", err)
+		os.Exit(1)
+	}
+
+	residuals, err := detide(*tide, observations, sensors, tides.Calibration{Factor: factor, Bias: bias, Units: units, Datum: datum})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "problem detiding observations for %s: %v\n", gauge, err)
+		os.Exit(1)
+	}
+
+	if err := writeResiduals(os.Stdout, residuals, gap); err != nil {
+		fmt.Fprintf(os.Stderr, "problem writing residuals: %v\n", err)
+		os.Exit(1)
+	}
+}
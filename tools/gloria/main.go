@@ -4,6 +4,7 @@ import (
 	"flag"
 	"fmt"
 	"github.com/GeoNet/delta/meta"
+	"github.com/GeoNet/delta/metadb"
 	"github.com/GeoNet/kit/gloria_pb"
 	"github.com/golang/protobuf/proto"
 	"io/ioutil"
@@ -22,11 +23,8 @@ func main() {
 	var output string
 	flag.StringVar(&output, "output", "output", "output directory")
 
-	var network string
-	flag.StringVar(&network, "network", "../../network", "base network directory")
-
-	var install string
-	flag.StringVar(&install, "install", "../../install", "base install directory")
+	var base string
+	flag.StringVar(&base, "base", "../..", "base of delta files on disk")
 
 	flag.Usage = func() {
 		fmt.Fprint(os.Stderr, "\n")
@@ -44,96 +42,43 @@ func main() {
 
 	flag.Parse()
 
-	var firmwareHistoryList meta.FirmwareHistoryList
-	if err := meta.LoadList(filepath.Join(install, "firmware.csv"), &firmwareHistoryList); err != nil {
-		fmt.Fprintf(os.Stderr, "error: unable to load firmware history: %v\n", err)
-		os.Exit(-1)
-	}
-
-	firmwareHistory := make(map[string]map[string][]meta.FirmwareHistory)
-	for _, i := range firmwareHistoryList {
-		if _, ok := firmwareHistory[i.Model]; !ok {
-			firmwareHistory[i.Model] = make(map[string][]meta.FirmwareHistory)
-		}
-		firmwareHistory[i.Model][i.Serial] = append(firmwareHistory[i.Model][i.Serial], i)
-	}
-
-	for j := range firmwareHistory {
-		for k := range firmwareHistory[j] {
-			sort.Sort(meta.FirmwareHistoryList(firmwareHistory[j][k]))
-		}
-	}
-
-	var installedAntennaList meta.InstalledAntennaList
-	if err := meta.LoadList(filepath.Join(install, "antennas.csv"), &installedAntennaList); err != nil {
-		fmt.Fprintf(os.Stderr, "error: unable to load antenna installs: %v\n", err)
-		os.Exit(-1)
-	}
-
-	installedAntenna := make(map[string][]meta.InstalledAntenna)
-	for _, i := range installedAntennaList {
-		installedAntenna[i.Mark] = append(installedAntenna[i.Mark], i)
-	}
-	for i := range installedAntenna {
-		sort.Sort(sort.Reverse(meta.InstalledAntennaList(installedAntenna[i])))
-	}
-
-	var deployedReceiverList meta.DeployedReceiverList
-	if err := meta.LoadList(filepath.Join(install, "receivers.csv"), &deployedReceiverList); err != nil {
-		fmt.Fprintf(os.Stderr, "error: unable to load receiver installs: %v\n", err)
-		os.Exit(-1)
-	}
-
-	deployedReceivers := make(map[string][]meta.DeployedReceiver)
-	for _, i := range deployedReceiverList {
-		deployedReceivers[i.Mark] = append(deployedReceivers[i.Mark], i)
-	}
-	for i := range deployedReceivers {
-		sort.Sort(sort.Reverse(meta.DeployedReceiverList(deployedReceivers[i])))
-	}
-
-	var installedRadomeList meta.InstalledRadomeList
-	if err := meta.LoadList(filepath.Join(install, "radomes.csv"), &installedRadomeList); err != nil {
-		fmt.Fprintf(os.Stderr, "error: unable to load radome installs: %v\n", err)
-		os.Exit(-1)
-	}
+	db := metadb.NewMetaDB(base)
 
-	installedRadomes := make(map[string][]meta.InstalledRadome)
-	for _, i := range installedRadomeList {
-		installedRadomes[i.Mark] = append(installedRadomes[i.Mark], i)
-	}
-	for i := range installedRadomes {
-		sort.Sort(meta.InstalledRadomeList(installedRadomes[i]))
-	}
-
-	var markList meta.MarkList
-	if err := meta.LoadList(filepath.Join(network, "marks.csv"), &markList); err != nil {
+	markList, err := db.Marks()
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "error: unable to load mark list: %v\n", err)
 		os.Exit(-1)
 	}
 
-	var monumentList meta.MonumentList
-	if err := meta.LoadList(filepath.Join(network, "monuments.csv"), &monumentList); err != nil {
-		fmt.Fprintf(os.Stderr, "error: unable to load monuments list: %v\n", err)
-		os.Exit(-1)
-	}
-	monuments := make(map[string]meta.Monument)
-	for _, m := range monumentList {
-		monuments[m.Mark] = m
-	}
-
 	var marks = gloria_pb.Marks{Marks: make(map[string]*gloria_pb.Mark)}
 
 	for _, m := range markList {
 
+		monument, err := db.Monument(m.Code)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: unable to load monuments list: %v\n", err)
+			os.Exit(-1)
+		}
+
 		mark_pb := gloria_pb.Mark{
 			Code: m.Code,
-			Point: &gloria_pb.Point{
-				Latitude:  m.Latitude,
-				Longitude: m.Longitude,
-				Elevation: m.Elevation,
-			},
-			DomesNumber:      monuments[m.Code].DomesNumber,
+			// the protobuf point cannot hold an unknown elevation, so the point is only given if it is complete
+			Point: func() *gloria_pb.Point {
+				if !m.HasElevation() {
+					return nil
+				}
+				return &gloria_pb.Point{
+					Latitude:  m.Latitude,
+					Longitude: m.Longitude,
+					Elevation: m.Elevation,
+				}
+			}(),
+			DomesNumber: func() string {
+				if monument != nil {
+					return monument.DomesNumber
+				}
+				return ""
+			}(),
 			DeployedReceiver: make([]*gloria_pb.DeployedReceiver, 0),
 			InstalledAntenna: make([]*gloria_pb.InstalledAntenna, 0),
 			InstalledRadome:  make([]*gloria_pb.InstalledRadome, 0),
@@ -173,7 +118,13 @@ contact information can be found at www.geonet.org.nz`
 
 		}
 
-		recList := deployedReceivers[m.Code]
+		recList, err := db.MarkDeployedReceivers(m.Code)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: unable to load receiver installs: %v\n", err)
+			os.Exit(-1)
+		}
+		sort.Sort(sort.Reverse(meta.DeployedReceiverList(recList)))
+
 		for _, rec := range recList {
 			rec_pb := gloria_pb.DeployedReceiver{
 				Receiver: &gloria_pb.Receiver{
@@ -187,7 +138,15 @@ contact information can be found at www.geonet.org.nz`
 				},
 			}
 
-			firmList := firmwareHistory[rec.Model][rec.Serial]
+			history, err := db.ReceiverFirmware(rec.Model, rec.Serial)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "error: unable to load firmware history: %v\n", err)
+				os.Exit(-1)
+			}
+			// sort a copy, the firmware history is shared with the database
+			firmList := append([]meta.FirmwareHistory{}, history...)
+			sort.Sort(meta.FirmwareHistoryList(firmList))
+
 			for _, firm := range firmList {
 				firm_pb := gloria_pb.Firmware{
 					Version: firm.Version,
@@ -201,18 +160,30 @@ contact information can be found at www.geonet.org.nz`
 			mark_pb.DeployedReceiver = append(mark_pb.DeployedReceiver, &rec_pb)
 		}
 
-		antList := installedAntenna[m.Code]
+		antList, err := db.MarkInstalledAntennas(m.Code)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: unable to load antenna installs: %v\n", err)
+			os.Exit(-1)
+		}
+		sort.Sort(sort.Reverse(meta.InstalledAntennaList(antList)))
+
 		for _, ant := range antList {
 			ant_pb := gloria_pb.InstalledAntenna{
 				Antenna: &gloria_pb.Antenna{
 					Model:        ant.Model,
 					SerialNumber: ant.Serial,
 				},
-				Offset: &gloria_pb.Offset{
-					Vertical: ant.Vertical,
-					North:    ant.North,
-					East:     ant.East,
-				},
+				// as for points, the offset is only given if the vertical offset is known
+				Offset: func() *gloria_pb.Offset {
+					if !ant.HasVertical() {
+						return nil
+					}
+					return &gloria_pb.Offset{
+						Vertical: ant.Vertical,
+						North:    ant.North,
+						East:     ant.East,
+					}
+				}(),
 				Span: &gloria_pb.Span{
 					Start: ant.Start.Unix(),
 					End:   ant.End.Unix(),
@@ -221,7 +192,13 @@ contact information can be found at www.geonet.org.nz`
 			mark_pb.InstalledAntenna = append(mark_pb.InstalledAntenna, &ant_pb)
 		}
 
-		radList := installedRadomes[m.Code]
+		radList, err := db.MarkInstalledRadomes(m.Code)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: unable to load radome installs: %v\n", err)
+			os.Exit(-1)
+		}
+		sort.Sort(meta.InstalledRadomeList(radList))
+
 		for _, rad := range radList {
 			rad_pb := gloria_pb.InstalledRadome{
 				Radome: &gloria_pb.Radome{
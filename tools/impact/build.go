@@ -7,8 +7,7 @@ import (
 	"strings"
 	"time"
 
-	"github.com/GeoNet/delta/internal/metadb"
-	"github.com/GeoNet/delta/resp"
+	"github.com/GeoNet/delta/metadb"
 )
 
 var Q = map[int]float64{
@@ -77,7 +76,7 @@ func buildStreams(base, channels string) (map[string]Stream, error) {
 			if time.Now().After(installation.End) {
 				continue
 			}
-			for _, response := range resp.Streams(installation.Datalogger.Model, installation.Sensor.Model) {
+			for _, response := range installation.Streams() {
 				q, ok := Q[int(response.Datalogger.SampleRate)]
 				if !ok {
 					continue
@@ -8,6 +8,8 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+
+	"github.com/GeoNet/delta/resp"
 )
 
 func main() {
@@ -21,6 +23,9 @@ func main() {
 	var output string
 	flag.StringVar(&output, "output", "", "output impact json file")
 
+	var responses string
+	flag.StringVar(&responses, "responses", "", "optional response YAML directory to use in place of the compiled responses")
+
 	flag.Usage = func() {
 		fmt.Fprintf(os.Stderr, "\n")
 		fmt.Fprintf(os.Stderr, "Build an impact json file from delta meta & response information\n")
@@ -37,6 +42,14 @@ func main() {
 
 	flag.Parse()
 
+	if responses != "" {
+		lib, err := resp.LoadDir(responses)
+		if err != nil {
+			log.Fatalf("error: unable to load responses %s: %v", responses, err)
+		}
+		resp.SetLibrary(lib)
+	}
+
 	streams, err := buildStreams(base, channels)
 	if err != nil {
 		log.Fatalf("problem loading streams %s: %v\n", base, err)
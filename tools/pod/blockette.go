@@ -5,13 +5,23 @@ import (
 	"log"
 	"strings"
 	"time"
+
+	"github.com/GeoNet/delta/meta"
 )
 
 func seedFormat(t time.Time) string {
-	if time.Now().After(t) {
-		return fmt.Sprintf("%04d,%03d,%s", t.Year(), t.YearDay(), t.Format("15:04:05.0000"))
+	if t.IsZero() {
+		return ""
+	}
+	return fmt.Sprintf("%04d,%03d,%s", t.Year(), t.YearDay(), t.Format("15:04:05.0000"))
+}
+
+// seedEnd formats the end of a span, open spans have no end time.
+func seedEnd(span meta.Span) string {
+	if span.IsOpen() {
+		return ""
 	}
-	return ""
+	return seedFormat(span.End)
 }
 
 type Blockette struct {
@@ -242,6 +252,9 @@ var commentDescriptions = []CommentDescription{
 	{Key: 9, Class: "L", Description: "Location estimated from plans and survey to mark"},
 	{Key: 5, Class: "L", Description: "Location estimated from external GPS measurement"},
 	{Key: 3, Class: "O", Description: "Sensor orientation not known"},
+	// seed requires elevations and depths, unknown values are given as zero with one of these comments
+	{Key: 10, Class: "L", Description: "Location elevation not known"},
+	{Key: 11, Class: "L", Description: "Sensor depth not known"},
 }
 
 func lookupCommentDescription(desc string) int {
@@ -294,12 +307,7 @@ func (s StationIdentifier) String() string {
 		int(3210),
 		int(10),
 		seedFormat(s.Opened),
-		func() string {
-			if time.Now().After(s.Closed) {
-				return seedFormat(s.Closed)
-			}
-			return ""
-		}(),
+		seedEnd(meta.Span{Start: s.Opened, End: s.Closed}),
 		"N",
 		s.Network,
 	)
@@ -314,12 +322,7 @@ type StationComment struct {
 func (s StationComment) String() string {
 	return fmt.Sprintf("%s~%s~%04d%06d",
 		seedFormat(s.Start),
-		func() string {
-			if time.Now().After(s.End) {
-				return seedFormat(s.End)
-			}
-			return ""
-		}(),
+		seedEnd(meta.Span{Start: s.Start, End: s.End}),
 		s.Lookup,
 		0,
 	)
@@ -373,7 +376,7 @@ func (c ChannelIdentifier) String() string {
 		c.NumberOfComments,
 		c.ChannelFlags,
 		seedFormat(c.StartDate),
-		seedFormat(c.EndDate),
+		seedEnd(meta.Span{Start: c.StartDate, End: c.EndDate}),
 		c.UpdateFlag,
 	)
 }
@@ -388,7 +391,7 @@ type ChannelComment struct {
 func (c ChannelComment) String() string {
 	return fmt.Sprintf("%s~%s~%04d%06d",
 		seedFormat(c.BeginningEffectiveTime),
-		seedFormat(c.EndEffectiveTime),
+		seedEnd(meta.Span{Start: c.BeginningEffectiveTime, End: c.EndEffectiveTime}),
 		c.CommentCodeKey,
 		c.CommentLevel,
 	)
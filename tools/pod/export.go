@@ -0,0 +1,267 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/GeoNet/delta/metadb"
+	"github.com/GeoNet/delta/resp"
+)
+
+// respUnitsLookup maps response units onto the units abbreviation codes.
+func respUnitsLookup(units string) int {
+	switch units {
+	case "hPa":
+		// as used in the original POD
+		return lookupUnitsAbbreviation("PA")
+	default:
+		return lookupUnitsAbbreviation(units)
+	}
+}
+
+// RespStages builds the RESP response blockettes for a stream, the stage values match those used
+// when building StationXML with stage gains given at the datalogger frequency.
+func RespStages(stream resp.Stream) []string {
+	var blockettes []string
+
+	freq := stream.Datalogger.Frequency
+
+	var count int
+	var sensitivity float64 = 1.0
+	for _, s := range append(append([]resp.ResponseStage{}, stream.Sensor.Stages...), stream.Datalogger.Stages...) {
+		if s.StageSet == nil {
+			continue
+		}
+		count++
+
+		gain := StageGain{
+			StageSequenceNumber: count,
+			Gain:                1.0,
+			Frequency:           freq,
+		}
+
+		switch f := s.StageSet.(type) {
+		case resp.PAZ:
+			var zeros, poles []ResponsePoleZero
+			for _, z := range f.Zeros {
+				zeros = append(zeros, ResponsePoleZero{Real: real(z), Imaginary: imag(z)})
+			}
+			for _, p := range f.Poles {
+				poles = append(poles, ResponsePoleZero{Real: real(p), Imaginary: imag(p)})
+			}
+			blockettes = append(blockettes, ResponsePolesZeros{
+				TransferFunctionType: func() string {
+					switch f.Code {
+					case resp.PZFunctionLaplaceRadiansPerSecond:
+						return "A"
+					case resp.PZFunctionLaplaceHertz:
+						return "B"
+					case resp.PZFunctionLaplaceZTransform:
+						return "D"
+					default:
+						return " "
+					}
+				}(),
+				StageSequenceNumber:    count,
+				StageSignalInputUnits:  respUnitsLookup(s.InputUnits),
+				StageSignalOutputUnits: respUnitsLookup(s.OutputUnits),
+				AONormalizationFactor:  1.0 / f.Gain(freq),
+				NormalizationFrequency: freq,
+				Zeros:                  zeros,
+				Poles:                  poles,
+			}.Resp())
+			if s.Gain != 0.0 {
+				gain.Gain = f.Gain(freq) * s.Gain / f.Gain(s.Frequency)
+			}
+		case resp.Polynomial:
+			var coeffs []float64
+			for _, c := range f.Coefficients {
+				coeffs = append(coeffs, c.Value)
+			}
+			blockettes = append(blockettes, ResponsePolynomial{
+				TransferFunctionType:   "P",
+				StageSequenceNumber:    count,
+				StageSignalInputUnits:  respUnitsLookup(s.InputUnits),
+				StageSignalOutputUnits: respUnitsLookup(s.OutputUnits),
+				PolynomialApproximationType: func() string {
+					switch f.ApproximationType {
+					case resp.ApproximationTypeMaclaurin:
+						return "M"
+					default:
+						return ""
+					}
+				}(),
+				ValidFrequencyUnits:       "B",
+				LowerValidFrequencyBound:  fmt.Sprintf("%+.5E", f.FrequencyLowerBound),
+				UpperValidFrequencyBound:  fmt.Sprintf("%+.5E", f.FrequencyUpperBound),
+				LowerBoundOfApproximation: f.ApproximationLowerBound,
+				UpperBoundOfApproximation: f.ApproximationUpperBound,
+				MaximumAbsoluteError:      f.MaximumError,
+				Coefficients:              coeffs,
+			}.Resp())
+			if f.Gain != 0.0 {
+				gain.Gain = f.Gain
+			}
+		case resp.A2D:
+			blockettes = append(blockettes, ResponseCoefficients{
+				ResponseType:           "D",
+				StageSequenceNumber:    count,
+				StageSignalInputUnits:  respUnitsLookup(s.InputUnits),
+				StageSignalOutputUnits: respUnitsLookup(s.OutputUnits),
+			}.Resp())
+			blockettes = append(blockettes, Decimation{
+				StageSequenceNumber: count,
+				InputSampleRate:     s.SampleRate,
+				DecimationFactor: func() int {
+					if s.Decimate != 0 {
+						return int(s.Decimate)
+					}
+					return 1
+				}(),
+				EstimatedDelay:    s.Delay,
+				CorrectionApplied: s.Correction,
+			}.Resp())
+			if s.Gain != 0.0 {
+				gain.Gain = s.Gain
+			}
+		case resp.FIR:
+			blockettes = append(blockettes, FIRResponse{
+				StageSequenceNumber:    count,
+				ResponseName:           f.Name,
+				StageSignalInputUnits:  respUnitsLookup(s.InputUnits),
+				StageSignalOutputUnits: respUnitsLookup(s.OutputUnits),
+				SymmetryCode: func() string {
+					switch f.Symmetry {
+					case resp.SymmetryOdd:
+						return "B"
+					case resp.SymmetryEven:
+						return "C"
+					default:
+						return "A"
+					}
+				}(),
+				Coefficients: f.Factors,
+			}.Resp())
+			blockettes = append(blockettes, Decimation{
+				StageSequenceNumber: count,
+				InputSampleRate:     f.Decimation * s.SampleRate,
+				DecimationFactor: func() int {
+					if s.Decimate != 0 {
+						return int(s.Decimate)
+					}
+					return int(f.Decimation)
+				}(),
+				EstimatedDelay:    s.Delay,
+				CorrectionApplied: s.Correction,
+			}.Resp())
+			if s.Gain != 0.0 {
+				gain.Gain = s.Gain
+			}
+		default:
+			count--
+			continue
+		}
+
+		blockettes = append(blockettes, gain.Resp())
+		sensitivity *= gain.Gain
+	}
+
+	blockettes = append(blockettes, StageGain{
+		Gain:      sensitivity,
+		Frequency: freq,
+	}.Resp())
+
+	return blockettes
+}
+
+// respFile returns the RESP file name of a channel epoch, the usual RESP.NET.STA.LOC.CHA name is
+// followed by the epoch start time so that each epoch of a channel has its own file.
+func respFile(channel RespChannel) string {
+	return strings.Join([]string{
+		"RESP",
+		channel.Network,
+		channel.Station,
+		channel.Location,
+		channel.Channel,
+		channel.Start.UTC().Format("2006.002.150405"),
+	}, ".")
+}
+
+// Resp writes a RESP file for each channel epoch of a station into the output directory.
+func (p *Pod) Resp(mdb *metadb.MetaDB, sta string, match func(string) bool) error {
+
+	station, err := mdb.Station(sta)
+	if err != nil || station == nil {
+		return err
+	}
+	network, err := mdb.Network(station.Network)
+	if err != nil || network == nil {
+		return err
+	}
+
+	installations, err := mdb.Installations(station.Code)
+	if err != nil {
+		return err
+	}
+
+	for _, installation := range installations {
+		location, err := mdb.Site(station.Code, installation.Location)
+		if err != nil {
+			return err
+		}
+		if location == nil {
+			continue
+		}
+
+		for _, response := range installation.Streams() {
+			stream, err := mdb.StationLocationSamplingRateStartStream(
+				station.Code,
+				installation.Location,
+				response.Datalogger.SampleRate,
+				installation.Start)
+			if err != nil {
+				return err
+			}
+			if stream == nil {
+				continue
+			}
+
+			lookup := response.Channels(stream.Axial)
+			for pin := range response.Components {
+				if !(pin < len(lookup)) {
+					continue
+				}
+				if match != nil && !match(lookup[pin]) {
+					continue
+				}
+
+				channel := RespChannel{
+					Network:  network.External,
+					Station:  station.Code,
+					Location: installation.Location,
+					Channel:  lookup[pin],
+					Start:    installation.Start,
+					End:      installation.End,
+				}
+
+				lines := []string{channel.Resp()}
+				for _, b := range RespStages(response) {
+					lines = append(lines, b, "#")
+				}
+
+				file := filepath.Join(p.base, respFile(channel))
+				if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+					return err
+				}
+				if err := ioutil.WriteFile(file, []byte(strings.Join(lines, "\n")+"\n"), 0644); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
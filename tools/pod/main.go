@@ -7,7 +7,10 @@ import (
 	"io/ioutil"
 	"log"
 	"os"
+	"regexp"
 
+	"github.com/GeoNet/delta/metadb"
+	"github.com/GeoNet/delta/resp"
 	"github.com/ozym/fdsn/stationxml"
 )
 
@@ -19,13 +22,29 @@ func main() {
 	var output string
 	flag.StringVar(&output, "output", "output", "output POD header directory")
 
+	var base string
+	flag.StringVar(&base, "base", "../..", "base of delta files on disk")
+
+	var stations string
+	flag.StringVar(&stations, "stations", "[A-Z0-9]+", "regexp selection of stations for RESP files")
+
+	var channels string
+	flag.StringVar(&channels, "channels", "[A-Z0-9]+", "regexp selection of channels for RESP files")
+
+	var respFiles bool
+	flag.BoolVar(&respFiles, "resp", false, "build RESP files from the delta base rather than POD headers from StationXML")
+
+	var responses string
+	flag.StringVar(&responses, "responses", "", "optional response YAML directory to use in place of the compiled responses")
+
 	flag.Usage = func() {
 		fmt.Fprintf(os.Stderr, "\n")
-		fmt.Fprintf(os.Stderr, "Build POD header files from StationXML file(s)\n")
+		fmt.Fprintf(os.Stderr, "Build POD header files from StationXML file(s), or RESP files from delta\n")
 		fmt.Fprintf(os.Stderr, "\n")
 		fmt.Fprintf(os.Stderr, "Usage:\n")
 		fmt.Fprintf(os.Stderr, "\n")
 		fmt.Fprintf(os.Stderr, "  %s [options] <stationxml> ...\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s [options] -resp\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "\n")
 		fmt.Fprintf(os.Stderr, "Options:\n")
 		fmt.Fprintf(os.Stderr, "\n")
@@ -35,12 +54,51 @@ func main() {
 
 	flag.Parse()
 
+	if responses != "" {
+		lib, err := resp.LoadDir(responses)
+		if err != nil {
+			log.Fatalf("error: unable to load responses %s: %v", responses, err)
+		}
+		resp.SetLibrary(lib)
+	}
+
 	if err := os.MkdirAll(output, 0755); err != nil {
 		log.Fatalf("error: unable to create directory %s: %v", output, err)
 	}
 
 	pod := NewPod(output)
 
+	if respFiles {
+		stationMatch, err := regexp.Compile("^(" + stations + ")$")
+		if err != nil {
+			log.Fatalf("error: invalid station regexp %q: %v", stations, err)
+		}
+		channelMatch, err := regexp.Compile("^(" + channels + ")$")
+		if err != nil {
+			log.Fatalf("error: invalid channel regexp %q: %v", channels, err)
+		}
+
+		mdb := metadb.NewMetaDB(base)
+
+		list, err := mdb.Stations()
+		if err != nil {
+			log.Fatalf("error: unable to load stations: %v", err)
+		}
+		for _, s := range list {
+			if !stationMatch.MatchString(s.Code) {
+				continue
+			}
+			if verbose {
+				log.Printf("building RESP files for station: %s", s.Code)
+			}
+			if err := pod.Resp(mdb, s.Code, channelMatch.MatchString); err != nil {
+				log.Fatalf("error: unable to build RESP files for %s: %v", s.Code, err)
+			}
+		}
+
+		return
+	}
+
 	if err := pod.Header(); err != nil {
 		log.Fatalf("error: unable to build POD header file: %v", err)
 	}
@@ -116,7 +116,7 @@ func (p *Pod) Station(net *stationxml.Network, sta *stationxml.Station) error {
 				if sta.TerminationDate != nil {
 					return sta.TerminationDate.Time
 				}
-				return time.Now().AddDate(9999, 0, 0)
+				return time.Time{}
 			}(),
 			Network: net.Code,
 		}.String(),
@@ -136,7 +136,7 @@ func (p *Pod) Station(net *stationxml.Network, sta *stationxml.Station) error {
 						if sta.TerminationDate != nil {
 							return sta.TerminationDate.Time
 						}
-						return time.Now().AddDate(9999, 0, 0)
+						return time.Time{}
 					}(),
 					Lookup: lookupCommentDescription(b.Value),
 				}.String(),
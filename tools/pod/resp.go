@@ -0,0 +1,192 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/GeoNet/delta/meta"
+)
+
+// The RESP text layout follows that of rdseed as expected by evalresp, each blockette is
+// rendered from the same values as used for the POD header files.
+
+func respField(key, label string, value interface{}) string {
+	return fmt.Sprintf("%-12s%-39s%v", key, label+":", value)
+}
+
+func respUnits(code int) string {
+	for _, a := range unitsAbbreviation {
+		if a.Code == code {
+			return a.Key + " - " + a.Description
+		}
+	}
+	return "UNKNOWN - No Abbreviation Referenced"
+}
+
+func respEnd(span meta.Span) string {
+	if s := seedEnd(span); s != "" {
+		return s
+	}
+	return "No Ending Time"
+}
+
+// RespChannel holds the RESP channel identification header.
+type RespChannel struct {
+	Network  string
+	Station  string
+	Location string
+	Channel  string
+	Start    time.Time
+	End      time.Time
+}
+
+func (c RespChannel) Resp() string {
+	return strings.Join([]string{
+		"#",
+		"#\t\t======== CHANNEL RESPONSE DATA ========",
+		fmt.Sprintf("B050F03     Station:     %s", c.Station),
+		fmt.Sprintf("B050F16     Network:     %s", c.Network),
+		fmt.Sprintf("B052F03     Location:    %s", func() string {
+			if c.Location != "" {
+				return c.Location
+			}
+			return "??"
+		}()),
+		fmt.Sprintf("B052F04     Channel:     %s", c.Channel),
+		fmt.Sprintf("B052F22     Start date:  %s", seedFormat(c.Start)),
+		fmt.Sprintf("B052F23     End date:    %s", respEnd(meta.Span{Start: c.Start, End: c.End})),
+		"#\t\t=======================================",
+	}, "\n")
+}
+
+func (r ResponsePolesZeros) Resp() string {
+	lines := []string{
+		respField("B053F03", "Transfer function type", func() string {
+			switch r.TransferFunctionType {
+			case "A":
+				return "A [Laplace Transform (Rad/sec)]"
+			case "B":
+				return "B [Analog (Hz)]"
+			case "D":
+				return "D [Digital (Z-transform)]"
+			default:
+				return r.TransferFunctionType
+			}
+		}()),
+		respField("B053F04", "Stage sequence number", r.StageSequenceNumber),
+		respField("B053F05", "Response in units lookup", respUnits(r.StageSignalInputUnits)),
+		respField("B053F06", "Response out units lookup", respUnits(r.StageSignalOutputUnits)),
+		respField("B053F07", "A0 normalization factor", fmt.Sprintf("%+.5E", r.AONormalizationFactor)),
+		respField("B053F08", "Normalization frequency", fmt.Sprintf("%+.5E", r.NormalizationFrequency)),
+		respField("B053F09", "Number of zeroes", len(r.Zeros)),
+		respField("B053F14", "Number of poles", len(r.Poles)),
+	}
+	lines = append(lines, "#\t\tComplex zeroes:", "#\t\t  i  real          imag          real_error    imag_error")
+	for i, z := range r.Zeros {
+		lines = append(lines, fmt.Sprintf("B053F10-13 %4d  %+.5E  %+.5E  %+.5E  %+.5E", i, z.Real, z.Imaginary, z.RealError, z.ImaginaryError))
+	}
+	lines = append(lines, "#\t\tComplex poles:", "#\t\t  i  real          imag          real_error    imag_error")
+	for i, p := range r.Poles {
+		lines = append(lines, fmt.Sprintf("B053F15-18 %4d  %+.5E  %+.5E  %+.5E  %+.5E", i, p.Real, p.Imaginary, p.RealError, p.ImaginaryError))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (r ResponseCoefficients) Resp() string {
+	lines := []string{
+		respField("B054F03", "Transfer function type", r.ResponseType),
+		respField("B054F04", "Stage sequence number", r.StageSequenceNumber),
+		respField("B054F05", "Response in units lookup", respUnits(r.StageSignalInputUnits)),
+		respField("B054F06", "Response out units lookup", respUnits(r.StageSignalOutputUnits)),
+		respField("B054F07", "Number of numerators", len(r.Numerators)),
+		respField("B054F10", "Number of denominators", len(r.Denominators)),
+	}
+	if len(r.Numerators) > 0 {
+		lines = append(lines, "#\t\tNumerator coefficients:", "#\t\t  i, coefficient,  error")
+		for i, n := range r.Numerators {
+			lines = append(lines, fmt.Sprintf("B054F08-09 %4d  %+.5E  %+.5E", i, n.Coefficient, n.CoefficientError))
+		}
+	}
+	if len(r.Denominators) > 0 {
+		lines = append(lines, "#\t\tDenominator coefficients:", "#\t\t  i, coefficient,  error")
+		for i, d := range r.Denominators {
+			lines = append(lines, fmt.Sprintf("B054F11-12 %4d  %+.5E  %+.5E", i, d.Coefficient, d.CoefficientError))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (r FIRResponse) Resp() string {
+	lines := []string{
+		respField("B061F03", "Stage sequence number", r.StageSequenceNumber),
+		respField("B061F04", "Response Name", r.ResponseName),
+		respField("B061F05", "Symmetry Code", r.SymmetryCode),
+		respField("B061F06", "Response in units lookup", respUnits(r.StageSignalInputUnits)),
+		respField("B061F07", "Response out units lookup", respUnits(r.StageSignalOutputUnits)),
+		respField("B061F08", "Number of Coefficients", len(r.Coefficients)),
+		"#\t\ti, coefficient",
+	}
+	for i, c := range r.Coefficients {
+		lines = append(lines, fmt.Sprintf("B061F09    i, coefficient: %3d  %+.7E", i, c))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (r ResponsePolynomial) Resp() string {
+	lines := []string{
+		respField("B062F03", "Transfer function type", r.TransferFunctionType),
+		respField("B062F04", "Stage sequence number", r.StageSequenceNumber),
+		respField("B062F05", "Response in units lookup", respUnits(r.StageSignalInputUnits)),
+		respField("B062F06", "Response out units lookup", respUnits(r.StageSignalOutputUnits)),
+		respField("B062F07", "Polynomial Approximation Type", r.PolynomialApproximationType),
+		respField("B062F08", "Valid Frequency Units", r.ValidFrequencyUnits),
+		respField("B062F09", "Lower Valid Frequency Bound", func() string {
+			if r.LowerValidFrequencyBound != "" {
+				return r.LowerValidFrequencyBound
+			}
+			return fmt.Sprintf("%+.5E", 0.0)
+		}()),
+		respField("B062F10", "Upper Valid Frequency Bound", func() string {
+			if r.UpperValidFrequencyBound != "" {
+				return r.UpperValidFrequencyBound
+			}
+			return fmt.Sprintf("%+.5E", 0.0)
+		}()),
+		respField("B062F11", "Lower Bound of Approximation", fmt.Sprintf("%+.5E", r.LowerBoundOfApproximation)),
+		respField("B062F12", "Upper Bound of Approximation", fmt.Sprintf("%+.5E", r.UpperBoundOfApproximation)),
+		respField("B062F13", "Maximum Absolute Error", fmt.Sprintf("%+.5E", r.MaximumAbsoluteError)),
+		respField("B062F14", "Number of coefficients", len(r.Coefficients)),
+		"#\t\tPolynomial coefficients:",
+		"#\t\t  i, coefficient,  error",
+	}
+	for i, c := range r.Coefficients {
+		lines = append(lines, fmt.Sprintf("B062F15-16 %4d  %+.5E  %+.5E", i, c, 0.0))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (d Decimation) Resp() string {
+	return strings.Join([]string{
+		respField("B057F03", "Stage sequence number", d.StageSequenceNumber),
+		respField("B057F04", "Input sample rate (HZ)", fmt.Sprintf("%.4E", d.InputSampleRate)),
+		respField("B057F05", "Decimation factor", fmt.Sprintf("%05d", d.DecimationFactor)),
+		respField("B057F06", "Decimation offset", fmt.Sprintf("%05d", d.DecimationOffset)),
+		respField("B057F07", "Estimated delay (seconds)", fmt.Sprintf("%+.4E", d.EstimatedDelay)),
+		respField("B057F08", "Correction applied (seconds)", fmt.Sprintf("%+.4E", d.CorrectionApplied)),
+	}, "\n")
+}
+
+// Resp renders the stage gain, stage zero is rendered as the overall channel sensitivity.
+func (s StageGain) Resp() string {
+	gain, frequency := "Gain", "Frequency of gain"
+	if s.StageSequenceNumber == 0 {
+		gain, frequency = "Sensitivity", "Frequency of sensitivity"
+	}
+	return strings.Join([]string{
+		respField("B058F03", "Stage sequence number", s.StageSequenceNumber),
+		respField("B058F04", gain, fmt.Sprintf("%+.5E", s.Gain)),
+		respField("B058F05", frequency, fmt.Sprintf("%+.5E HZ", s.Frequency)),
+		respField("B058F06", "Number of calibrations", s.Something),
+	}, "\n")
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GeoNet/delta/meta"
+	"github.com/GeoNet/delta/resp"
+)
+
+func TestRespStages(t *testing.T) {
+
+	stream := resp.Stream{
+		Sensor: resp.Sensor{
+			Stages: []resp.ResponseStage{{
+				StageSet: resp.PAZ{
+					Code:  resp.PZFunctionLaplaceRadiansPerSecond,
+					Poles: []complex128{complex(-4.44, 4.44), complex(-4.44, -4.44)},
+					Zeros: []complex128{0, 0},
+				},
+				Frequency:   15.0,
+				Gain:        400.0,
+				InputUnits:  "m/s",
+				OutputUnits: "V",
+			}},
+		},
+		Datalogger: resp.Datalogger{
+			Frequency: 15.0,
+			Stages: []resp.ResponseStage{{
+				StageSet:    resp.A2D{},
+				SampleRate:  200.0,
+				Gain:        419430.0,
+				InputUnits:  "V",
+				OutputUnits: "count",
+			}, {
+				StageSet: resp.FIR{
+					Name:       "TEST_FIR",
+					Symmetry:   resp.SymmetryOdd,
+					Decimation: 2.0,
+					Factors:    []float64{0.125, 0.25, 0.25},
+				},
+				SampleRate:  100.0,
+				InputUnits:  "count",
+				OutputUnits: "count",
+			}},
+		},
+	}
+
+	text := strings.Join(RespStages(stream), "\n")
+
+	for _, line := range []string{
+		"B053F03     Transfer function type:                A [Laplace Transform (Rad/sec)]",
+		"B053F05     Response in units lookup:              M/S - Velocity in Meters Per Second",
+		"B058F04     Gain:                                  +4.00000E+02",
+		"B054F03     Transfer function type:                D",
+		"B057F04     Input sample rate (HZ):                2.0000E+02",
+		"B057F05     Decimation factor:                     00002",
+		"B061F05     Symmetry Code:                         B",
+		"B061F09    i, coefficient:   2  +2.5000000E-01",
+		"B058F03     Stage sequence number:                 0",
+		"B058F04     Sensitivity:                           +1.67772E+08",
+		"B058F05     Frequency of sensitivity:              +1.50000E+01 HZ",
+	} {
+		if !strings.Contains(text, line+"\n") {
+			t.Errorf("missing RESP line: %q", line)
+		}
+	}
+}
+
+func TestRespChannel(t *testing.T) {
+
+	start := time.Date(2010, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	var tests = []struct {
+		end      time.Time
+		expected string
+	}{
+		{time.Time{}, "No Ending Time"},
+		{meta.OpenEnd, "No Ending Time"},
+		{time.Date(2015, time.February, 1, 0, 0, 0, 0, time.UTC), "2015,032,00:00:00.0000"},
+		// a planned closure is not treated as open
+		{time.Date(9000, time.January, 1, 0, 0, 0, 0, time.UTC), "9000,001,00:00:00.0000"},
+	}
+
+	for _, test := range tests {
+		text := RespChannel{Station: "WEL", Start: start, End: test.end}.Resp()
+		if !strings.Contains(text, "B052F22     Start date:  2010,001,00:00:00.0000") {
+			t.Errorf("missing start date: %s", text)
+		}
+		if !strings.Contains(text, "B052F23     End date:    "+test.expected) {
+			t.Errorf("invalid end date, expected %q: %s", test.expected, text)
+		}
+	}
+}
+
+func TestRespFile(t *testing.T) {
+
+	channel := RespChannel{
+		Network:  "NZ",
+		Station:  "WEL",
+		Location: "10",
+		Channel:  "HHZ",
+		Start:    time.Date(2010, time.February, 1, 12, 30, 15, 0, time.UTC),
+	}
+
+	if f := respFile(channel); f != "RESP.NZ.WEL.10.HHZ.2010.032.123015" {
+		t.Errorf("invalid resp file name: %s", f)
+	}
+}
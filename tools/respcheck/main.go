@@ -0,0 +1,132 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/GeoNet/delta/resp"
+)
+
+func main() {
+
+	var tolerance float64
+	flag.Float64Var(&tolerance, "tolerance", 0.01, "allowed relative difference in gains")
+
+	var responses string
+	flag.StringVar(&responses, "responses", "", "optional response YAML directory to check in place of the compiled responses")
+
+	var baseline string
+	flag.StringVar(&baseline, "baseline", "", "optional file of known problems to ignore, one regular expression per line")
+
+	var verbose bool
+	flag.BoolVar(&verbose, "verbose", false, "report the number of problems found")
+
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "\n")
+		fmt.Fprintf(os.Stderr, "Check the consistency of the sensitivities, normalisations and units of delta responses\n")
+		fmt.Fprintf(os.Stderr, "\n")
+		fmt.Fprintf(os.Stderr, "Usage:\n")
+		fmt.Fprintf(os.Stderr, "\n")
+		fmt.Fprintf(os.Stderr, "  %s [options]\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fmt.Fprintf(os.Stderr, "\n")
+		flag.PrintDefaults()
+		fmt.Fprintf(os.Stderr, "\n")
+	}
+
+	flag.Parse()
+
+	if responses != "" {
+		lib, err := resp.LoadDir(responses)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "problem loading responses %s: %v\n", responses, err)
+			os.Exit(1)
+		}
+		resp.SetLibrary(lib)
+	}
+
+	var known []*regexp.Regexp
+	if baseline != "" {
+		k, err := readBaseline(baseline)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "problem reading baseline %s: %v\n", baseline, err)
+			os.Exit(1)
+		}
+		known = k
+	}
+
+	used := make(map[int]bool)
+	matches := func(p resp.Problem) bool {
+		for i, re := range known {
+			if re.MatchString(p.String()) {
+				used[i] = true
+				return true
+			}
+		}
+		return false
+	}
+
+	var ignored int
+	var problems []resp.Problem
+	for _, p := range resp.Check(resp.Responses, tolerance) {
+		if matches(p) {
+			ignored++
+			continue
+		}
+		problems = append(problems, p)
+	}
+
+	for _, p := range problems {
+		fmt.Println(p.String())
+	}
+
+	for i, re := range known {
+		if !used[i] {
+			fmt.Fprintf(os.Stderr, "baseline entry %q no longer matches any problem\n", re.String())
+		}
+	}
+
+	if verbose {
+		fmt.Fprintf(os.Stderr, "found %d problems, and ignored %d known problems, in %d responses\n", len(problems), ignored, len(resp.Responses))
+	}
+
+	if len(problems) > 0 {
+		os.Exit(1)
+	}
+}
+
+// readBaseline reads a list of regular expressions describing known problems, blank lines and
+// lines starting with a hash are ignored.
+func readBaseline(path string) ([]*regexp.Regexp, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var known []*regexp.Regexp
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		re, err := regexp.Compile(line)
+		if err != nil {
+			return nil, err
+		}
+		known = append(known, re)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return known, nil
+}
@@ -0,0 +1,307 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/GeoNet/delta/resp/config"
+)
+
+// blockette holds the fields of a single RESP blockette, rows hold the tabular entries such as poles,
+// zeros or coefficients keyed by their starting field.
+type blockette struct {
+	kind   string
+	fields map[string]string
+	rows   map[string][][]string
+	last   int
+}
+
+// word returns the first word of a field, i.e. without any trailing description.
+func (b blockette) word(field string) string {
+	if f := strings.Fields(b.fields[field]); len(f) > 0 {
+		return f[0]
+	}
+	return ""
+}
+
+func (b blockette) float(field string) (float64, error) {
+	v := b.word(field)
+	if v == "" {
+		return 0, nil
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%s%s: invalid value %q", b.kind, field, v)
+	}
+	return f, nil
+}
+
+func (b blockette) int(field string) (int, error) {
+	f, err := b.float(field)
+	return int(f), err
+}
+
+// values returns the given column from the rows of a field as floats.
+func (b blockette) values(field string, column int) ([]float64, error) {
+	var list []float64
+	for _, row := range b.rows[field] {
+		if !(column < len(row)) {
+			return nil, fmt.Errorf("%s%s: missing column %d", b.kind, field, column)
+		}
+		v, err := strconv.ParseFloat(row[column], 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s%s: invalid value %q", b.kind, field, row[column])
+		}
+		list = append(list, v)
+	}
+	return list, nil
+}
+
+func (b blockette) complex(field string) ([]config.Complex128, error) {
+	re, err := b.values(field, 1)
+	if err != nil {
+		return nil, err
+	}
+	im, err := b.values(field, 2)
+	if err != nil {
+		return nil, err
+	}
+	list := []config.Complex128{}
+	for i := range re {
+		list = append(list, config.Complex128(complex(re[i], im[i])))
+	}
+	return list, nil
+}
+
+// scanRESP splits the response blockettes of the first channel found in a RESP file.
+func scanRESP(rd io.Reader) ([]blockette, error) {
+	var blockettes []blockette
+
+	scanner := bufio.NewScanner(rd)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) < 7 || line[0] != 'B' || line[4] != 'F' {
+			continue
+		}
+		key := strings.Fields(line)[0]
+		kind, field := key[0:4], key[4:7]
+		rest := strings.TrimSpace(line[len(key):])
+
+		n, err := strconv.Atoi(field[1:])
+		if err != nil {
+			return nil, fmt.Errorf("invalid blockette field: %s", key)
+		}
+
+		switch kind {
+		case "B050", "B052":
+			// only the first channel is decoded
+			if len(blockettes) > 0 {
+				return blockettes, scanner.Err()
+			}
+			continue
+		}
+
+		// rows may follow later header fields, so only header fields start a new blockette
+		row := strings.Contains(key, "-") || (kind == "B061" && field == "F09")
+
+		if len(blockettes) == 0 || blockettes[len(blockettes)-1].kind != kind || (!row && n < blockettes[len(blockettes)-1].last) {
+			blockettes = append(blockettes, blockette{
+				kind:   kind,
+				fields: make(map[string]string),
+				rows:   make(map[string][][]string),
+			})
+		}
+		b := &blockettes[len(blockettes)-1]
+
+		switch {
+		case row:
+			if i := strings.Index(rest, ":"); i >= 0 {
+				rest = rest[i+1:]
+			}
+			b.rows[field] = append(b.rows[field], strings.Fields(rest))
+		default:
+			if i := strings.Index(rest, ":"); i >= 0 {
+				b.fields[field] = strings.TrimSpace(rest[i+1:])
+			}
+			b.last = n
+		}
+	}
+
+	return blockettes, scanner.Err()
+}
+
+// stageField gives the field holding the stage sequence number for each response blockette.
+var stageField = map[string]string{
+	"B053": "F04",
+	"B054": "F04",
+	"B057": "F03",
+	"B058": "F03",
+	"B061": "F03",
+	"B062": "F04",
+}
+
+var pazTypes = map[string]string{
+	"A": "Laplace transform analog stage response, in rad/sec.",
+	"B": "Analogue response, in Hz.",
+	"D": "Digital (Z-transform).",
+}
+
+// DecodeRESP decodes the response stages of the first channel found in an evalresp RESP file.
+func DecodeRESP(rd io.Reader) ([]Stage, error) {
+	blockettes, err := scanRESP(rd)
+	if err != nil {
+		return nil, err
+	}
+
+	stages := make(map[int]*Stage)
+	for _, b := range blockettes {
+		field, ok := stageField[b.kind]
+		if !ok {
+			continue
+		}
+		n, err := b.int(field)
+		if err != nil {
+			return nil, err
+		}
+		// stage zero holds the overall sensitivity
+		if n == 0 {
+			continue
+		}
+		if _, ok := stages[n]; !ok {
+			stages[n] = &Stage{}
+		}
+		s := stages[n]
+
+		switch b.kind {
+		case "B053":
+			code := strings.ToUpper(b.word("F03"))
+			zeros, err := b.complex("F10")
+			if err != nil {
+				return nil, err
+			}
+			poles, err := b.complex("F15")
+			if err != nil {
+				return nil, err
+			}
+			s.Type = "paz"
+			s.PAZ = config.PAZ{Code: code, Type: pazTypes[code], Poles: poles, Zeros: zeros}
+			s.InputUnits, s.OutputUnits = units(b.fields["F05"]), units(b.fields["F06"])
+		case "B054":
+			numerators, err := b.values("F08", 1)
+			if err != nil {
+				return nil, err
+			}
+			if len(b.rows["F11"]) > 0 {
+				return nil, fmt.Errorf("stage %d: coefficient responses with denominators are not supported", n)
+			}
+			s.Type = "fir"
+			s.FIR = config.FIR{Symmetry: "none", Factors: numerators}
+			s.InputUnits, s.OutputUnits = units(b.fields["F05"]), units(b.fields["F06"])
+		case "B061":
+			factors, err := b.values("F09", 1)
+			if err != nil {
+				return nil, err
+			}
+			symmetry := "none"
+			switch strings.ToUpper(b.word("F05")) {
+			case "B":
+				symmetry = "odd"
+			case "C":
+				symmetry = "even"
+			}
+			s.Type, s.Name = "fir", strings.TrimSpace(b.fields["F04"])
+			s.FIR = config.FIR{Symmetry: symmetry, Factors: factors}
+			s.InputUnits, s.OutputUnits = units(b.fields["F06"]), units(b.fields["F07"])
+		case "B062":
+			coeffs, err := b.values("F15", 1)
+			if err != nil {
+				return nil, err
+			}
+			var poly config.Polynomial
+			if strings.ToUpper(b.word("F07")) == "M" {
+				poly.ApproximationType = "MACLAURIN"
+			}
+			for k, v := range map[string]*float64{
+				"F09": &poly.FrequencyLowerBound,
+				"F10": &poly.FrequencyUpperBound,
+				"F11": &poly.ApproximationLowerBound,
+				"F12": &poly.ApproximationUpperBound,
+				"F13": &poly.MaximumError,
+			} {
+				if *v, err = b.float(k); err != nil {
+					return nil, err
+				}
+			}
+			poly.Coefficients = coeffs
+			s.Type, s.Polynomial = "poly", poly
+			s.InputUnits, s.OutputUnits = units(b.fields["F05"]), units(b.fields["F06"])
+		case "B057":
+			rate, err := b.float("F04")
+			if err != nil {
+				return nil, err
+			}
+			factor, err := b.int("F05")
+			if err != nil {
+				return nil, err
+			}
+			if s.Delay, err = b.float("F07"); err != nil {
+				return nil, err
+			}
+			if s.Correction, err = b.float("F08"); err != nil {
+				return nil, err
+			}
+			s.Decimate, s.SampleRate = int32(factor), rate
+			if factor > 0 {
+				s.SampleRate = rate / float64(factor)
+			}
+		case "B058":
+			if s.Gain, err = b.float("F04"); err != nil {
+				return nil, err
+			}
+			if s.Frequency, err = b.float("F05"); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	var keys []int
+	for k := range stages {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+
+	var list []Stage
+	for _, k := range keys {
+		list = append(list, gainStage(*stages[k]))
+	}
+
+	return list, nil
+}
+
+// gainStage converts stages without any poles, zeros or coefficients into either analogue to digital
+// converters or simple gain stages.
+func gainStage(s Stage) Stage {
+	switch {
+	case s.Type == "paz" && (len(s.PAZ.Poles) > 0 || len(s.PAZ.Zeros) > 0):
+		return s
+	case s.Type == "fir" && len(s.FIR.Factors) > 0:
+		return s
+	case s.Type == "poly":
+		return s
+	case s.InputUnits == "V" && s.OutputUnits == "count":
+		s.Type = "a2d"
+		return s
+	default:
+		code := s.PAZ.Code
+		if code == "" {
+			code = "A"
+		}
+		s.Type = "paz"
+		s.PAZ = config.PAZ{Code: code, Type: pazTypes[code], Poles: []config.Complex128{}, Zeros: []config.Complex128{}}
+		return s
+	}
+}
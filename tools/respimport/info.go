@@ -0,0 +1,171 @@
+package main
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/GeoNet/delta/resp"
+	"github.com/GeoNet/delta/resp/config"
+	"gopkg.in/yaml.v2"
+)
+
+// Encode returns the response information as a YAML document in the layout of the response files.
+func Encode(r config.ResponseInfo) ([]byte, error) {
+	var b []byte
+	if len(r.SensorModel)+len(r.DataloggerModel)+len(r.PAZ)+len(r.Polynomial)+len(r.FIR)+len(r.Filter) > 0 {
+		v, err := yaml.Marshal(r)
+		if err != nil {
+			return nil, err
+		}
+		b = v
+	}
+	return append(append([]byte("---\n"), b...), []byte("\n# vim: tabstop=2 expandtab shiftwidth=2 softtabstop=2\n")...), nil
+}
+
+// similar compares two values using a relative tolerance suitable for values read from text files.
+func similar(a, b float64) bool {
+	if a == b {
+		return true
+	}
+	return math.Abs(a-b) <= 1.0e-6*math.Max(math.Abs(a), math.Abs(b))
+}
+
+func similarComplex(a, b []config.Complex128) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !similar(real(a[i]), real(b[i])) || !similar(imag(a[i]), imag(b[i])) {
+			return false
+		}
+	}
+	return true
+}
+
+func similarFloats(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !similar(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// keys returns the sorted keys of a map of response entries.
+func keys(m interface{}) []string {
+	var list []string
+	switch m := m.(type) {
+	case map[string]config.PAZ:
+		for k := range m {
+			list = append(list, k)
+		}
+	case map[string]config.FIR:
+		for k := range m {
+			list = append(list, k)
+		}
+	case map[string]config.Polynomial:
+		for k := range m {
+			list = append(list, k)
+		}
+	case map[string][]config.ResponseStage:
+		for k := range m {
+			list = append(list, k)
+		}
+	}
+	sort.Strings(list)
+	return list
+}
+
+// MatchPAZ returns the name of an existing set of poles and zeros with the same response.
+func MatchPAZ(r *config.ResponseInfo, paz config.PAZ) (string, bool) {
+	for _, k := range keys(r.PAZ) {
+		v := r.PAZ[k]
+		if !strings.EqualFold(v.Code, paz.Code) {
+			continue
+		}
+		if similarComplex(v.Poles, paz.Poles) && similarComplex(v.Zeros, paz.Zeros) {
+			return k, true
+		}
+	}
+	return "", false
+}
+
+// MatchFIR returns the name of an existing FIR filter with the same coefficients and decimation.
+func MatchFIR(r *config.ResponseInfo, fir config.FIR) (string, bool) {
+	for _, k := range keys(r.FIR) {
+		v := r.FIR[k]
+		if !similar(v.Decimation, fir.Decimation) || !similar(v.Gain, fir.Gain) {
+			continue
+		}
+		if similarFloats(coefficients(v), coefficients(fir)) {
+			return k, true
+		}
+	}
+	return "", false
+}
+
+// MatchPolynomial returns the name of an existing polynomial with the same coefficients.
+func MatchPolynomial(r *config.ResponseInfo, poly config.Polynomial) (string, bool) {
+	for _, k := range keys(r.Polynomial) {
+		v := r.Polynomial[k]
+		if !similar(v.Gain, poly.Gain) || !strings.EqualFold(v.ApproximationType, poly.ApproximationType) {
+			continue
+		}
+		if similarFloats(v.Coefficients, poly.Coefficients) {
+			return k, true
+		}
+	}
+	return "", false
+}
+
+// MatchFilter returns the name of an existing filter with the same stages, any delay corrections are ignored.
+func MatchFilter(r *config.ResponseInfo, stages []config.ResponseStage) (string, bool) {
+	for _, k := range keys(r.Filter) {
+		v := r.Filter[k]
+		if len(v) != len(stages) {
+			continue
+		}
+		match := true
+		for i := range v {
+			a, b := v[i], stages[i]
+			switch {
+			case a.Type != b.Type, a.Lookup != b.Lookup:
+				match = false
+			case !similar(a.Frequency, b.Frequency), !similar(a.SampleRate, b.SampleRate), !similar(a.Gain, b.Gain):
+				match = false
+			case decimation(a.Decimate) != decimation(b.Decimate):
+				match = false
+			case a.InputUnits != b.InputUnits, a.OutputUnits != b.OutputUnits:
+				match = false
+			}
+		}
+		if match {
+			return k, true
+		}
+	}
+	return "", false
+}
+
+// decimation treats missing decimation factors as no decimation.
+func decimation(d int32) int32 {
+	if d < 1 {
+		return 1
+	}
+	return d
+}
+
+// coefficients returns the full list of filter coefficients, expanding any symmetrical filters.
+func coefficients(f config.FIR) []float64 {
+	fir := resp.FIR{Factors: f.Factors}
+	switch strings.ToUpper(f.Symmetry) {
+	case "EVEN":
+		fir.Symmetry = resp.SymmetryEven
+	case "ODD":
+		fir.Symmetry = resp.SymmetryOdd
+	}
+	return fir.Coefficients()
+}
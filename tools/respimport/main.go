@@ -0,0 +1,125 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/GeoNet/delta/resp/config"
+)
+
+func main() {
+
+	var base string
+	flag.StringVar(&base, "base", "../..", "base of delta files on disk, existing responses are used to avoid duplicate entries")
+
+	var sensor string
+	flag.StringVar(&sensor, "sensor", "", "sensor model name to use for the sensor stages")
+
+	var datalogger string
+	flag.StringVar(&datalogger, "datalogger", "", "datalogger model name to use for the datalogger stages")
+
+	var channel string
+	flag.StringVar(&channel, "channel", "", "StationXML NET.STA.LOC.CHA prefix used to select a channel response")
+
+	var output string
+	flag.StringVar(&output, "output", "", "output response yaml file")
+
+	var verbose bool
+	flag.BoolVar(&verbose, "verbose", false, "report existing entries that have been reused")
+
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "\n")
+		fmt.Fprintf(os.Stderr, "Convert a RESP or StationXML response into delta response YAML entries\n")
+		fmt.Fprintf(os.Stderr, "\n")
+		fmt.Fprintf(os.Stderr, "Usage:\n")
+		fmt.Fprintf(os.Stderr, "\n")
+		fmt.Fprintf(os.Stderr, "  %s [options] <resp|stationxml>\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fmt.Fprintf(os.Stderr, "\n")
+		flag.PrintDefaults()
+		fmt.Fprintf(os.Stderr, "\n")
+		fmt.Fprintf(os.Stderr, "Sensor stages are those prior to the first stage with an input of V or count,\n")
+		fmt.Fprintf(os.Stderr, "the remaining stages are assigned to the datalogger.\n")
+		fmt.Fprintf(os.Stderr, "\n")
+	}
+
+	flag.Parse()
+
+	if flag.NArg() != 1 || (sensor == "" && datalogger == "") {
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	existing := config.NewResponseInfo()
+	if base != "" {
+		responses := filepath.Join(base, "resp", "responses")
+		info, err := config.LoadDir(responses)
+		if err != nil {
+			log.Fatalf("error: unable to load responses %s: %v", responses, err)
+		}
+		existing = info
+	}
+
+	raw, err := ioutil.ReadFile(flag.Arg(0))
+	if err != nil {
+		log.Fatalf("error: unable to read %s: %v", flag.Arg(0), err)
+	}
+
+	sensorEquipment, dataloggerEquipment := Equipment{Description: sensor}, Equipment{Type: "Datalogger", Description: datalogger}
+
+	var stages []Stage
+	switch {
+	case bytes.Contains(raw, []byte("<FDSNStationXML")):
+		list, se, dl, err := DecodeStationXML(bytes.NewReader(raw), channel)
+		if err != nil {
+			log.Fatalf("error: unable to decode stationxml %s: %v", flag.Arg(0), err)
+		}
+		if se.Description != "" {
+			sensorEquipment = se
+		}
+		if dl.Description != "" {
+			dataloggerEquipment = dl
+		}
+		stages = list
+	default:
+		list, err := DecodeRESP(bytes.NewReader(raw))
+		if err != nil {
+			log.Fatalf("error: unable to decode resp %s: %v", flag.Arg(0), err)
+		}
+		stages = list
+	}
+
+	builder := NewBuilder(existing)
+	if err := builder.Add(sensor, datalogger, stages, sensorEquipment, dataloggerEquipment, defaultComponents); err != nil {
+		log.Fatalf("error: unable to convert %s: %v", flag.Arg(0), err)
+	}
+
+	if verbose {
+		for _, r := range builder.Reused() {
+			log.Printf("reusing %s", r)
+		}
+	}
+
+	res, err := Encode(builder.Info())
+	if err != nil {
+		log.Fatalf("error: unable to encode responses: %v", err)
+	}
+
+	switch output {
+	case "", "-":
+		os.Stdout.Write(res)
+	default:
+		if err := os.MkdirAll(filepath.Dir(output), 0755); err != nil {
+			log.Fatalf("error: unable to create directory %s: %v", filepath.Dir(output), err)
+		}
+		if err := ioutil.WriteFile(output, res, 0644); err != nil {
+			log.Fatalf("error: unable to write file %s: %v", output, err)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/GeoNet/delta/resp/config"
+)
+
+func TestDecodeRESP(t *testing.T) {
+
+	f, err := os.Open("testdata/RESP.NZ.TEST.10.HHZ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	stages, err := DecodeRESP(f)
+	if err != nil {
+		t.Fatalf("error: unable to decode test resp file: %v", err)
+	}
+	if len(stages) != 3 {
+		t.Fatalf("invalid number of stages: got %d, expected %d", len(stages), 3)
+	}
+
+	builder := NewBuilder(config.NewResponseInfo())
+	if err := builder.Add("TEST-SENSOR", "TEST-DATALOGGER", stages, Equipment{Description: "TEST-SENSOR"}, Equipment{Type: "Datalogger", Description: "TEST-DATALOGGER"}, defaultComponents); err != nil {
+		t.Fatal(err)
+	}
+
+	b1, err := ioutil.ReadFile("testdata/RESP.NZ.TEST.10.HHZ.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b2, err := Encode(builder.Info())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b1) != string(b2) {
+		t.Errorf("**** resp yaml mismatch ****\n%s", string(b2))
+	}
+}
+
+func TestDecodeStationXML(t *testing.T) {
+
+	f, err := os.Open("testdata/NZ.WEL.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	stages, se, dl, err := DecodeStationXML(f, "NZ.WEL.10.HH")
+	if err != nil {
+		t.Fatalf("error: unable to decode test stationxml file: %v", err)
+	}
+	if se.Description != "CMG-3ESP" || dl.Manufacturer != "Quanterra" {
+		t.Errorf("invalid equipment: %v %v", se, dl)
+	}
+
+	existing, err := config.LoadDir("../../resp/responses")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	builder := NewBuilder(existing)
+	if err := builder.Add("CMG-3ESP", "Q330/6", stages, se, dl, defaultComponents); err != nil {
+		t.Fatal(err)
+	}
+
+	info := builder.Info()
+	if n := len(info.PAZ) + len(info.FIR) + len(info.Polynomial) + len(info.Filter); n != 0 {
+		t.Errorf("expected existing responses to be reused, found %d new entries", n)
+	}
+	if len(builder.Reused()) != 4 {
+		t.Errorf("invalid number of reused entries: got %d, expected %d: %v", len(builder.Reused()), 4, builder.Reused())
+	}
+}
@@ -0,0 +1,253 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/GeoNet/delta/resp/config"
+)
+
+// Stage is a single response stage as decoded from either a RESP file or a StationXML response.
+type Stage struct {
+	Type string // one of paz, fir, poly or a2d
+	Name string
+
+	PAZ        config.PAZ
+	FIR        config.FIR
+	Polynomial config.Polynomial
+
+	Frequency   float64
+	SampleRate  float64
+	Decimate    int32
+	Gain        float64
+	Correction  float64
+	Delay       float64
+	InputUnits  string
+	OutputUnits string
+}
+
+// Equipment describes the sensor or datalogger associated with a response.
+type Equipment struct {
+	Type         string
+	Description  string
+	Manufacturer string
+	Vendor       string
+}
+
+var unitsLookup = map[string]string{
+	"M":       "m",
+	"M/S":     "m/s",
+	"M/S**2":  "m/s**2",
+	"M/S/S":   "m/s**2",
+	"M/S2":    "m/s**2",
+	"V":       "V",
+	"VOLTS":   "V",
+	"A":       "A",
+	"C":       "C",
+	"PA":      "PA",
+	"HPA":     "hPa",
+	"COUNT":   "count",
+	"COUNTS":  "count",
+	"COUNT/S": "count",
+}
+
+// units converts response units into the names used by the response files.
+func units(s string) string {
+	name := strings.TrimSpace(strings.SplitN(s, " - ", 2)[0])
+	if u, ok := unitsLookup[strings.ToUpper(name)]; ok {
+		return u
+	}
+	return name
+}
+
+// Builder converts decoded response stages into response file entries, it reuses any matching
+// entries that already exist in the response library.
+type Builder struct {
+	existing *config.ResponseInfo
+	info     *config.ResponseInfo
+	reused   map[string]string
+}
+
+func NewBuilder(existing *config.ResponseInfo) *Builder {
+	return &Builder{
+		existing: existing,
+		info:     config.NewResponseInfo(),
+		reused:   make(map[string]string),
+	}
+}
+
+// Info returns the new response entries.
+func (b *Builder) Info() config.ResponseInfo {
+	return *b.info
+}
+
+// Reused returns a description of the existing entries which have been used in place of new ones.
+func (b *Builder) Reused() []string {
+	var list []string
+	for k, v := range b.reused {
+		list = append(list, fmt.Sprintf("%s: %s", k, v))
+	}
+	sort.Strings(list)
+	return list
+}
+
+func (b *Builder) paz(name string, paz config.PAZ) (string, error) {
+	if k, ok := MatchPAZ(b.existing, paz); ok {
+		b.reused["paz "+name] = k
+		return k, nil
+	}
+	if _, ok := b.existing.PAZ[name]; ok {
+		return "", fmt.Errorf("paz %q already exists with a different response", name)
+	}
+	b.info.PAZ[name] = paz
+	return name, nil
+}
+
+func (b *Builder) fir(name string, fir config.FIR) (string, error) {
+	if k, ok := MatchFIR(b.existing, fir); ok {
+		b.reused["fir "+name] = k
+		return k, nil
+	}
+	if _, ok := b.existing.FIR[name]; ok {
+		return "", fmt.Errorf("fir %q already exists with different coefficients", name)
+	}
+	b.info.FIR[name] = fir
+	return name, nil
+}
+
+func (b *Builder) polynomial(name string, poly config.Polynomial) (string, error) {
+	if k, ok := MatchPolynomial(b.existing, poly); ok {
+		b.reused["polynomial "+name] = k
+		return k, nil
+	}
+	if _, ok := b.existing.Polynomial[name]; ok {
+		return "", fmt.Errorf("polynomial %q already exists with different coefficients", name)
+	}
+	b.info.Polynomial[name] = poly
+	return name, nil
+}
+
+// filter adds the given stages as a named filter, returning the name of the filter used.
+func (b *Builder) filter(name string, stages []Stage) (string, error) {
+	var list []config.ResponseStage
+	for i, s := range stages {
+		lookup := s.Name
+		if lookup == "" {
+			lookup = name
+			if len(stages) > 1 {
+				lookup = fmt.Sprintf("%s-%d", name, i+1)
+			}
+		}
+
+		stage := config.ResponseStage{
+			Type:        s.Type,
+			Frequency:   s.Frequency,
+			SampleRate:  s.SampleRate,
+			Decimate:    s.Decimate,
+			Gain:        s.Gain,
+			Scale:       1,
+			Correction:  s.Correction,
+			Delay:       s.Delay,
+			InputUnits:  s.InputUnits,
+			OutputUnits: s.OutputUnits,
+		}
+
+		var err error
+		switch s.Type {
+		case "a2d":
+			stage.Lookup, stage.Scale, stage.Frequency = "A2D", 0, 0
+		case "paz":
+			stage.Lookup, err = b.paz(lookup, s.PAZ)
+		case "poly":
+			poly := s.Polynomial
+			poly.Gain, stage.Gain = s.Gain, 0
+			stage.Lookup, err = b.polynomial(lookup, poly)
+		case "fir":
+			fir := s.FIR
+			fir.Decimation, fir.Gain = float64(s.Decimate), s.Gain
+			if fir.Decimation < 1 {
+				fir.Decimation = 1
+			}
+			if fir.Gain == 0 {
+				fir.Gain = 1
+			}
+			// the response generator derives these from the filter
+			stage.Frequency, stage.Decimate, stage.Gain, stage.Scale, stage.Delay = 0, 0, 0, 0, 0
+			if fir.Decimation > 1 && similar(s.Correction, fir.Correction(s.SampleRate)) {
+				stage.Correction = 0
+			}
+			stage.Lookup, err = b.fir(lookup, fir)
+		default:
+			err = fmt.Errorf("unknown stage type %q", s.Type)
+		}
+		if err != nil {
+			return "", err
+		}
+
+		list = append(list, stage)
+	}
+
+	if k, ok := MatchFilter(b.existing, list); ok {
+		b.reused["filter "+name] = k
+		return k, nil
+	}
+	if _, ok := b.existing.Filter[name]; ok {
+		return "", fmt.Errorf("filter %q already exists with different stages", name)
+	}
+	b.info.Filter[name] = list
+
+	return name, nil
+}
+
+// analogue returns the number of leading stages which belong to the sensor, i.e. those prior to
+// the first stage with an electrical or digital input.
+func analogue(stages []Stage) int {
+	for i, s := range stages {
+		switch s.InputUnits {
+		case "V", "count":
+			return i
+		}
+	}
+	return len(stages)
+}
+
+// Add converts the given stages into sensor and datalogger entries, either name may be empty
+// in which case the corresponding stages are ignored.
+func (b *Builder) Add(sensor, datalogger string, stages []Stage, se, dl Equipment, components []config.SensorComponent) error {
+	n := analogue(stages)
+
+	if sensor != "" && n > 0 {
+		if _, err := b.filter(sensor, stages[:n]); err != nil {
+			return err
+		}
+		if _, ok := b.existing.SensorModel[sensor]; !ok {
+			b.info.SensorModel[sensor] = config.SensorModel{
+				Type:         se.Type,
+				Description:  se.Description,
+				Manufacturer: se.Manufacturer,
+				Vendor:       se.Vendor,
+				Components:   components,
+			}
+		}
+	}
+
+	if datalogger != "" && n < len(stages) {
+		if _, err := b.filter(datalogger, stages[n:]); err != nil {
+			return err
+		}
+		if _, ok := b.existing.DataloggerModel[datalogger]; !ok {
+			b.info.DataloggerModel[datalogger] = config.DataloggerModel{
+				Type:         dl.Type,
+				Description:  dl.Description,
+				Manufacturer: dl.Manufacturer,
+				Vendor:       dl.Vendor,
+			}
+		}
+	}
+
+	return nil
+}
+
+// defaultComponents are used for new sensor models, the usual vertical, north and east layout.
+var defaultComponents = []config.SensorComponent{{Azimuth: 0, Dip: -90}, {Azimuth: 0, Dip: 0}, {Azimuth: 90, Dip: 0}}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	"github.com/GeoNet/delta/resp/config"
+	"github.com/ozym/fdsn/stationxml"
+)
+
+var pzCodes = map[stationxml.PzTransferFunctionType]string{
+	stationxml.PZFunctionLaplaceRadiansPerSecond: "A",
+	stationxml.PZFunctionLaplaceHertz:            "B",
+	stationxml.PZFunctionLaplaceZTransform:       "D",
+}
+
+var symmetryNames = map[stationxml.Symmetry]string{
+	stationxml.SymmetryEven: "even",
+	stationxml.SymmetryOdd:  "odd",
+}
+
+func equipment(e *stationxml.Equipment) Equipment {
+	if e == nil {
+		return Equipment{}
+	}
+	desc := e.Description
+	if desc == "" {
+		desc = e.Model
+	}
+	return Equipment{
+		Type:         e.Type,
+		Description:  desc,
+		Manufacturer: e.Manufacturer,
+		Vendor:       e.Vendor,
+	}
+}
+
+func poleZeros(list []stationxml.PoleZero) []config.Complex128 {
+	values := []config.Complex128{}
+	for _, pz := range list {
+		values = append(values, config.Complex128(complex(pz.Real.Value, pz.Imaginary.Value)))
+	}
+	return values
+}
+
+// decodeStage converts a single StationXML response stage.
+func decodeStage(rs stationxml.ResponseStage) (Stage, error) {
+	s := Stage{
+		Gain:      rs.StageGain.Value,
+		Frequency: rs.StageGain.Frequency,
+	}
+
+	if d := rs.Decimation; d != nil {
+		s.Decimate, s.SampleRate = d.Factor, d.InputSampleRate.Value
+		if d.Factor > 0 {
+			s.SampleRate = d.InputSampleRate.Value / float64(d.Factor)
+		}
+		s.Delay, s.Correction = d.Delay.Value, d.Correction.Value
+	}
+
+	switch {
+	case rs.PolesZeros != nil:
+		pz := rs.PolesZeros
+		code := pzCodes[pz.PzTransferFunctionType]
+		s.Type, s.Name = "paz", ""
+		s.PAZ = config.PAZ{Code: code, Type: pazTypes[code], Notes: pz.Description, Poles: poleZeros(pz.Poles), Zeros: poleZeros(pz.Zeros)}
+		s.InputUnits, s.OutputUnits = units(pz.InputUnits.Name), units(pz.OutputUnits.Name)
+	case rs.FIR != nil:
+		fir := rs.FIR
+		var factors []float64
+		for _, c := range fir.NumeratorCoefficients {
+			factors = append(factors, c.Value)
+		}
+		symmetry, ok := symmetryNames[fir.Symmetry]
+		if !ok {
+			symmetry = "none"
+		}
+		s.Type, s.Name = "fir", fir.Name
+		s.FIR = config.FIR{Symmetry: symmetry, Notes: fir.Description, Factors: factors}
+		s.InputUnits, s.OutputUnits = units(fir.InputUnits.Name), units(fir.OutputUnits.Name)
+	case rs.Coefficients != nil:
+		c := rs.Coefficients
+		if len(c.Denominators) > 0 {
+			return Stage{}, fmt.Errorf("stage %d: coefficient responses with denominators are not supported", rs.Number)
+		}
+		var factors []float64
+		for _, n := range c.Numerators {
+			factors = append(factors, n.Value)
+		}
+		s.Type, s.Name = "fir", ""
+		s.FIR = config.FIR{Symmetry: "none", Notes: c.Description, Factors: factors}
+		s.InputUnits, s.OutputUnits = units(c.InputUnits.Name), units(c.OutputUnits.Name)
+	case rs.Polynomial != nil:
+		p := rs.Polynomial
+		poly := config.Polynomial{
+			FrequencyLowerBound: p.FrequencyLowerBound.Value,
+			FrequencyUpperBound: p.FrequencyUpperBound.Value,
+			MaximumError:        p.MaximumError,
+			Notes:               p.Description,
+		}
+		if p.ApproximationType == stationxml.ApproximationTypeMaclaurin {
+			poly.ApproximationType = "MACLAURIN"
+		}
+		for k, v := range map[string]*float64{
+			p.ApproximationLowerBound: &poly.ApproximationLowerBound,
+			p.ApproximationUpperBound: &poly.ApproximationUpperBound,
+		} {
+			if k == "" {
+				continue
+			}
+			f, err := strconv.ParseFloat(k, 64)
+			if err != nil {
+				return Stage{}, fmt.Errorf("stage %d: invalid approximation bound %q", rs.Number, k)
+			}
+			*v = f
+		}
+		for _, c := range p.Coefficients {
+			poly.Coefficients = append(poly.Coefficients, c.Value)
+		}
+		s.Type, s.Polynomial = "poly", poly
+		s.InputUnits, s.OutputUnits = units(p.InputUnits.Name), units(p.OutputUnits.Name)
+	default:
+		return Stage{}, fmt.Errorf("stage %d: unsupported response type", rs.Number)
+	}
+
+	return gainStage(s), nil
+}
+
+// DecodeStationXML decodes the response stages of the first channel in a StationXML file which has a
+// response and matches the given NET.STA.LOC.CHA prefix, the associated sensor and datalogger
+// descriptions are also returned.
+func DecodeStationXML(rd io.Reader, match string) ([]Stage, Equipment, Equipment, error) {
+	b, err := ioutil.ReadAll(rd)
+	if err != nil {
+		return nil, Equipment{}, Equipment{}, err
+	}
+
+	var root stationxml.FDSNStationXML
+	if err := xml.Unmarshal(b, &root); err != nil {
+		return nil, Equipment{}, Equipment{}, err
+	}
+
+	for _, n := range root.Networks {
+		for _, s := range n.Stations {
+			for _, c := range s.Channels {
+				if c.Response == nil || len(c.Response.Stages) == 0 {
+					continue
+				}
+				if id := strings.Join([]string{n.Code, s.Code, c.LocationCode, c.Code}, "."); !strings.HasPrefix(id, match) {
+					continue
+				}
+
+				var stages []Stage
+				for _, rs := range c.Response.Stages {
+					stage, err := decodeStage(rs)
+					if err != nil {
+						return nil, Equipment{}, Equipment{}, err
+					}
+					stages = append(stages, stage)
+				}
+
+				return stages, equipment(c.Sensor), equipment(c.DataLogger), nil
+			}
+		}
+	}
+
+	return nil, Equipment{}, Equipment{}, fmt.Errorf("no matching channel response found")
+}
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/GeoNet/delta/meta"
+	"github.com/GeoNet/delta/metadb"
 )
 
 const (
@@ -125,6 +126,22 @@ func (s SessionList) Len() int           { return len(s) }
 func (s SessionList) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
 func (s SessionList) Less(i, j int) bool { return s[i].Start.After(s[j].Start) }
 
+// formatNullable returns a formatted value, or an empty string if the value is not known.
+func formatNullable(value float64, ok bool) string {
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("%.4f", value)
+}
+
+// formatHeight formats a mark height as a plain number, an unknown height is left empty.
+func formatHeight(value float64, ok bool) string {
+	if !ok {
+		return ""
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
 func main() {
 
 	var verbose bool
@@ -139,11 +156,8 @@ func main() {
 	var stopped string
 	flag.StringVar(&stopped, "stopped", "stopped.xml", "stopped status file name")
 
-	var network string
-	flag.StringVar(&network, "network", "../../network", "base network directory")
-
-	var install string
-	flag.StringVar(&install, "install", "../../install", "base install directory")
+	var base string
+	flag.StringVar(&base, "base", "../..", "base of delta files on disk")
 
 	flag.Usage = func() {
 		fmt.Fprintf(os.Stderr, "\n")
@@ -161,132 +175,77 @@ func main() {
 
 	flag.Parse()
 
-	var firmwareHistoryList meta.FirmwareHistoryList
-	if err := meta.LoadList(filepath.Join(install, "firmware.csv"), &firmwareHistoryList); err != nil {
-		fmt.Fprintf(os.Stderr, "error: unable to load firmware history: %v\n", err)
-		os.Exit(-1)
-	}
-
-	firmwareHistory := make(map[string]map[string][]meta.FirmwareHistory)
-	for _, i := range firmwareHistoryList {
-		if _, ok := firmwareHistory[i.Model]; !ok {
-			firmwareHistory[i.Model] = make(map[string][]meta.FirmwareHistory)
-		}
-		firmwareHistory[i.Model][i.Serial] = append(firmwareHistory[i.Model][i.Serial], i)
-	}
-
-	for j := range firmwareHistory {
-		for k := range firmwareHistory[j] {
-			sort.Sort(meta.FirmwareHistoryList(firmwareHistory[j][k]))
-		}
-	}
+	db := metadb.NewMetaDB(base)
 
-	var installedAntennaList meta.InstalledAntennaList
-	if err := meta.LoadList(filepath.Join(install, "antennas.csv"), &installedAntennaList); err != nil {
-		fmt.Fprintf(os.Stderr, "error: unable to load antenna installs: %v\n", err)
-		os.Exit(-1)
-	}
-
-	installedAntenna := make(map[string][]meta.InstalledAntenna)
-	for _, i := range installedAntennaList {
-		installedAntenna[i.Mark] = append(installedAntenna[i.Mark], i)
-	}
-	for i := range installedAntenna {
-		sort.Sort(sort.Reverse(meta.InstalledAntennaList(installedAntenna[i])))
-	}
-
-	var deployedReceiverList meta.DeployedReceiverList
-	if err := meta.LoadList(filepath.Join(install, "receivers.csv"), &deployedReceiverList); err != nil {
-		fmt.Fprintf(os.Stderr, "error: unable to load receiver installs: %v\n", err)
-		os.Exit(-1)
-	}
-
-	deployedReceivers := make(map[string][]meta.DeployedReceiver)
-	for _, i := range deployedReceiverList {
-		deployedReceivers[i.Mark] = append(deployedReceivers[i.Mark], i)
-	}
-	for i := range deployedReceivers {
-		sort.Sort(sort.Reverse(meta.DeployedReceiverList(deployedReceivers[i])))
-	}
-
-	var installedRadomeList meta.InstalledRadomeList
-	if err := meta.LoadList(filepath.Join(install, "radomes.csv"), &installedRadomeList); err != nil {
-		fmt.Fprintf(os.Stderr, "error: unable to load radome installs: %v\n", err)
-		os.Exit(-1)
-	}
-
-	installedRadomes := make(map[string][]meta.InstalledRadome)
-	for _, i := range installedRadomeList {
-		installedRadomes[i.Mark] = append(installedRadomes[i.Mark], i)
-	}
-	for i := range installedRadomes {
-		sort.Sort(meta.InstalledRadomeList(installedRadomes[i]))
-	}
-
-	var installedMetSensorList meta.InstalledMetSensorList
-	if err := meta.LoadList(filepath.Join(install, "metsensors.csv"), &installedMetSensorList); err != nil {
-		fmt.Fprintf(os.Stderr, "error: unable to load metsensors installs: %v\n", err)
-		os.Exit(-1)
-	}
-
-	installedMetSensors := make(map[string][]meta.InstalledMetSensor)
-	for _, i := range installedMetSensorList {
-		installedMetSensors[i.Mark] = append(installedMetSensors[i.Mark], i)
-	}
-	for i := range installedMetSensors {
-		sort.Sort(meta.InstalledMetSensorList(installedMetSensors[i]))
-	}
-
-	var markList meta.MarkList
-	if err := meta.LoadList(filepath.Join(network, "marks.csv"), &markList); err != nil {
+	marks, err := db.Marks()
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "error: unable to load mark list: %v\n", err)
 		os.Exit(-1)
 	}
 
-	var monumentList meta.MonumentList
-	if err := meta.LoadList(filepath.Join(network, "monuments.csv"), &monumentList); err != nil {
-		fmt.Fprintf(os.Stderr, "error: unable to load monuments list: %v\n", err)
-		os.Exit(-1)
-	}
-	monuments := make(map[string]meta.Monument)
-	for _, m := range monumentList {
-		monuments[m.Mark] = m
-	}
-
-	var sessionList meta.SessionList
-	if err := meta.LoadList(filepath.Join(install, "sessions.csv"), &sessionList); err != nil {
-		fmt.Fprintf(os.Stderr, "error: unable to load session list: %v\n", err)
-		os.Exit(-1)
-	}
-
-	sort.Sort(SessionList(sessionList))
-
 	var on, off []Mark
 
-	sessions := make(map[string][]meta.Session)
-	for _, s := range sessionList {
-		sessions[s.Mark] = append(sessions[s.Mark], s)
-	}
-
-	for _, m := range markList {
-		if _, ok := sessions[m.Code]; !ok {
+	for _, m := range marks {
+		markSessions, err := db.MarkSessions(m.Code)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: unable to load session list: %v\n", err)
+			os.Exit(-1)
+		}
+		if len(markSessions) == 0 {
 			continue
 		}
-		if _, ok := installedAntenna[m.Code]; !ok {
+		// sort a copy, the sessions are shared with the database
+		sessions := append([]meta.Session{}, markSessions...)
+		sort.Sort(SessionList(sessions))
+
+		installedAntennas, err := db.MarkInstalledAntennas(m.Code)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: unable to load antenna installs: %v\n", err)
+			os.Exit(-1)
+		}
+		if len(installedAntennas) == 0 {
 			continue
 		}
-		if _, ok := deployedReceivers[m.Code]; !ok {
+		sort.Sort(sort.Reverse(meta.InstalledAntennaList(installedAntennas)))
+
+		deployedReceivers, err := db.MarkDeployedReceivers(m.Code)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: unable to load receiver installs: %v\n", err)
+			os.Exit(-1)
+		}
+		if len(deployedReceivers) == 0 {
 			continue
 		}
+		sort.Sort(sort.Reverse(meta.DeployedReceiverList(deployedReceivers)))
+
+		installedRadomes, err := db.MarkInstalledRadomes(m.Code)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: unable to load radome installs: %v\n", err)
+			os.Exit(-1)
+		}
+		sort.Sort(meta.InstalledRadomeList(installedRadomes))
+
+		installedMetSensors, err := db.MarkInstalledMetSensors(m.Code)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: unable to load metsensors installs: %v\n", err)
+			os.Exit(-1)
+		}
+		sort.Sort(meta.InstalledMetSensorList(installedMetSensors))
+
+		monument, err := db.Monument(m.Code)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: unable to load monuments list: %v\n", err)
+			os.Exit(-1)
+		}
 
 		var list []CGPSSessionXML
-		for _, s := range sessions[m.Code] {
-			for _, a := range installedAntenna[m.Code] {
+		for _, s := range sessions {
+			for _, a := range installedAntennas {
 				if a.Start.After(s.End) || a.End.Before(s.Start) {
 					continue
 				}
 
-				for _, r := range deployedReceivers[m.Code] {
+				for _, r := range deployedReceivers {
 					if (!r.Start.Before(s.End)) || (!r.End.After(s.Start)) {
 						continue
 					}
@@ -295,57 +254,52 @@ func main() {
 					}
 
 					radome := "NONE"
-					if _, ok := installedRadomes[m.Code]; ok {
-						for _, v := range installedRadomes[m.Code] {
-							if v.Start.After(a.End) || v.Start.After(r.End) {
-								continue
-							}
-							if v.End.Before(a.Start) || v.End.Before(r.Start) {
-								continue
-							}
-							radome = v.Model
+					for _, v := range installedRadomes {
+						if v.Start.After(a.End) || v.Start.After(r.End) {
+							continue
+						}
+						if v.End.Before(a.Start) || v.End.Before(r.Start) {
+							continue
 						}
+						radome = v.Model
 					}
 
 					var metsensor *MetSensor
-					if _, ok := installedMetSensors[m.Code]; ok {
-						for _, v := range installedMetSensors[m.Code] {
-							if (!v.Start.Before(s.End)) || (!v.End.After(s.Start)) {
-								continue
-							}
-							metsensor = &MetSensor{
-								Model:      v.Make,
-								Type:       v.Model + " S/N " + v.Serial,
-								HrAccuracy: strconv.FormatFloat(v.Accuracy.Humidity, 'g', -1, 64),
-								PrAccuracy: strconv.FormatFloat(v.Accuracy.Pressure, 'g', -1, 64),
-								TdAccuracy: strconv.FormatFloat(v.Accuracy.Temperature, 'g', -1, 64),
-							}
+					for _, v := range installedMetSensors {
+						if (!v.Start.Before(s.End)) || (!v.End.After(s.Start)) {
+							continue
+						}
+						metsensor = &MetSensor{
+							Model:      v.Make,
+							Type:       v.Model + " S/N " + v.Serial,
+							HrAccuracy: strconv.FormatFloat(v.Accuracy.Humidity, 'g', -1, 64),
+							PrAccuracy: strconv.FormatFloat(v.Accuracy.Pressure, 'g', -1, 64),
+							TdAccuracy: strconv.FormatFloat(v.Accuracy.Temperature, 'g', -1, 64),
 						}
 					}
 
+					history, err := db.ReceiverFirmware(r.Model, r.Serial)
+					if err != nil {
+						fmt.Fprintf(os.Stderr, "error: unable to load firmware history: %v\n", err)
+						os.Exit(-1)
+					}
+					// sort a copy, the firmware history is shared with the database
+					history = append([]meta.FirmwareHistory{}, history...)
+					sort.Sort(meta.FirmwareHistoryList(history))
+
 					var firmware []FirmwareHistoryXML
-					if _, ok := firmwareHistory[r.Model]; ok {
-						if _, ok := firmwareHistory[r.Model][r.Serial]; ok {
-							for i := range firmwareHistory[r.Model][r.Serial] {
-								v := firmwareHistory[r.Model][r.Serial][len(firmwareHistory[r.Model][r.Serial])-i-1]
-								/*
-									if v.End.Before(r.Start) || v.Start.After(r.End) {
-										continue
-									}
-								*/
-								firmware = append(firmware, FirmwareHistoryXML{
-									StartTime: v.Start.Format(DateTimeFormat),
-									StopTime: func() string {
-										if time.Now().After(v.End) {
-											return v.End.Format(DateTimeFormat)
-										} else {
-											return "open"
-										}
-									}(),
-									Version: v.Version,
-								})
-							}
-						}
+					for i := range history {
+						v := history[len(history)-i-1]
+						firmware = append(firmware, FirmwareHistoryXML{
+							StartTime: v.Start.Format(DateTimeFormat),
+							StopTime: func() string {
+								if v.IsOpen() {
+									return "open"
+								}
+								return v.End.Format(DateTimeFormat)
+							}(),
+							Version: v.Version,
+						})
 					}
 
 					list = append(list, CGPSSessionXML{
@@ -360,23 +314,20 @@ func main() {
 						}(),
 						StopTime: func() string {
 							if r.End.Before(s.End) && r.End.Before(a.End) {
-								if time.Now().After(r.End) {
-									return r.End.Format(DateTimeFormat)
-								} else {
+								if r.IsOpen() {
 									return "open"
 								}
+								return r.End.Format(DateTimeFormat)
 							} else if a.End.Before(s.End) {
-								if time.Now().After(a.End) {
-									return a.End.Format(DateTimeFormat)
-								} else {
+								if a.IsOpen() {
 									return "open"
 								}
+								return a.End.Format(DateTimeFormat)
 							} else {
-								if time.Now().After(s.End) {
-									return s.End.Format(DateTimeFormat)
-								} else {
+								if s.IsOpen() {
 									return "open"
 								}
+								return s.End.Format(DateTimeFormat)
 							}
 						}(),
 						Receiver: ReceiverXML{
@@ -385,9 +336,9 @@ func main() {
 							FirmwareHistories: firmware,
 						},
 						InstalledCGPSAntenna: InstalledCGPSAntennaXML{
-							Height:      Number{Units: "m", Value: fmt.Sprintf("%.4f", a.Vertical)},
-							OffsetNorth: Number{Units: "m", Value: fmt.Sprintf("%.4f", a.North)},
-							OffsetEast:  Number{Units: "m", Value: fmt.Sprintf("%.4f", a.East)},
+							Height:      Number{Units: "m", Value: formatNullable(a.Vertical, a.HasVertical())},
+							OffsetNorth: Number{Units: "m", Value: formatNullable(a.North, a.HasNorth())},
+							OffsetEast:  Number{Units: "m", Value: formatNullable(a.East, a.HasEast())},
 							Radome:      radome,
 							CGPSAntenna: CGPSAntennaXML{
 								SerialNumber:   a.Serial,
@@ -460,8 +411,8 @@ func main() {
 			MarkXML{
 				GeodeticCode: m.Code,
 				DomesNumber: func() string {
-					if c, ok := monuments[m.Code]; ok {
-						return c.DomesNumber
+					if monument != nil {
+						return monument.DomesNumber
 					}
 					return ""
 				}(),
@@ -469,7 +420,7 @@ func main() {
 			LocationXML{
 				Latitude:  m.Latitude,
 				Longitude: m.Longitude,
-				Height:    m.Elevation,
+				Height:    formatHeight(m.Elevation, m.HasElevation()),
 				Datum:     m.Datum,
 			},
 			list,
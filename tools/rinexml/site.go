@@ -21,7 +21,7 @@ type MarkXML struct {
 type LocationXML struct {
 	Latitude  float64 `xml:"latitude"`
 	Longitude float64 `xml:"longitude"`
-	Height    float64 `xml:"height"`
+	Height    string  `xml:"height"`
 	Datum     string  `xml:"datum"`
 }
 
@@ -93,7 +93,7 @@ func TestSiteXML_Marshal(t *testing.T) {
 				Location: LocationXML{
 					Latitude:  -43.490766992,
 					Longitude: 172.481129517,
-					Height:    64.65,
+					Height:    "64.65",
 					Datum:     "WGS84",
 				},
 				Sessions: []CGPSSessionXML{
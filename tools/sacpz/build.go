@@ -0,0 +1,124 @@
+package main
+
+import (
+	"regexp"
+
+	"github.com/GeoNet/delta/metadb"
+)
+
+// Builder selects the channels to output, both open and restricted networks are included.
+type Builder struct {
+	networks *regexp.Regexp
+	stations *regexp.Regexp
+	channels *regexp.Regexp
+}
+
+func NewBuilder(networks, stations, channels string) (*Builder, error) {
+	var b Builder
+	for _, m := range []struct {
+		re  **regexp.Regexp
+		exp string
+	}{
+		{&b.networks, networks},
+		{&b.stations, stations},
+		{&b.channels, channels},
+	} {
+		re, err := regexp.Compile("^(" + m.exp + ")$")
+		if err != nil {
+			return nil, err
+		}
+		*m.re = re
+	}
+	return &b, nil
+}
+
+// Construct returns the SAC pole-zero details of every matching channel epoch.
+func (b *Builder) Construct(base string) ([]Channel, error) {
+	var channels []Channel
+
+	mdb := metadb.NewMetaDB(base)
+
+	stations, err := mdb.Stations()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, station := range stations {
+		if !b.stations.MatchString(station.Code) {
+			continue
+		}
+		network, err := mdb.Network(station.Network)
+		if err != nil {
+			return nil, err
+		}
+		if network == nil || !b.networks.MatchString(network.External) {
+			continue
+		}
+
+		installations, err := mdb.Installations(station.Code)
+		if err != nil {
+			return nil, err
+		}
+		for _, installation := range installations {
+			location, err := mdb.Site(station.Code, installation.Location)
+			if err != nil {
+				return nil, err
+			}
+			if location == nil {
+				continue
+			}
+
+			for _, response := range installation.Streams() {
+				stream, err := mdb.StationLocationSamplingRateStartStream(
+					station.Code,
+					installation.Location,
+					response.Datalogger.SampleRate,
+					installation.Start)
+				if err != nil {
+					return nil, err
+				}
+				if stream == nil {
+					continue
+				}
+
+				pz := Reduce(response)
+
+				lookup := response.Channels(stream.Axial)
+				for pin, comp := range response.Components {
+					if !(pin < len(lookup)) {
+						continue
+					}
+					if !b.channels.MatchString(lookup[pin]) {
+						continue
+					}
+
+					azimuth, dip := response.Orientation(comp, installation.Sensor.Azimuth, stream.Reversed)
+
+					channels = append(channels, Channel{
+						Network:     network.External,
+						Station:     station.Code,
+						Location:    installation.Location,
+						Code:        lookup[pin],
+						Description: station.Name,
+						Sensor:      installation.Sensor.Model,
+						Start:       installation.Start,
+						End:         installation.End,
+						Latitude:    location.Latitude,
+						Longitude:   location.Longitude,
+						Elevation:   location.Elevation,
+						Depth:       -installation.Sensor.Vertical,
+						Azimuth:     azimuth,
+						Dip:         dip,
+						SampleRate:  response.SampleRate,
+						PolesZeros:  pz,
+
+						nullElevation: !location.HasElevation(),
+						nullDepth:     !installation.Sensor.HasVertical(),
+					})
+				}
+			}
+		}
+	}
+
+	return channels, nil
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/GeoNet/delta/resp"
+)
+
+func main() {
+
+	var verbose bool
+	flag.BoolVar(&verbose, "verbose", false, "make noise")
+
+	var base string
+	flag.StringVar(&base, "base", "../..", "base of delta files on disk")
+
+	var output string
+	flag.StringVar(&output, "output", "output", "output SAC pole-zero directory")
+
+	var responses string
+	flag.StringVar(&responses, "responses", "", "optional response YAML directory to use in place of the compiled responses")
+
+	var networks string
+	flag.StringVar(&networks, "networks", "[A-Z0-9]+", "regexp selection of external networks")
+
+	var stations string
+	flag.StringVar(&stations, "stations", "[A-Z0-9]+", "regexp selection of stations")
+
+	var channels string
+	flag.StringVar(&channels, "channels", "[A-Z0-9]+", "regexp selection of channels")
+
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "\n")
+		fmt.Fprintf(os.Stderr, "Build SAC pole-zero files for each channel epoch from delta meta & response information\n")
+		fmt.Fprintf(os.Stderr, "\n")
+		fmt.Fprintf(os.Stderr, "Usage:\n")
+		fmt.Fprintf(os.Stderr, "\n")
+		fmt.Fprintf(os.Stderr, "  %s [options]\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fmt.Fprintf(os.Stderr, "\n")
+		flag.PrintDefaults()
+		fmt.Fprintf(os.Stderr, "\n")
+	}
+
+	flag.Parse()
+
+	if responses != "" {
+		lib, err := resp.LoadDir(responses)
+		if err != nil {
+			log.Fatalf("error: unable to load responses %s: %v", responses, err)
+		}
+		resp.SetLibrary(lib)
+	}
+
+	builder, err := NewBuilder(networks, stations, channels)
+	if err != nil {
+		log.Fatalf("error: unable to make builder: %v", err)
+	}
+
+	list, err := builder.Construct(base)
+	if err != nil {
+		log.Fatalf("error: unable to build channel list: %v", err)
+	}
+
+	if err := os.MkdirAll(output, 0755); err != nil {
+		log.Fatalf("error: unable to create directory %s: %v", output, err)
+	}
+
+	created := time.Now()
+	for _, c := range list {
+		file := filepath.Join(output, c.Filename())
+		if verbose {
+			log.Printf("writing SAC pole-zero file: %s", file)
+		}
+		if err := ioutil.WriteFile(file, c.Marshal(created), 0644); err != nil {
+			log.Fatalf("error: unable to write file %s: %v", file, err)
+		}
+	}
+}
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/GeoNet/delta/resp"
+)
+
+const sacpzTime = "2006-01-02T15:04:05"
+
+// PolesZeros holds the combined analogue response of a stream in radians per second, the input units
+// are displacement where the stream records velocity or acceleration.
+type PolesZeros struct {
+	InputUnits       string
+	OutputUnits      string
+	SensitivityUnits string
+
+	Zeros []complex128
+	Poles []complex128
+
+	A0          float64
+	Sensitivity float64
+}
+
+// Constant returns the SAC normalisation constant.
+func (p PolesZeros) Constant() float64 {
+	return p.A0 * p.Sensitivity
+}
+
+// Reduce combines the analogue pole and zero stages of a stream into a single set given in radians per
+// second, normalised at the datalogger frequency and scaled by the total stream sensitivity.
+func Reduce(stream resp.Stream) PolesZeros {
+	var pz PolesZeros
+
+	for _, stage := range append(append([]resp.ResponseStage{}, stream.Sensor.Stages...), stream.Datalogger.Stages...) {
+		if stage.StageSet == nil {
+			continue
+		}
+		if pz.InputUnits == "" {
+			pz.InputUnits = stage.InputUnits
+		}
+		pz.OutputUnits = stage.OutputUnits
+
+		paz, ok := stage.StageSet.(resp.PAZ)
+		if !ok {
+			continue
+		}
+
+		var scale float64
+		switch paz.Code {
+		case resp.PZFunctionLaplaceRadiansPerSecond:
+			scale = 1.0
+		case resp.PZFunctionLaplaceHertz:
+			scale = 2.0 * math.Pi
+		default:
+			// digital responses have no analogue equivalent
+			continue
+		}
+		for _, z := range paz.Zeros {
+			pz.Zeros = append(pz.Zeros, z*complex(scale, 0.0))
+		}
+		for _, p := range paz.Poles {
+			pz.Poles = append(pz.Poles, p*complex(scale, 0.0))
+		}
+	}
+
+	if g := (resp.PAZ{
+		Code:  resp.PZFunctionLaplaceRadiansPerSecond,
+		Zeros: pz.Zeros,
+		Poles: pz.Poles,
+	}).Gain(stream.Datalogger.Frequency); g > 0.0 {
+		pz.A0 = 1.0 / g
+	}
+	pz.Sensitivity, pz.SensitivityUnits = stream.Gain(), pz.InputUnits
+
+	// convert ground motion into displacement by adding zeros at the origin
+	switch strings.ToLower(pz.InputUnits) {
+	case "m/s":
+		pz.Zeros = append(pz.Zeros, 0)
+		pz.InputUnits = "m"
+	case "m/s**2":
+		pz.Zeros = append(pz.Zeros, 0, 0)
+		pz.InputUnits = "m"
+	}
+
+	return pz
+}
+
+// Channel describes a single channel epoch for a SAC pole-zero file.
+type Channel struct {
+	Network  string
+	Station  string
+	Location string
+	Code     string
+
+	Description string
+	Sensor      string
+
+	Start time.Time
+	End   time.Time
+
+	Latitude  float64
+	Longitude float64
+	Elevation float64
+	Depth     float64
+	Azimuth   float64
+	Dip       float64
+
+	SampleRate float64
+
+	PolesZeros
+
+	nullElevation bool
+	nullDepth     bool
+}
+
+// HasElevation returns whether the channel elevation is known.
+func (c Channel) HasElevation() bool {
+	return !c.nullElevation
+}
+
+// HasDepth returns whether the channel depth is known.
+func (c Channel) HasDepth() bool {
+	return !c.nullDepth
+}
+
+// Filename returns the conventional SAC pole-zero file name for the channel epoch.
+func (c Channel) Filename() string {
+	return fmt.Sprintf("SAC_PZs_%s_%s_%s_%s_%s_%s",
+		c.Network, c.Station, c.Code, func() string {
+			if c.Location != "" {
+				return c.Location
+			}
+			return "__"
+		}(),
+		c.Start.UTC().Format("2006.002.15.04.05.0000"),
+		c.end().Format("2006.002.15.04.05.0000"),
+	)
+}
+
+// sacUndefined is the value used by SAC for header fields that have not been set.
+const sacUndefined = -12345.0
+
+// header formats a header value, unknown values are given as the SAC undefined value.
+func header(value float64, ok bool) string {
+	if !ok {
+		return fmt.Sprintf("%.1f", sacUndefined)
+	}
+	return fmt.Sprintf("%.1f", value)
+}
+
+// end returns the channel end time, open channels are given a far future end time.
+func (c Channel) end() time.Time {
+	if c.End.After(time.Date(2599, time.December, 31, 23, 59, 59, 0, time.UTC)) {
+		return time.Date(2599, time.December, 31, 23, 59, 59, 0, time.UTC)
+	}
+	return c.End.UTC()
+}
+
+// Marshal encodes the channel as a SAC pole-zero file, the created time is added to the header.
+func (c Channel) Marshal(created time.Time) []byte {
+	var buf bytes.Buffer
+
+	units := func(u string) string {
+		switch strings.ToLower(u) {
+		case "count":
+			return "COUNTS"
+		default:
+			return strings.ToUpper(u)
+		}
+	}
+
+	fmt.Fprintln(&buf, "* **********************************")
+	fmt.Fprintf(&buf, "* NETWORK   (KNETWK): %s\n", c.Network)
+	fmt.Fprintf(&buf, "* STATION    (KSTNM): %s\n", c.Station)
+	fmt.Fprintf(&buf, "* LOCATION   (KHOLE): %s\n", c.Location)
+	fmt.Fprintf(&buf, "* CHANNEL   (KCMPNM): %s\n", c.Code)
+	fmt.Fprintf(&buf, "* CREATED           : %s\n", created.UTC().Format(sacpzTime))
+	fmt.Fprintf(&buf, "* START             : %s\n", c.Start.UTC().Format(sacpzTime))
+	fmt.Fprintf(&buf, "* END               : %s\n", c.end().Format(sacpzTime))
+	fmt.Fprintf(&buf, "* DESCRIPTION       : %s\n", c.Description)
+	fmt.Fprintf(&buf, "* LATITUDE          : %.6f\n", c.Latitude)
+	fmt.Fprintf(&buf, "* LONGITUDE         : %.6f\n", c.Longitude)
+	// unknown values are given as the SAC undefined value rather than zero
+	fmt.Fprintf(&buf, "* ELEVATION         : %s\n", header(c.Elevation, c.HasElevation()))
+	fmt.Fprintf(&buf, "* DEPTH             : %s\n", header(c.Depth, c.HasDepth()))
+	// sac measures the inclination from the vertical rather than below the horizontal
+	fmt.Fprintf(&buf, "* DIP               : %.1f\n", c.Dip+90.0)
+	fmt.Fprintf(&buf, "* AZIMUTH           : %.1f\n", c.Azimuth)
+	fmt.Fprintf(&buf, "* SAMPLE RATE       : %g\n", c.SampleRate)
+	fmt.Fprintf(&buf, "* INPUT UNIT        : %s\n", units(c.InputUnits))
+	fmt.Fprintf(&buf, "* OUTPUT UNIT       : %s\n", units(c.OutputUnits))
+	fmt.Fprintf(&buf, "* INSTTYPE          : %s\n", c.Sensor)
+	fmt.Fprintf(&buf, "* SENSITIVITY       : %.6e (%s)\n", c.Sensitivity, units(c.SensitivityUnits))
+	fmt.Fprintf(&buf, "* A0                : %.6e\n", c.A0)
+	fmt.Fprintln(&buf, "* **********************************")
+
+	fmt.Fprintf(&buf, "ZEROS\t%d\n", len(c.Zeros))
+	for _, z := range c.Zeros {
+		fmt.Fprintf(&buf, "\t%+.6e\t%+.6e\n", real(z), imag(z))
+	}
+	fmt.Fprintf(&buf, "POLES\t%d\n", len(c.Poles))
+	for _, p := range c.Poles {
+		fmt.Fprintf(&buf, "\t%+.6e\t%+.6e\n", real(p), imag(p))
+	}
+	fmt.Fprintf(&buf, "CONSTANT\t%.6e\n", c.Constant())
+
+	return buf.Bytes()
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"math"
+	"math/cmplx"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GeoNet/delta/resp"
+)
+
+func TestReduce(t *testing.T) {
+
+	stream := resp.Stream{
+		Sensor: resp.Sensor{
+			Stages: []resp.ResponseStage{{
+				StageSet: resp.PAZ{
+					Code:  resp.PZFunctionLaplaceHertz,
+					Poles: []complex128{complex(-1.0, 1.0), complex(-1.0, -1.0)},
+					Zeros: []complex128{0, 0},
+				},
+				Frequency:   10.0,
+				Gain:        100.0,
+				InputUnits:  "m/s",
+				OutputUnits: "V",
+			}},
+		},
+		Datalogger: resp.Datalogger{
+			Frequency: 10.0,
+			Stages: []resp.ResponseStage{{
+				StageSet:    resp.A2D{},
+				Gain:        1000.0,
+				InputUnits:  "V",
+				OutputUnits: "count",
+			}},
+		},
+	}
+
+	pz := Reduce(stream)
+
+	if pz.InputUnits != "m" || pz.OutputUnits != "count" || pz.SensitivityUnits != "m/s" {
+		t.Errorf("invalid units: %s -> %s (%s)", pz.InputUnits, pz.OutputUnits, pz.SensitivityUnits)
+	}
+	if len(pz.Zeros) != 3 || len(pz.Poles) != 2 {
+		t.Fatalf("invalid poles and zeros: %v %v", pz.Poles, pz.Zeros)
+	}
+	if p := pz.Poles[0]; cmplx.Abs(p-complex(-2.0*math.Pi, 2.0*math.Pi)) > 1.0e-9 {
+		t.Errorf("invalid pole conversion: %v", p)
+	}
+	if pz.Sensitivity != 100000.0 {
+		t.Errorf("invalid sensitivity: %g", pz.Sensitivity)
+	}
+
+	// the velocity response should have unit amplitude at the normalisation frequency
+	w := complex(0.0, 2.0*math.Pi*10.0)
+	h := complex(pz.A0, 0.0) * w * w / ((w - pz.Poles[0]) * (w - pz.Poles[1]))
+	if math.Abs(cmplx.Abs(h)-1.0) > 1.0e-9 {
+		t.Errorf("invalid normalisation: %g", cmplx.Abs(h))
+	}
+
+	channel := Channel{
+		Network:    "NZ",
+		Station:    "TEST",
+		Location:   "10",
+		Code:       "HHZ",
+		Start:      time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC),
+		End:        time.Date(9999, time.January, 1, 0, 0, 0, 0, time.UTC),
+		Dip:        -90.0,
+		SampleRate: 100.0,
+		PolesZeros: pz,
+
+		nullElevation: true,
+	}
+
+	if s := channel.Filename(); s != "SAC_PZs_NZ_TEST_HHZ_10_2020.001.00.00.00.0000_2599.365.23.59.59.0000" {
+		t.Errorf("invalid filename: %s", s)
+	}
+
+	text := string(channel.Marshal(time.Now()))
+	for _, line := range []string{
+		"* ELEVATION         : -12345.0\n",
+		"* DEPTH             : 0.0\n",
+		"* DIP               : 0.0\n",
+		"* INPUT UNIT        : M\n",
+		"* OUTPUT UNIT       : COUNTS\n",
+		"* SENSITIVITY       : 1.000000e+05 (M/S)\n",
+		"ZEROS\t3\n",
+		"POLES\t2\n",
+	} {
+		if !strings.Contains(text, line) {
+			t.Errorf("missing line: %q", line)
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"flag"
 	"fmt"
 	"github.com/GeoNet/delta/meta"
+	"github.com/GeoNet/delta/metadb"
 	"github.com/GeoNet/kit/sit_delta_pb"
 	"github.com/golang/protobuf/proto"
 	"io/ioutil"
@@ -13,6 +14,28 @@ import (
 	"strings"
 )
 
+// newPoint converts a point, the protobuf encoding leaves out unset values so an unknown elevation
+// is not given rather than being published as zero.
+func newPoint(p meta.Point) *sit_delta_pb.Point {
+	point := sit_delta_pb.Point{
+		Latitude:  p.Latitude,
+		Longitude: p.Longitude,
+		Datum:     p.Datum,
+	}
+	if p.HasElevation() {
+		point.Elevation = p.Elevation
+	}
+	return &point
+}
+
+// height returns the equipment height above ground, an unknown vertical offset is left unset.
+func height(offset meta.Offset) float64 {
+	if !offset.HasVertical() {
+		return 0.0
+	}
+	return -offset.Vertical
+}
+
 func main() {
 
 	//Meta Loading Code grabbed from rinexml
@@ -22,11 +45,8 @@ func main() {
 	var output string
 	flag.StringVar(&output, "output", "output", "output directory")
 
-	var network string
-	flag.StringVar(&network, "network", "../../network", "base network directory")
-
-	var install string
-	flag.StringVar(&install, "install", "../../install", "base install directory")
+	var base string
+	flag.StringVar(&base, "base", "../..", "base of delta files on disk")
 
 	var asset string
 	flag.StringVar(&asset, "asset", "../../assets", "base asset directory")
@@ -49,15 +69,17 @@ func main() {
 
 	//data from 'network' files
 	//List of marks from marks.csv - gps
-	var markList meta.MarkList
-	if err := meta.LoadList(filepath.Join(network, "marks.csv"), &markList); err != nil {
+	db := metadb.NewMetaDB(base)
+
+	markList, err := db.Marks()
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "error: unable to load mark list: %v\n", err)
 		os.Exit(-1)
 	}
 
 	//List of monuments from monuments.csv
-	var monumentList meta.MonumentList
-	if err := meta.LoadList(filepath.Join(network, "monuments.csv"), &monumentList); err != nil {
+	monumentList, err := db.Monuments()
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "error: unable to load monuments list: %v\n", err)
 		os.Exit(-1)
 	}
@@ -68,14 +90,14 @@ func main() {
 
 	//List of mounts from mounts.csv - cameras
 	var mountList meta.MountList
-	if err := meta.LoadList(filepath.Join(network, "mounts.csv"), &mountList); err != nil {
+	if err := meta.LoadList(filepath.Join(base, "network", "mounts.csv"), &mountList); err != nil {
 		fmt.Fprintf(os.Stderr, "error: unable to load mounts list: %v\n", err)
 		os.Exit(-1)
 	}
 
 	//List of stations from stations.csv - seismic AND tsunami
 	var stationList meta.StationList
-	if err := meta.LoadList(filepath.Join(network, "stations.csv"), &stationList); err != nil {
+	if err := meta.LoadList(filepath.Join(base, "network", "stations.csv"), &stationList); err != nil {
 		fmt.Fprintf(os.Stderr, "error: unable to load stations list: %v\n", err)
 		os.Exit(-1)
 	}
@@ -83,18 +105,13 @@ func main() {
 	//List of sites from sites.csv - 'location' for stations
 	var siteList meta.SiteList
 	locations := make(map[string][]*sit_delta_pb.Location)
-	if err := meta.LoadList(filepath.Join(network, "sites.csv"), &siteList); err != nil {
+	if err := meta.LoadList(filepath.Join(base, "network", "sites.csv"), &siteList); err != nil {
 		fmt.Fprintf(os.Stderr, "error: unable to load sites list: %v\n", err)
 		os.Exit(-1)
 	}
 	for _, m := range siteList {
 		locations[m.Station] = append(locations[m.Station], &sit_delta_pb.Location{
-			Point: &sit_delta_pb.Point{
-				Datum:     m.Datum,
-				Elevation: m.Elevation,
-				Latitude:  m.Latitude,
-				Longitude: m.Longitude,
-			},
+			Point:    newPoint(m.Point),
 			Location: m.Location,
 			Span: &sit_delta_pb.Span{
 				Start: m.Start.Unix(),
@@ -130,9 +147,13 @@ func main() {
 	//antennas.csv
 	equipment := make(map[string][]*sit_delta_pb.Equipment_Install)
 	var installedAntennaList meta.InstalledAntennaList
-	if err := meta.LoadList(filepath.Join(install, "antennas.csv"), &installedAntennaList); err != nil {
-		fmt.Fprintf(os.Stderr, "error: unable to load antenna installs: %v\n", err)
-		os.Exit(-1)
+	for _, m := range markList {
+		list, err := db.MarkInstalledAntennas(m.Code)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: unable to load antenna installs: %v\n", err)
+			os.Exit(-1)
+		}
+		installedAntennaList = append(installedAntennaList, list...)
 	}
 	for _, i := range installedAntennaList {
 		equipment[i.Mark] = append(equipment[i.Mark], &sit_delta_pb.Equipment_Install{
@@ -142,7 +163,7 @@ func main() {
 				AssetNumber:  assets[i.Make+i.Model+i.Serial].Number,
 				SerialNumber: i.Serial,
 				Manufacturer: i.Make,
-				Height:       -i.Vertical,
+				Height:       height(i.Offset),
 				Orientation:  i.Azimuth,
 			},
 			Installed: &sit_delta_pb.Span{
@@ -154,7 +175,7 @@ func main() {
 
 	//cameras.csv
 	var installedCameraList meta.InstalledCameraList
-	if err := meta.LoadList(filepath.Join(install, "cameras.csv"), &installedCameraList); err != nil {
+	if err := meta.LoadList(filepath.Join(base, "install", "cameras.csv"), &installedCameraList); err != nil {
 		fmt.Fprintf(os.Stderr, "error: unable to load camera installs: %v\n", err)
 		os.Exit(-1)
 	}
@@ -166,7 +187,7 @@ func main() {
 				AssetNumber:  assets[i.Make+i.Model+i.Serial].Number,
 				SerialNumber: i.Serial,
 				Manufacturer: i.Make,
-				Height:       -i.Vertical,
+				Height:       height(i.Offset),
 			},
 			Installed: &sit_delta_pb.Span{
 				Start: i.Start.Unix(),
@@ -177,7 +198,7 @@ func main() {
 
 	//connections.csv - needed to link a site to a datalogger
 	var connectionList meta.ConnectionList
-	if err := meta.LoadList(filepath.Join(install, "connections.csv"), &connectionList); err != nil {
+	if err := meta.LoadList(filepath.Join(base, "install", "connections.csv"), &connectionList); err != nil {
 		fmt.Fprintf(os.Stderr, "error: unable to load datalogger installs: %v\n", err)
 		os.Exit(-1)
 	}
@@ -200,7 +221,7 @@ func main() {
 
 	//dataloggers.csv
 	var deployedDataloggerList meta.DeployedDataloggerList
-	if err := meta.LoadList(filepath.Join(install, "dataloggers.csv"), &deployedDataloggerList); err != nil {
+	if err := meta.LoadList(filepath.Join(base, "install", "dataloggers.csv"), &deployedDataloggerList); err != nil {
 		fmt.Fprintf(os.Stderr, "error: unable to load datalogger installs: %v\n", err)
 		os.Exit(-1)
 	}
@@ -225,9 +246,13 @@ func main() {
 
 	//metsensors.csv
 	var installedMetsensorList meta.InstalledMetSensorList
-	if err := meta.LoadList(filepath.Join(install, "metsensors.csv"), &installedMetsensorList); err != nil {
-		fmt.Fprintf(os.Stderr, "error: unable to load metsensor installs: %v\n", err)
-		os.Exit(-1)
+	for _, m := range markList {
+		list, err := db.MarkInstalledMetSensors(m.Code)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: unable to load metsensor installs: %v\n", err)
+			os.Exit(-1)
+		}
+		installedMetsensorList = append(installedMetsensorList, list...)
 	}
 	for _, i := range installedMetsensorList {
 		equipment[i.Mark] = append(equipment[i.Mark], &sit_delta_pb.Equipment_Install{
@@ -247,7 +272,7 @@ func main() {
 
 	//recorders.csv
 	var installedRecorderList meta.InstalledRecorderList
-	if err := meta.LoadList(filepath.Join(install, "recorders.csv"), &installedRecorderList); err != nil {
+	if err := meta.LoadList(filepath.Join(base, "install", "recorders.csv"), &installedRecorderList); err != nil {
 		fmt.Fprintf(os.Stderr, "error: unable to load recorder installs: %v\n", err)
 		os.Exit(-1)
 	}
@@ -259,7 +284,7 @@ func main() {
 				AssetNumber:  assets[i.Make+i.Model+i.Serial].Number,
 				SerialNumber: i.Serial,
 				Manufacturer: i.Make,
-				Height:       -i.Vertical,
+				Height:       height(i.Offset),
 				Location:     i.Location,
 				Orientation:  i.Azimuth,
 			},
@@ -269,7 +294,7 @@ func main() {
 			},
 		})
 		for _, l := range locations[i.Station] {
-			if l.Location == i.Location {
+			if l.Location == i.Location && i.HasVertical() {
 				l.GroundRelationship = -i.Vertical
 			}
 		}
@@ -277,9 +302,13 @@ func main() {
 
 	//receivers.csv
 	var deployedReceiverList meta.DeployedReceiverList
-	if err := meta.LoadList(filepath.Join(install, "receivers.csv"), &deployedReceiverList); err != nil {
-		fmt.Fprintf(os.Stderr, "error: unable to load receiver installs: %v\n", err)
-		os.Exit(-1)
+	for _, m := range markList {
+		list, err := db.MarkDeployedReceivers(m.Code)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: unable to load receiver installs: %v\n", err)
+			os.Exit(-1)
+		}
+		deployedReceiverList = append(deployedReceiverList, list...)
 	}
 	for _, i := range deployedReceiverList {
 		equipment[i.Mark] = append(equipment[i.Mark], &sit_delta_pb.Equipment_Install{
@@ -299,9 +328,13 @@ func main() {
 
 	//radomes.csv
 	var installedRadomeList meta.InstalledRadomeList
-	if err := meta.LoadList(filepath.Join(install, "radomes.csv"), &installedRadomeList); err != nil {
-		fmt.Fprintf(os.Stderr, "error: unable to load radome installs: %v\n", err)
-		os.Exit(-1)
+	for _, m := range markList {
+		list, err := db.MarkInstalledRadomes(m.Code)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: unable to load radome installs: %v\n", err)
+			os.Exit(-1)
+		}
+		installedRadomeList = append(installedRadomeList, list...)
 	}
 	for _, i := range installedRadomeList {
 		equipment[i.Mark] = append(equipment[i.Mark], &sit_delta_pb.Equipment_Install{
@@ -321,7 +354,7 @@ func main() {
 
 	//sensors.csv
 	var installedSensorList meta.InstalledSensorList
-	if err := meta.LoadList(filepath.Join(install, "sensors.csv"), &installedSensorList); err != nil {
+	if err := meta.LoadList(filepath.Join(base, "install", "sensors.csv"), &installedSensorList); err != nil {
 		fmt.Fprintf(os.Stderr, "error: unable to load sensor installs: %v\n", err)
 		os.Exit(-1)
 	}
@@ -333,7 +366,7 @@ func main() {
 				AssetNumber:  assets[i.Make+i.Model+i.Serial].Number,
 				SerialNumber: i.Serial,
 				Manufacturer: i.Make,
-				Height:       -i.Vertical,
+				Height:       height(i.Offset),
 				Location:     i.Location,
 				Orientation:  i.Azimuth,
 			},
@@ -343,7 +376,7 @@ func main() {
 			},
 		})
 		for _, l := range locations[i.Station] {
-			if l.Location == i.Location {
+			if l.Location == i.Location && i.HasVertical() {
 				l.GroundRelationship = -i.Vertical
 			}
 		}
@@ -374,12 +407,7 @@ func main() {
 
 		mark := sit_delta_pb.Mark{
 			InstalledMonument: im,
-			Point: &sit_delta_pb.Point{
-				Longitude: m.Longitude,
-				Latitude:  m.Latitude,
-				Elevation: m.Elevation,
-				Datum:     m.Datum,
-			},
+			Point:             newPoint(m.Point),
 		}
 
 		markMap[m.Code] = &m
@@ -412,17 +440,17 @@ func main() {
 			m := stationMap[s]
 			site_pb.Span = &sit_delta_pb.Span{Start: m.Start.Unix(), End: m.End.Unix()}
 			site_pb.Network = m.Network
-			site_pb.Point = &sit_delta_pb.Point{Longitude: m.Longitude, Latitude: m.Latitude, Elevation: m.Elevation, Datum: m.Datum}
+			site_pb.Point = newPoint(m.Point)
 		} else if markMap[s] != nil {
 			m := markMap[s]
 			site_pb.Span = &sit_delta_pb.Span{Start: m.Start.Unix(), End: m.End.Unix()}
 			site_pb.Network = m.Network
-			site_pb.Point = &sit_delta_pb.Point{Longitude: m.Longitude, Latitude: m.Latitude, Elevation: m.Elevation, Datum: m.Datum}
+			site_pb.Point = newPoint(m.Point)
 		} else if mountMap[s] != nil {
 			m := mountMap[s]
 			site_pb.Span = &sit_delta_pb.Span{Start: m.Start.Unix(), End: m.End.Unix()}
 			site_pb.Network = m.Network
-			site_pb.Point = &sit_delta_pb.Point{Longitude: m.Longitude, Latitude: m.Latitude, Elevation: m.Elevation, Datum: m.Datum}
+			site_pb.Point = newPoint(m.Point)
 		}
 
 		b, err := proto.Marshal(&site_pb)
@@ -16,6 +16,7 @@ import (
 	"time"
 
 	"github.com/GeoNet/delta/meta"
+	"github.com/GeoNet/delta/metadb"
 )
 
 //go:generate bash -c "rm -f config_auto.go; go run generate/*.go | gofmt > config_auto.go"
@@ -34,11 +35,8 @@ func main() {
 	var logs string
 	flag.StringVar(&logs, "logs", "logs", "logs output directory")
 
-	var network string
-	flag.StringVar(&network, "network", "../../network", "base network directory")
-
-	var install string
-	flag.StringVar(&install, "install", "../../install", "base install directory")
+	var base string
+	flag.StringVar(&base, "base", "../..", "base of delta files on disk")
 
 	flag.Usage = func() {
 		fmt.Fprintf(os.Stderr, "\n")
@@ -103,128 +101,72 @@ func main() {
 		log.Fatalf("error: unable to compile template: %v", err)
 	}
 
-	var firmwareHistoryList meta.FirmwareHistoryList
-	if err := meta.LoadList(filepath.Join(install, "firmware.csv"), &firmwareHistoryList); err != nil {
-		log.Fatalf("error: unable to load firmware history: %v", err)
-	}
-
-	firmwareHistory := make(map[string]map[string][]meta.FirmwareHistory)
-	for _, i := range firmwareHistoryList {
-		if _, ok := firmwareHistory[i.Model]; !ok {
-			firmwareHistory[i.Model] = make(map[string][]meta.FirmwareHistory)
-		}
-		firmwareHistory[i.Model][i.Serial] = append(firmwareHistory[i.Model][i.Serial], i)
-	}
-
-	for j := range firmwareHistory {
-		for k := range firmwareHistory[j] {
-			sort.Sort(meta.FirmwareHistoryList(firmwareHistory[j][k]))
-		}
-	}
-
-	var installedAntennaList meta.InstalledAntennaList
-	if err := meta.LoadList(filepath.Join(install, "antennas.csv"), &installedAntennaList); err != nil {
-		log.Fatalf("error: unable to load antenna installs: %v", err)
-	}
-
-	installedAntenna := make(map[string][]meta.InstalledAntenna)
-	for _, i := range installedAntennaList {
-		installedAntenna[i.Mark] = append(installedAntenna[i.Mark], i)
-	}
-	for i := range installedAntenna {
-		sort.Sort(meta.InstalledAntennaList(installedAntenna[i]))
-	}
-
-	var deployedReceiverList meta.DeployedReceiverList
-	if err := meta.LoadList(filepath.Join(install, "receivers.csv"), &deployedReceiverList); err != nil {
-		log.Fatalf("error: unable to load receiver installs: %v", err)
-	}
+	db := metadb.NewMetaDB(base)
 
-	deployedReceivers := make(map[string][]meta.DeployedReceiver)
-	for _, i := range deployedReceiverList {
-		deployedReceivers[i.Mark] = append(deployedReceivers[i.Mark], i)
-	}
-	for i := range deployedReceivers {
-		sort.Sort(meta.DeployedReceiverList(deployedReceivers[i]))
-	}
-
-	var installedRadomeList meta.InstalledRadomeList
-	if err := meta.LoadList(filepath.Join(install, "radomes.csv"), &installedRadomeList); err != nil {
-		log.Fatalf("error: unable to load radome installs: %v", err)
-	}
-
-	installedRadomes := make(map[string][]meta.InstalledRadome)
-	for _, i := range installedRadomeList {
-		installedRadomes[i.Mark] = append(installedRadomes[i.Mark], i)
-	}
-	for i := range installedRadomes {
-		sort.Sort(meta.InstalledRadomeList(installedRadomes[i]))
-	}
-
-	var installedMetSensorList meta.InstalledMetSensorList
-	if err := meta.LoadList(filepath.Join(install, "metsensors.csv"), &installedMetSensorList); err != nil {
-		log.Fatalf("error: unable to load metsensors list: %v", err)
-	}
-
-	installedMetSensors := make(map[string][]meta.InstalledMetSensor)
-	for _, i := range installedMetSensorList {
-		installedMetSensors[i.Mark] = append(installedMetSensors[i.Mark], i)
-	}
-	for i := range installedMetSensors {
-		sort.Sort(meta.InstalledMetSensorList(installedMetSensors[i]))
-	}
-
-	var markList meta.MarkList
-	if err := meta.LoadList(filepath.Join(network, "marks.csv"), &markList); err != nil {
+	marks, err := db.Marks()
+	if err != nil {
 		log.Fatalf("error: unable to load mark list: %v", err)
 	}
 
-	var sessionList meta.SessionList
-	if err := meta.LoadList(filepath.Join(install, "sessions.csv"), &sessionList); err != nil {
-		log.Fatalf("error: unable to load session list: %v", err)
-	}
+	for _, m := range marks {
 
-	sessions := make(map[string][]meta.Session)
-	for _, s := range sessionList {
-		sessions[s.Mark] = append(sessions[s.Mark], s)
-	}
-
-	var monumentList meta.MonumentList
-	if err := meta.LoadList(filepath.Join(network, "monuments.csv"), &monumentList); err != nil {
-		log.Fatalf("error: unable to load monument list: %v", err)
-	}
-	monuments := make(map[string]meta.Monument)
-	for _, m := range monumentList {
-		monuments[m.Mark] = m
-	}
-
-	for _, m := range markList {
-
-		if _, ok := monuments[m.Code]; !ok {
+		monument, err := db.Monument(m.Code)
+		if err != nil {
+			log.Fatalf("error: unable to load monument list: %v", err)
+		}
+		if monument == nil {
 			continue
 		}
 
-		if _, ok := sessions[m.Code]; !ok {
+		sessions, err := db.MarkSessions(m.Code)
+		if err != nil {
+			log.Fatalf("error: unable to load session list: %v", err)
+		}
+		if len(sessions) == 0 {
 			continue
 		}
-		if _, ok := installedAntenna[m.Code]; !ok {
+
+		installedAntennas, err := db.MarkInstalledAntennas(m.Code)
+		if err != nil {
+			log.Fatalf("error: unable to load antenna installs: %v", err)
+		}
+		if len(installedAntennas) == 0 {
 			continue
 		}
-		if _, ok := deployedReceivers[m.Code]; !ok {
+		sort.Sort(meta.InstalledAntennaList(installedAntennas))
+
+		deployedReceivers, err := db.MarkDeployedReceivers(m.Code)
+		if err != nil {
+			log.Fatalf("error: unable to load receiver installs: %v", err)
+		}
+		if len(deployedReceivers) == 0 {
 			continue
 		}
+		sort.Sort(meta.DeployedReceiverList(deployedReceivers))
+
+		installedRadomes, err := db.MarkInstalledRadomes(m.Code)
+		if err != nil {
+			log.Fatalf("error: unable to load radome installs: %v", err)
+		}
+		sort.Sort(meta.InstalledRadomeList(installedRadomes))
+
+		installedMetSensors, err := db.MarkInstalledMetSensors(m.Code)
+		if err != nil {
+			log.Fatalf("error: unable to load metsensors list: %v", err)
+		}
+		sort.Sort(meta.InstalledMetSensorList(installedMetSensors))
 
 		var receivers []GnssReceiver
 		var antennas []GnssAntenna
 		var metsensors []GnssMetSensor
 
-		for _, m := range installedMetSensors[m.Reference.Code] {
+		for _, m := range installedMetSensors {
 			var session *meta.Session
-			for i, s := range sessions[m.Mark] {
+			for i, s := range sessions {
 				if m.Start.After(s.End) || m.End.Before(s.Start) {
 					continue
 				}
-				session = &sessions[m.Mark][i]
+				session = &sessions[i]
 				break
 			}
 			if session == nil {
@@ -240,13 +182,13 @@ func main() {
 			})
 		}
 
-		for _, a := range installedAntenna[m.Code] {
+		for _, a := range installedAntennas {
 			var session *meta.Session
-			for i, s := range sessions[m.Code] {
+			for i, s := range sessions {
 				if a.Start.After(s.End) || a.End.Before(s.Start) {
 					continue
 				}
-				session = &sessions[m.Code][i]
+				session = &sessions[i]
 				break
 			}
 			if session == nil {
@@ -255,23 +197,21 @@ func main() {
 
 			radome := "NONE"
 			serial := ""
-			if _, ok := installedRadomes[m.Code]; ok {
-				for _, v := range installedRadomes[m.Code] {
-					if v.Start.After(a.End) || v.End.Before(a.Start) {
-						continue
-					}
-					radome = v.Model
-					serial = v.Serial
+			for _, v := range installedRadomes {
+				if v.Start.After(a.End) || v.End.Before(a.Start) {
+					continue
 				}
+				radome = v.Model
+				serial = v.Serial
 			}
 
 			antennas = append(antennas, GnssAntenna{
 				AntennaType:            a.Model,
 				SerialNumber:           a.Serial,
 				AntennaReferencePoint:  "BAM",
-				MarkerArpUpEcc:         strconv.FormatFloat(a.Vertical, 'f', 4, 64),
-				MarkerArpNorthEcc:      strconv.FormatFloat(a.North, 'f', 4, 64),
-				MarkerArpEastEcc:       strconv.FormatFloat(a.East, 'f', 4, 64),
+				MarkerArpUpEcc:         formatNullable(a.Vertical, a.HasVertical(), 4),
+				MarkerArpNorthEcc:      formatNullable(a.North, a.HasNorth(), 4),
+				MarkerArpEastEcc:       formatNullable(a.East, a.HasEast(), 4),
 				AlignmentFromTrueNorth: "0",
 				AntennaRadomeType:      radome,
 				RadomeSerialNumber:     serial,
@@ -279,112 +219,75 @@ func main() {
 				AntennaCableLength:     "",
 				DateInstalled:          a.Start.Format(DateTimeFormat),
 				DateRemoved: func() string {
-					if time.Now().After(a.End) {
-						return a.End.Format(DateTimeFormat)
-					} else {
+					if a.IsOpen() {
 						return ""
 					}
+					return a.End.Format(DateTimeFormat)
 				}(),
 				Notes: "",
 			})
 		}
 
-		for _, r := range deployedReceivers[m.Code] {
-			if _, ok := firmwareHistory[r.Model]; ok {
-				if _, ok := firmwareHistory[r.Model][r.Serial]; ok {
-					for i := range firmwareHistory[r.Model][r.Serial] {
+		for _, r := range deployedReceivers {
+			firmware, err := db.ReceiverFirmware(r.Model, r.Serial)
+			if err != nil {
+				log.Fatalf("error: unable to load firmware history: %v", err)
+			}
 
-						v := firmwareHistory[r.Model][r.Serial][len(firmwareHistory[r.Model][r.Serial])-i-1]
-						if v.End.Before(r.Start) || v.Start.After(r.End) {
-							continue
-						}
+			// sort a copy, the firmware history is shared with the database
+			history := append([]meta.FirmwareHistory{}, firmware...)
+			sort.Sort(meta.FirmwareHistoryList(history))
 
-						var session *meta.Session
-						for i, s := range sessions[m.Code] {
-							if r.Start.After(s.End) || r.End.Before(s.Start) {
-								continue
-							}
-							if v.Start.After(s.End) || v.End.Before(s.Start) {
-								continue
-							}
-							session = &sessions[m.Code][i]
-							break
-						}
-						if session == nil {
-							continue
-						}
+			for i := range history {
 
-						start := r.Start
-						/*
-							if start.Before(s.Start) {
-								start = s.Start
-							}
-						*/
-						if start.Before(v.Start) {
-							start = v.Start
-						}
-
-						end := r.End
-						/*
-							if end.After(s.End) {
-								end = s.End
-							}
-						*/
-						if end.After(v.End) {
-							end = v.End
-						}
+				v := history[len(history)-i-1]
+				if v.End.Before(r.Start) || v.Start.After(r.End) {
+					continue
+				}
 
-						receivers = append(receivers, GnssReceiver{
-							ReceiverType:           r.Model,
-							SatelliteSystem:        session.SatelliteSystem,
-							SerialNumber:           r.Serial,
-							FirmwareVersion:        v.Version,
-							ElevationCutoffSetting: strconv.FormatFloat(session.ElevationMask, 'g', -1, 64),
-							DateInstalled:          start.Format(DateTimeFormat),
-							/*
-								DateInstalled: func() string {
-									if v.Start.Before(r.Start) {
-										return r.Start.Format(DateTimeFormat)
-									} else {
-										return v.Start.Format(DateTimeFormat)
-									}
-								}(),
-							*/
-							DateRemoved: func() string {
-								/*
-									if v.End.After(r.End) {
-										if time.Now().After(r.End) {
-											return r.End.Format(DateTimeFormat)
-										} else {
-											return ""
-										}
-									} else {
-										if time.Now().After(v.End) {
-											return v.End.Format(DateTimeFormat)
-										} else {
-											return ""
-										}
-									}
-								*/
-								if time.Now().After(end) {
-									return end.Format(DateTimeFormat)
-								} else {
-									return ""
-								}
-							}(),
-							TemperatureStabilization: "",
-							Notes: "",
-						})
+				var session *meta.Session
+				for i, s := range sessions {
+					if r.Start.After(s.End) || r.End.Before(s.Start) {
+						continue
 					}
+					if v.Start.After(s.End) || v.End.Before(s.Start) {
+						continue
+					}
+					session = &sessions[i]
+					break
 				}
+				if session == nil {
+					continue
+				}
+
+				span, ok := r.Span.Intersect(v.Span)
+				if !ok {
+					continue
+				}
+
+				receivers = append(receivers, GnssReceiver{
+					ReceiverType:           r.Model,
+					SatelliteSystem:        session.SatelliteSystem,
+					SerialNumber:           r.Serial,
+					FirmwareVersion:        v.Version,
+					ElevationCutoffSetting: strconv.FormatFloat(session.ElevationMask, 'g', -1, 64),
+					DateInstalled:          span.Start.Format(DateTimeFormat),
+					DateRemoved: func() string {
+						if span.IsOpen() {
+							return ""
+						}
+						return span.End.Format(DateTimeFormat)
+					}(),
+					TemperatureStabilization: "",
+					Notes:                    "",
+				})
 			}
 		}
 
 		sort.Sort(GnssReceivers(receivers))
 		sort.Sort(GnssAntennas(antennas))
 
-		monument := monuments[m.Code]
-
+		// the itrf position depends on the elevation, it is left empty if this is not known
 		X, Y, Z := WGS842ITRF(m.Latitude, m.Longitude, m.Elevation)
 
 		x := SiteLog{
@@ -453,12 +356,12 @@ func main() {
 
 				TectonicPlate: TectonicPlate(m.Latitude, m.Longitude),
 				ApproximatePositionITRF: ApproximatePositionITRF{
-					XCoordinateInMeters: strconv.FormatFloat(X, 'f', 1, 64),
-					YCoordinateInMeters: strconv.FormatFloat(Y, 'f', 1, 64),
-					ZCoordinateInMeters: strconv.FormatFloat(Z, 'f', 1, 64),
+					XCoordinateInMeters: formatNullable(X, m.HasElevation(), 1),
+					YCoordinateInMeters: formatNullable(Y, m.HasElevation(), 1),
+					ZCoordinateInMeters: formatNullable(Z, m.HasElevation(), 1),
 					LatitudeNorth:       strconv.FormatFloat(m.Latitude, 'g', -1, 64),
 					LongitudeEast:       strconv.FormatFloat(m.Longitude, 'g', -1, 64),
-					ElevationMEllips:    strconv.FormatFloat(m.Elevation, 'f', 1, 64),
+					ElevationMEllips:    formatNullable(m.Elevation, m.HasElevation(), 1),
 				},
 				Notes: "",
 			},
@@ -540,3 +443,11 @@ func main() {
 		}
 	}
 }
+
+// formatNullable returns a formatted value, or an empty string if the value is not known.
+func formatNullable(value float64, ok bool, prec int) string {
+	if !ok {
+		return ""
+	}
+	return strconv.FormatFloat(value, 'f', prec, 64)
+}
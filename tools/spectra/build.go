@@ -9,7 +9,7 @@ import (
 	"strings"
 	"time"
 
-	"github.com/GeoNet/delta/internal/metadb"
+	"github.com/GeoNet/delta/metadb"
 	"github.com/GeoNet/delta/resp"
 )
 
@@ -161,7 +161,7 @@ func buildSpectras(cfgs map[string]Config, base, dir string) ([]Spectra, error)
 					continue
 				}
 
-				for _, response := range resp.Streams(installation.Datalogger.Model, installation.Sensor.Model) {
+				for _, response := range installation.Streams() {
 					stream, err := db.StationLocationSamplingRateStartStream(
 						station.Code,
 						installation.Location,
@@ -5,7 +5,7 @@ import (
 	"sort"
 	"time"
 
-	"github.com/GeoNet/delta/internal/metadb"
+	"github.com/GeoNet/delta/metadb"
 	"github.com/GeoNet/delta/resp"
 
 	"github.com/ozym/fdsn/stationxml"
@@ -231,7 +231,7 @@ func (b *Builder) Construct(base string) ([]stationxml.Network, error) {
 				continue
 			}
 
-			for _, response := range resp.Streams(installation.Datalogger.Model, installation.Sensor.Model) {
+			for _, response := range installation.Streams() {
 				stream, err := mdb.StationLocationSamplingRateStartStream(
 					station.Code,
 					installation.Location,
@@ -271,42 +271,7 @@ func (b *Builder) Construct(base string) ([]stationxml.Network, error) {
 
 					channel := lookup[pin]
 					freq := response.Datalogger.Frequency
-					dip := comp.Dip
-					azimuth := installation.Sensor.Azimuth + comp.Azimuth
-
-					// only rotate horizontal components
-					if dip == 0.0 {
-						if response.Sensor.Reversed {
-							azimuth += 180.0
-						}
-						if response.Datalogger.Reversed {
-							azimuth += 180.0
-						}
-						if stream.Reversed {
-							azimuth += 180.0
-						}
-						// avoid negative zero
-						dip = 0.0
-						// bring into positive range
-						for azimuth < 0.0 {
-							azimuth += 360.0
-						}
-						for azimuth >= 360.0 {
-							azimuth -= 360.0
-						}
-					} else {
-						if response.Sensor.Reversed {
-							dip *= -1.0
-						}
-						if response.Datalogger.Reversed {
-							dip *= -1.0
-						}
-						if stream.Reversed {
-							dip *= -1.0
-						}
-						// no azimuth on verticals
-						azimuth = 0.0
-					}
+					azimuth, dip := response.Orientation(comp, installation.Sensor.Azimuth, stream.Reversed)
 
 					tag := fmt.Sprintf(
 						"%s.%s.%s",
@@ -385,7 +350,12 @@ func (b *Builder) Construct(base string) ([]stationxml.Network, error) {
 						BaseNode: stationxml.BaseNode{
 							Code:      channel, //response.Label + string(cha),
 							StartDate: &stationxml.DateTime{installation.Start},
-							EndDate:   &stationxml.DateTime{installation.End},
+							EndDate: func() *stationxml.DateTime {
+								if installation.IsOpen() {
+									return nil
+								}
+								return &stationxml.DateTime{installation.End}
+							}(),
 							RestrictedStatus: func() stationxml.RestrictedStatus {
 								switch network.Restricted {
 								case true:
@@ -394,35 +364,50 @@ func (b *Builder) Construct(base string) ([]stationxml.Network, error) {
 									return stationxml.StatusOpen
 								}
 							}(),
-							Comments: []stationxml.Comment{
-								{
-									Id: 1,
-									Value: func() string {
-										switch location.Survey {
-										case "Unknown":
-											return "Location estimation method is unknown"
-										case "External GPS Device":
-											return "Location estimated from external GPS measurement"
-										case "Internal GPS Clock":
-											return "Location estimated from internal GPS clock"
-										case "Topographic Map":
-											return "Location estimated from topographic map"
-										case "Site Survey":
-											return "Location estimated from plans and survey to mark"
-										default:
-											return "Location estimation method is unknown"
-										}
-									}(),
-								},
-								{
-									Id:    2,
-									Value: "Location is given in " + location.Datum,
-								},
-								{
-									Id:    3,
-									Value: "Sensor orientation not known",
-								},
-							},
+							Comments: func() []stationxml.Comment {
+								comments := []stationxml.Comment{
+									{
+										Id: 1,
+										Value: func() string {
+											switch location.Survey {
+											case "Unknown":
+												return "Location estimation method is unknown"
+											case "External GPS Device":
+												return "Location estimated from external GPS measurement"
+											case "Internal GPS Clock":
+												return "Location estimated from internal GPS clock"
+											case "Topographic Map":
+												return "Location estimated from topographic map"
+											case "Site Survey":
+												return "Location estimated from plans and survey to mark"
+											default:
+												return "Location estimation method is unknown"
+											}
+										}(),
+									},
+									{
+										Id:    2,
+										Value: "Location is given in " + location.Datum,
+									},
+									{
+										Id:    3,
+										Value: "Sensor orientation not known",
+									},
+								}
+								if !location.HasElevation() {
+									comments = append(comments, stationxml.Comment{
+										Id:    stationxml.Counter(len(comments) + 1),
+										Value: "Location elevation not known",
+									})
+								}
+								if !installation.Sensor.HasVertical() {
+									comments = append(comments, stationxml.Comment{
+										Id:    stationxml.Counter(len(comments) + 1),
+										Value: "Sensor depth not known",
+									})
+								}
+								return comments
+							}(),
 						},
 						LocationCode: location.Location,
 						Latitude: stationxml.Latitude{
@@ -441,6 +426,7 @@ func (b *Builder) Construct(base string) ([]stationxml.Network, error) {
 							},
 							Datum: location.Datum,
 						},
+						// stationxml requires both values, unknown values are given as zero and noted in the comments
 						Elevation: stationxml.Distance{Float: stationxml.Float{Value: location.Elevation}},
 						Depth:     stationxml.Distance{Float: stationxml.Float{Value: -installation.Sensor.Vertical}},
 						Azimuth:   &stationxml.Azimuth{Float: stationxml.Float{Value: azimuth}},
@@ -496,10 +482,10 @@ func (b *Builder) Construct(base string) ([]stationxml.Network, error) {
 								return &stationxml.DateTime{installation.Sensor.Start}
 							}(),
 							RemovalDate: func() *stationxml.DateTime {
-								if time.Now().After(installation.Sensor.End) {
-									return &stationxml.DateTime{installation.Sensor.End}
+								if installation.Sensor.IsOpen() {
+									return nil
 								}
-								return nil
+								return &stationxml.DateTime{installation.Sensor.End}
 							}(),
 						},
 
@@ -535,10 +521,10 @@ func (b *Builder) Construct(base string) ([]stationxml.Network, error) {
 								return &stationxml.DateTime{installation.Datalogger.Start}
 							}(),
 							RemovalDate: func() *stationxml.DateTime {
-								if time.Now().After(installation.Datalogger.End) {
-									return &stationxml.DateTime{installation.Datalogger.End}
+								if installation.Datalogger.IsOpen() {
+									return nil
 								}
-								return nil
+								return &stationxml.DateTime{installation.Datalogger.End}
 							}(),
 						},
 						Response: &stationxml.Response{
@@ -610,11 +596,14 @@ func (b *Builder) Construct(base string) ([]stationxml.Network, error) {
 		sort.Sort(Channels(channels))
 
 		start, end := &(stationxml.DateTime{station.Start}), &(stationxml.DateTime{station.End})
+		if station.IsOpen() {
+			end = nil
+		}
 		if b.Installed() && len(channels) > 0 {
 			start, end = channels[0].StartDate, channels[len(channels)-1].EndDate
 		}
 
-		if !b.MatchOperational(end.Time) {
+		if end != nil && !b.MatchOperational(end.Time) {
 			continue
 		}
 
@@ -632,12 +621,21 @@ func (b *Builder) Construct(base string) ([]stationxml.Network, error) {
 				}(),
 				StartDate: start,
 				EndDate:   end,
-				Comments: []stationxml.Comment{
-					{
-						Id:    1,
-						Value: "Location is given in " + station.Datum,
-					},
-				},
+				Comments: func() []stationxml.Comment {
+					comments := []stationxml.Comment{
+						{
+							Id:    1,
+							Value: "Location is given in " + station.Datum,
+						},
+					}
+					if !station.HasElevation() {
+						comments = append(comments, stationxml.Comment{
+							Id:    stationxml.Counter(len(comments) + 1),
+							Value: "Location elevation not known",
+						})
+					}
+					return comments
+				}(),
 			},
 			Latitude: stationxml.Latitude{LatitudeBase: stationxml.LatitudeBase{
 				Float: stationxml.Float{
@@ -647,6 +645,7 @@ func (b *Builder) Construct(base string) ([]stationxml.Network, error) {
 				Float: stationxml.Float{
 					Value: station.Longitude,
 				}}, Datum: station.Datum},
+			// stationxml requires an elevation, an unknown value is given as zero and noted in the comments
 			Elevation: stationxml.Distance{
 				Float: stationxml.Float{Value: station.Elevation},
 			},
@@ -675,7 +674,7 @@ func (b *Builder) Construct(base string) ([]stationxml.Network, error) {
 			},
 			CreationDate: stationxml.DateTime{station.Start},
 			TerminationDate: func() *stationxml.DateTime {
-				if time.Now().Before(station.End) {
+				if station.IsOpen() {
 					return nil
 				}
 				return &stationxml.DateTime{station.End}
@@ -693,6 +692,7 @@ func (b *Builder) Construct(base string) ([]stationxml.Network, error) {
 			continue
 		}
 
+		var open bool
 		var start, end *stationxml.DateTime
 		for _, s := range stationList {
 			if s.BaseNode.StartDate != nil {
@@ -700,12 +700,16 @@ func (b *Builder) Construct(base string) ([]stationxml.Network, error) {
 					start = s.BaseNode.StartDate
 				}
 			}
-			if s.BaseNode.EndDate != nil {
-				if end == nil || s.BaseNode.EndDate.After(end.Time) {
-					end = s.BaseNode.EndDate
-				}
+			switch {
+			case s.BaseNode.EndDate == nil:
+				open = true
+			case end == nil || s.BaseNode.EndDate.After(end.Time):
+				end = s.BaseNode.EndDate
 			}
 		}
+		if open {
+			end = nil
+		}
 		networks = append(networks, stationxml.Network{
 			BaseNode: stationxml.BaseNode{
 				Code:        network.External,
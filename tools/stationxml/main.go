@@ -9,6 +9,8 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/GeoNet/delta/resp"
+
 	"github.com/ozym/fdsn/stationxml"
 )
 
@@ -53,6 +55,9 @@ func main() {
 	var dataloggerRegexp string
 	flag.StringVar(&dataloggerRegexp, "dataloggers", ".*", "regexp selection of dataloggers")
 
+	var responses string
+	flag.StringVar(&responses, "responses", "", "optional response YAML directory to use in place of the compiled responses")
+
 	var installed bool
 	flag.BoolVar(&installed, "installed", false, "set station times based on installation dates")
 
@@ -83,6 +88,14 @@ func main() {
 
 	flag.Parse()
 
+	if responses != "" {
+		lib, err := resp.LoadDir(responses)
+		if err != nil {
+			log.Fatalf("error: unable to load responses %s: %v", responses, err)
+		}
+		resp.SetLibrary(lib)
+	}
+
 	builder, err := NewBuilder(
 		SetInstalled(installed),
 		SetActive(active),
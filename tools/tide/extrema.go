@@ -0,0 +1,116 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/GeoNet/delta/meta"
+	"github.com/GeoNet/delta/tides"
+)
+
+// Water represents a single row of a high and low water table.
+type Water struct {
+	Gauge  string  `json:"gauge"`
+	Time   string  `json:"time"`
+	Height float64 `json:"height"`
+	Type   string  `json:"type"`
+}
+
+// Models holds the tidal prediction models for each gauge.
+type Models map[string]tides.Tide
+
+// tideModel builds a tidal prediction model from the delta gauge and constituent details.
+func tideModel(gauge meta.Gauge, constituents []meta.Constituent) tides.Tide {
+	tide := tides.Tide{
+		Code:      gauge.Code,
+		Network:   gauge.Network,
+		Number:    gauge.Number,
+		TimeZone:  gauge.TimeZone,
+		Latitude:  gauge.Latitude,
+		Longitude: gauge.Longitude,
+		Crex:      gauge.Crex,
+	}
+	for _, c := range constituents {
+		tide.Constituents = append(tide.Constituents, tides.Constituent{
+			Name:      c.Name,
+			Amplitude: c.Amplitude,
+			Lag:       c.Lag,
+		})
+	}
+	return tide
+}
+
+// waters builds the high and low water tables for the matching gauges, times are given in UTC
+// unless local is set, in which case the gauge analysis time zone is used.
+func waters(models Models, gauges *regexp.Regexp, start, end time.Time, local bool) ([]Water, error) {
+	var codes []string
+	for k := range models {
+		if gauges.MatchString(k) {
+			codes = append(codes, k)
+		}
+	}
+	sort.Strings(codes)
+
+	var list []Water
+	for _, code := range codes {
+		tide := models[code]
+
+		// the table dates are taken to be in the gauge time zone if needed
+		loc, from, to := time.UTC, start, end
+		if local {
+			loc = tide.Location()
+			from = time.Date(start.Year(), start.Month(), start.Day(), start.Hour(), start.Minute(), start.Second(), 0, loc)
+			to = time.Date(end.Year(), end.Month(), end.Day(), end.Hour(), end.Minute(), end.Second(), 0, loc)
+		}
+
+		extrema, err := tide.Extrema(from, to)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, e := range extrema {
+			list = append(list, Water{
+				Gauge:  code,
+				Time:   e.Time.In(loc).Format(time.RFC3339),
+				Height: math.Round(e.Height*100.0) / 100.0,
+				Type:   e.Label(),
+			})
+		}
+	}
+
+	return list, nil
+}
+
+// writeWaters outputs the high and low water tables in either csv or json format.
+func writeWaters(w io.Writer, list []Water, format string) error {
+	switch format {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if list == nil {
+			list = []Water{}
+		}
+		return enc.Encode(list)
+	case "csv":
+		cw := csv.NewWriter(w)
+		if err := cw.Write([]string{"Gauge", "Time", "Height", "Type"}); err != nil {
+			return err
+		}
+		for _, v := range list {
+			if err := cw.Write([]string{v.Gauge, v.Time, strconv.FormatFloat(v.Height, 'f', -1, 64), v.Type}); err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+	default:
+		return fmt.Errorf("unknown output format: %s", format)
+	}
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/GeoNet/delta/tides"
+)
+
+func TestWaters(t *testing.T) {
+
+	// a solar semi-diurnal tide has high waters at local midnight and noon
+	models := make(Models)
+	for k, v := range map[string]float64{"AAAA": 360.0, "BBBB": 270.0, "CCCC": 360.0} {
+		models[k] = tides.Tide{
+			Code:     k,
+			TimeZone: v,
+			Constituents: []tides.Constituent{
+				{Name: "Z0", Amplitude: 100.0},
+				{Name: "S2", Amplitude: 10.0},
+			},
+		}
+	}
+
+	start := time.Date(2021, time.June, 1, 3, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+
+	var tests = []struct {
+		name   string
+		local  bool
+		waters []Water
+	}{
+		{"utc", false, []Water{
+			{Gauge: "AAAA", Time: "2021-06-01T06:00:00Z", Height: 90.0, Type: "Low"},
+			{Gauge: "AAAA", Time: "2021-06-01T12:00:00Z", Height: 110.0, Type: "High"},
+			{Gauge: "AAAA", Time: "2021-06-01T18:00:00Z", Height: 90.0, Type: "Low"},
+			{Gauge: "AAAA", Time: "2021-06-02T00:00:00Z", Height: 110.0, Type: "High"},
+			{Gauge: "BBBB", Time: "2021-06-01T06:00:00Z", Height: 110.0, Type: "High"},
+			{Gauge: "BBBB", Time: "2021-06-01T12:00:00Z", Height: 90.0, Type: "Low"},
+			{Gauge: "BBBB", Time: "2021-06-01T18:00:00Z", Height: 110.0, Type: "High"},
+			{Gauge: "BBBB", Time: "2021-06-02T00:00:00Z", Height: 90.0, Type: "Low"},
+		}},
+		{"local", true, []Water{
+			{Gauge: "AAAA", Time: "2021-06-01T06:00:00Z", Height: 90.0, Type: "Low"},
+			{Gauge: "AAAA", Time: "2021-06-01T12:00:00Z", Height: 110.0, Type: "High"},
+			{Gauge: "AAAA", Time: "2021-06-01T18:00:00Z", Height: 90.0, Type: "Low"},
+			{Gauge: "AAAA", Time: "2021-06-02T00:00:00Z", Height: 110.0, Type: "High"},
+			{Gauge: "BBBB", Time: "2021-06-01T06:00:00+06:00", Height: 90.0, Type: "Low"},
+			{Gauge: "BBBB", Time: "2021-06-01T12:00:00+06:00", Height: 110.0, Type: "High"},
+			{Gauge: "BBBB", Time: "2021-06-01T18:00:00+06:00", Height: 90.0, Type: "Low"},
+			{Gauge: "BBBB", Time: "2021-06-02T00:00:00+06:00", Height: 110.0, Type: "High"},
+		}},
+	}
+
+	for _, x := range tests {
+		t.Run(x.name, func(t *testing.T) {
+			list, err := waters(models, regexp.MustCompile("^(AAAA|BBBB)$"), start, end, x.local)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(list, x.waters) {
+				t.Errorf("invalid waters: got %v, expected %v", list, x.waters)
+			}
+		})
+	}
+
+	list, err := waters(models, regexp.MustCompile("^DDDD$"), start, end, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if list != nil {
+		t.Errorf("expected no waters for an unknown gauge: got %v", list)
+	}
+}
+
+func TestWriteWaters(t *testing.T) {
+
+	list := []Water{
+		{Gauge: "AAAA", Time: "2021-06-01T06:00:00Z", Height: 90.5, Type: "Low"},
+		{Gauge: "BBBB", Time: "2021-06-01T12:00:00+06:00", Height: 110.0, Type: "High"},
+	}
+
+	var tests = []struct {
+		format string
+		list   []Water
+		output string
+	}{
+		{"csv", list, "Gauge,Time,Height,Type\nAAAA,2021-06-01T06:00:00Z,90.5,Low\nBBBB,2021-06-01T12:00:00+06:00,110,High\n"},
+		{"csv", nil, "Gauge,Time,Height,Type\n"},
+		{"json", list, `[
+  {
+    "gauge": "AAAA",
+    "time": "2021-06-01T06:00:00Z",
+    "height": 90.5,
+    "type": "Low"
+  },
+  {
+    "gauge": "BBBB",
+    "time": "2021-06-01T12:00:00+06:00",
+    "height": 110,
+    "type": "High"
+  }
+]
+`},
+		{"json", nil, "[]\n"},
+	}
+
+	for _, x := range tests {
+		var buf bytes.Buffer
+		if err := writeWaters(&buf, x.list, x.format); err != nil {
+			t.Fatal(err)
+		}
+		if s := buf.String(); s != x.output {
+			t.Errorf("invalid %s output: got %q, expected %q", x.format, s, x.output)
+		}
+	}
+
+	if err := writeWaters(&bytes.Buffer{}, list, "xml"); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
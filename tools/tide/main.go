@@ -6,11 +6,12 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"regexp"
 	"strings"
 	"text/template"
 	"time"
 
-	"github.com/GeoNet/delta/internal/metadb"
+	"github.com/GeoNet/delta/metadb"
 	"github.com/GeoNet/delta/meta"
 )
 
@@ -36,11 +37,12 @@ func main() {
 
 	flag.Usage = func() {
 		fmt.Fprintf(os.Stderr, "\n")
-		fmt.Fprintf(os.Stderr, "Provide a tidal templating\n")
+		fmt.Fprintf(os.Stderr, "Provide a tidal templating, or high and low water tables\n")
 		fmt.Fprintf(os.Stderr, "\n")
 		fmt.Fprintf(os.Stderr, "Usage:\n")
 		fmt.Fprintf(os.Stderr, "\n")
 		fmt.Fprintf(os.Stderr, "  %s [options] [templates ....]\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -extrema [options]\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "\n")
 		fmt.Fprintf(os.Stderr, "General Options:\n")
 		fmt.Fprintf(os.Stderr, "\n")
@@ -56,14 +58,61 @@ func main() {
 	var footer string
 	flag.StringVar(&footer, "footer", "", "output footer after all templates have been proccessed")
 
+	var extrema bool
+	flag.BoolVar(&extrema, "extrema", false, "output high and low water tables rather than processing templates")
+
+	var selection string
+	flag.StringVar(&selection, "gauges", "[A-Z0-9]+", "regexp selection of gauges for the high and low water tables")
+
+	var start string
+	flag.StringVar(&start, "start", "", "high and low water table start date, defaults to today")
+
+	var end string
+	flag.StringVar(&end, "end", "", "high and low water table end date, defaults to the day after the start date")
+
+	var format string
+	flag.StringVar(&format, "format", "csv", "high and low water table output format, either csv or json")
+
+	var local bool
+	flag.BoolVar(&local, "local", false, "use the gauge analysis time zone for high and low water tables rather than UTC")
+
 	flag.Parse()
 
+	// the dates of any high and low water tables
+	from, to := time.Now().UTC().Truncate(24*time.Hour), time.Time{}
+	if start != "" {
+		t, err := time.Parse("2006-01-02", start)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "invalid start date %s: %v\n", start, err)
+			os.Exit(1)
+		}
+		from = t
+	}
+	to = from.Add(24 * time.Hour)
+	if end != "" {
+		t, err := time.Parse("2006-01-02", end)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "invalid end date %s: %v\n", end, err)
+			os.Exit(1)
+		}
+		to = t
+	}
+
+	match, err := regexp.Compile("^(" + selection + ")$")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid gauge selection %s: %v\n", selection, err)
+		os.Exit(1)
+	}
+
 	// load delta meta helper
 	db := metadb.NewMetaDB(base)
 
 	// build the set of known tidal
 	tides := make(map[string]Tide)
 
+	// and the associated prediction models
+	models := make(Models)
+
 	// recover linz tide gauge sites
 	gauges, err := db.Gauges()
 	if err != nil {
@@ -140,6 +189,8 @@ func main() {
 			}
 		}
 
+		models[gauge.Code] = tideModel(gauge, constituents)
+
 		// remember this tide
 		tides[gauge.Code] = Tide{
 			Gauge:        gauge,
@@ -149,6 +200,19 @@ func main() {
 		}
 	}
 
+	if extrema {
+		list, err := waters(models, match, from, to, local)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "problem building high and low water tables: %v\n", err)
+			os.Exit(1)
+		}
+		if err := writeWaters(os.Stdout, list, format); err != nil {
+			fmt.Fprintf(os.Stderr, "problem writing high and low water tables: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	if header != "" {
 		fmt.Fprintln(os.Stdout, header)
 	}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/GeoNet/delta/meta"
+	"github.com/GeoNet/delta/tides"
+)
+
+// build fits the selected constituents to the observations and returns them as rows suitable for
+// the delta constituents file, amplitudes are given in the observation units. Missing observations
+// are ignored, including when finding the record length.
+func build(gauge string, observations []tides.Observation, names []string, zone, rayleigh float64) (meta.ConstituentList, error) {
+	observations = tides.Valid(observations)
+	if len(observations) < 2 {
+		return nil, fmt.Errorf("not enough observations: %d", len(observations))
+	}
+
+	length := observations[len(observations)-1].Time.Sub(observations[0].Time)
+
+	selected, err := tides.Select(names, length, rayleigh)
+	if err != nil {
+		return nil, err
+	}
+
+	constituents, err := tides.Analyse(observations, selected, zone)
+	if err != nil {
+		return nil, err
+	}
+
+	var list meta.ConstituentList
+	for i, c := range constituents {
+		list = append(list, meta.Constituent{
+			Gauge:     gauge,
+			Number:    i + 1,
+			Name:      c.Name,
+			Amplitude: math.Round(c.Amplitude*10000.0) / 10000.0,
+			Lag:       math.Round(c.Lag*100.0) / 100.0,
+		})
+	}
+
+	return list, nil
+}
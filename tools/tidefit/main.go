@@ -0,0 +1,86 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/GeoNet/delta/meta"
+	"github.com/GeoNet/delta/tides"
+)
+
+func main() {
+
+	var gauge string
+	flag.StringVar(&gauge, "gauge", "", "gauge code to use for the output constituents")
+
+	var input string
+	flag.StringVar(&input, "input", "", "csv file of observation times and sea levels, defaults to standard input")
+
+	var zone float64
+	flag.Float64Var(&zone, "zone", 180.0, "analysis time zone, as given in the gauges file")
+
+	var constituents string
+	flag.StringVar(&constituents, "constituents", strings.Join(tides.Standard, ","), "constituents to fit, in order of importance")
+
+	var rayleigh float64
+	flag.Float64Var(&rayleigh, "rayleigh", 1.0, "rayleigh criterion factor used to select resolvable constituents")
+
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "\n")
+		fmt.Fprintf(os.Stderr, "Estimate tidal constituents from a time series of sea level observations\n")
+		fmt.Fprintf(os.Stderr, "\n")
+		fmt.Fprintf(os.Stderr, "Usage:\n")
+		fmt.Fprintf(os.Stderr, "\n")
+		fmt.Fprintf(os.Stderr, "  %s [options]\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fmt.Fprintf(os.Stderr, "\n")
+		flag.PrintDefaults()
+		fmt.Fprintf(os.Stderr, "\n")
+	}
+
+	flag.Parse()
+
+	if gauge == "" {
+		fmt.Fprintf(os.Stderr, "a gauge code must be given\n")
+		os.Exit(1)
+	}
+
+	var rd io.Reader = os.Stdin
+	if input != "" {
+		file, err := os.Open(input)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "problem opening input file %s: %v\n", input, err)
+			os.Exit(1)
+		}
+		defer file.Close()
+		rd = file
+	}
+
+	observations, err := tides.ReadObservations(rd)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "problem reading observations: %v\n", err)
+		os.Exit(1)
+	}
+
+	var names []string
+	for _, n := range strings.Split(constituents, ",") {
+		if n = strings.ToUpper(strings.TrimSpace(n)); n != "" {
+			names = append(names, n)
+		}
+	}
+
+	list, err := build(gauge, observations, names, zone, rayleigh)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "problem fitting constituents for %s: %v\n", gauge, err)
+		os.Exit(1)
+	}
+
+	if _, err := os.Stdout.Write(meta.MarshalList(list)); err != nil {
+		fmt.Fprintf(os.Stderr, "problem writing constituents: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/GeoNet/delta/tides"
+)
+
+func TestBuild(t *testing.T) {
+
+	tide := tides.Tide{
+		Code:     "TEST",
+		TimeZone: 180.0,
+		Constituents: []tides.Constituent{
+			{Name: "Z0", Amplitude: 186.2448},
+			{Name: "K1", Amplitude: 7.033, Lag: 175.54},
+			{Name: "M2", Amplitude: 114.3039, Lag: 204.7},
+			{Name: "S2", Amplitude: 17.2, Lag: 280.11},
+		},
+	}
+
+	start := time.Date(2021, time.June, 1, 0, 0, 0, 0, time.UTC)
+	predictions, err := tide.PredictRange(start, start.Add(30*24*time.Hour), time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// include a missing value
+	var observations []tides.Observation
+	for i, p := range predictions {
+		if i == 100 {
+			observations = append(observations, tides.Observation{Time: p.Time, Value: math.NaN()})
+			continue
+		}
+		observations = append(observations, tides.Observation{Time: p.Time, Value: p.Height})
+	}
+
+	// missing values at the end should not extend the record length used for selection
+	for i := 1; i <= 365; i++ {
+		observations = append(observations, tides.Observation{Time: start.Add(time.Duration(30+i) * 24 * time.Hour), Value: math.NaN()})
+	}
+
+	// K2 cannot be separated from S2 over a month
+	list, err := build("TEST", observations, []string{"Z0", "M2", "S2", "K1", "K2"}, tide.TimeZone, 1.0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != len(tide.Constituents) {
+		t.Fatalf("invalid number of constituents: got %d, expected %d", len(list), len(tide.Constituents))
+	}
+
+	for i, c := range tide.Constituents {
+		v := list[i]
+		if v.Gauge != "TEST" || v.Number != i+1 || v.Name != c.Name {
+			t.Errorf("invalid constituent %d: %v", i, v)
+		}
+		if v.Amplitude != c.Amplitude || v.Lag != c.Lag {
+			t.Errorf("invalid %s fit: got %g/%g, expected %g/%g", c.Name, v.Amplitude, v.Lag, c.Amplitude, c.Lag)
+		}
+	}
+}